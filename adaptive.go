@@ -0,0 +1,42 @@
+package termenv
+
+// AdaptiveColor is a color with a variant for light and one for dark
+// terminal backgrounds, so an application doesn't need two palettes. Light
+// and Dark are hex colors or ANSI color codes (0-255), as accepted by
+// Profile.Color.
+//
+// Output.ConvertColor and the Styles created by Output.String pick the
+// variant matching the background of that output. Converting and rendering
+// without an output doesn't query the terminal, so everywhere else, e.g. in
+// Profile.ConvertColor and Sequence, the Dark variant is used; Resolve picks
+// a variant explicitly.
+type AdaptiveColor struct {
+	Light string
+	Dark  string
+}
+
+// Sequence returns the ANSI sequence for the Dark variant.
+func (c AdaptiveColor) Sequence(bg bool) string {
+	if v := c.Resolve(true); v != nil {
+		return v.Sequence(bg)
+	}
+	return ""
+}
+
+// Resolve returns the Dark variant for dark backgrounds, and the Light
+// variant otherwise. It returns nil if the variant is not a valid color.
+func (c AdaptiveColor) Resolve(dark bool) Color {
+	if dark {
+		return TrueColor.Color(c.Dark)
+	}
+	return TrueColor.Color(c.Light)
+}
+
+// adapt converts an AdaptiveColor with the Style's output, picking the
+// variant matching its background. Other colors are returned as is.
+func (t Style) adapt(c Color) Color {
+	if _, ok := c.(AdaptiveColor); ok && t.out != nil {
+		return t.out.ConvertColor(c)
+	}
+	return c
+}
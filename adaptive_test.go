@@ -0,0 +1,57 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAdaptiveColor(t *testing.T) {
+	c := AdaptiveColor{Light: "#000000", Dark: "#ffffff"}
+
+	light := NewOutput(&bytes.Buffer{}, WithProfile(TrueColor))
+	light.bgColor = RGBColor("#fafafa")
+	dark := NewOutput(&bytes.Buffer{}, WithProfile(ANSI256))
+	dark.bgColor = RGBColor("#101010")
+
+	if got := light.ConvertColor(c); got != RGBColor("#000000") {
+		t.Errorf("expected the light variant, got %v", got)
+	}
+	if got := dark.ConvertColor(c); got != ANSI256Color(231) {
+		t.Errorf("expected the dark variant converted to ANSI256, got %v", got)
+	}
+
+	// conversions without an output use the dark variant
+	if got, exp := c.Sequence(false), "38;2;255;255;255"; got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+	if got, exp := TrueColor.String("x").Foreground(c).String(), "\x1b[38;2;255;255;255mx\x1b[0m"; got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+	if got := ANSI.ConvertColor(c); got != ANSIColor(15) {
+		t.Errorf("expected the dark variant converted to ANSI, got %v", got)
+	}
+	if got := ConvertToRGB(c).Hex(); got != "#ffffff" {
+		t.Errorf("expected #ffffff, got %s", got)
+	}
+	if got := c.Resolve(false); got != RGBColor("#000000") {
+		t.Errorf("expected the light variant, got %v", got)
+	}
+
+	// styles created for an output use its background
+	if got, exp := light.String("x").Foreground(c).String(), "\x1b[38;2;0;0;0mx\x1b[0m"; got != exp {
+		t.Errorf("expected the light variant, %q, got %q", exp, got)
+	}
+	if got, exp := light.String("x").Background(c).String(), "\x1b[48;2;0;0;0mx\x1b[0m"; got != exp {
+		t.Errorf("expected the light variant, %q, got %q", exp, got)
+	}
+	if got, exp := StringFor(light, "x").UnderlineColor(c).String(), "\x1b[58;2;0;0;0mx\x1b[0m"; got != exp {
+		t.Errorf("expected the light variant, %q, got %q", exp, got)
+	}
+	if got, exp := dark.String("x").Foreground(c).String(), "\x1b[38;5;231mx\x1b[0m"; got != exp {
+		t.Errorf("expected the dark variant, %q, got %q", exp, got)
+	}
+
+	if got := (AdaptiveColor{Light: "1"}).Sequence(true); got != "" {
+		t.Errorf("expected no sequence for a missing variant, got %q", got)
+	}
+}
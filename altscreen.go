@@ -0,0 +1,52 @@
+package termenv
+
+import "sync"
+
+// altScreen tracks how often the alternate screen buffer has been entered,
+// so nested AltScreen calls don't switch buffers again.
+type altScreen struct {
+	mu    sync.Mutex
+	depth int
+}
+
+// enter records entering the alternate screen and returns whether the
+// buffer needs to be switched.
+func (a *altScreen) enter() bool {
+	if a == nil {
+		return true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.depth++
+	return a.depth == 1
+}
+
+// exit records leaving the alternate screen and returns whether the buffer
+// needs to be switched back. Without a matching enter the buffer is switched
+// anyway, as it may have been entered by someone else, e.g. a crashed
+// program.
+func (a *altScreen) exit() bool {
+	if a == nil {
+		return true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.depth > 1 {
+		a.depth--
+		return false
+	}
+	a.depth = 0
+	return true
+}
+
+func (a *altScreen) active() bool {
+	if a == nil {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.depth > 0
+}
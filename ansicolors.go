@@ -1,5 +1,7 @@
 package termenv
 
+import "github.com/lucasb-eyer/go-colorful"
+
 // ANSI color codes.
 const (
 	ANSIBlack ANSIColor = iota
@@ -279,3 +281,24 @@ var ansiHex = []string{
 	"#e4e4e4",
 	"#eeeeee",
 }
+
+// ANSI256Palette holds the RGB values of the 256 ANSI colors, as defined by
+// xterm: the 16 basic colors, the 6×6×6 color cube and the grayscale ramp.
+var ANSI256Palette = func() [256]RGBColor {
+	var p [256]RGBColor
+	for i := range p {
+		p[i] = RGBColor(ansiHex[i])
+	}
+	return p
+}()
+
+// ansiRGB holds the RGB values of the 256 ANSI colors as integers.
+var ansiRGB = func() [256][3]uint8 {
+	var t [256][3]uint8
+	for i, hex := range ansiHex {
+		c, _ := colorful.Hex(hex)
+		r, g, b := c.RGB255()
+		t[i] = [3]uint8{r, g, b}
+	}
+	return t
+}()
@@ -0,0 +1,169 @@
+package termenv
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BidiPolicy controls how bidirectional control characters in styled text are
+// handled. Unbalanced embeddings and overrides reorder everything following
+// them on the line, which corrupts aligned layouts.
+type BidiPolicy int
+
+const (
+	// BidiPassThrough leaves bidi control characters untouched.
+	BidiPassThrough BidiPolicy = iota
+	// BidiStrip removes all bidi control characters.
+	BidiStrip
+	// BidiIsolate closes all unterminated embeddings, overrides, and isolates,
+	// drops pop directional isolates without a matching isolate, and wraps
+	// the text in a first strong isolate, so it can't affect the surrounding
+	// text.
+	BidiIsolate
+)
+
+// CombiningPolicy controls how combining marks in styled text are handled.
+type CombiningPolicy int
+
+const (
+	// CombiningPassThrough leaves combining marks untouched.
+	CombiningPassThrough CombiningPolicy = iota
+	// CombiningStrip removes all combining marks.
+	CombiningStrip
+	// CombiningIsolate places a no-break space before a combining mark at
+	// the start of the text, so it doesn't combine with the preceding
+	// character on screen.
+	CombiningIsolate
+)
+
+// Bidi control characters.
+const (
+	bidiLRE = '\u202a'
+	bidiRLE = '\u202b'
+	bidiPDF = '\u202c'
+	bidiLRO = '\u202d'
+	bidiRLO = '\u202e'
+	bidiLRI = '\u2066'
+	bidiRLI = '\u2067'
+	bidiFSI = '\u2068'
+	bidiPDI = '\u2069'
+)
+
+// isBidiControl returns whether r is a bidi control character.
+func isBidiControl(r rune) bool {
+	switch r {
+	case '\u061c', '\u200e', '\u200f':
+		return true
+	}
+	return (r >= bidiLRE && r <= bidiRLO) || (r >= bidiLRI && r <= bidiPDI)
+}
+
+// Bidi makes the Style handle bidi control characters according to p.
+func (t Style) Bidi(p BidiPolicy) Style {
+	t.bidi = p
+	return t
+}
+
+// Combining makes the Style handle combining marks according to p.
+func (t Style) Combining(p CombiningPolicy) Style {
+	t.combining = p
+	return t
+}
+
+// ApplyBidiPolicy returns s with its bidi control characters handled
+// according to p.
+func ApplyBidiPolicy(s string, p BidiPolicy) string {
+	switch p {
+	case BidiStrip:
+		return strings.Map(func(r rune) rune {
+			if isBidiControl(r) {
+				return -1
+			}
+			return r
+		}, s)
+
+	case BidiIsolate:
+		if s == "" {
+			return s
+		}
+
+		var b strings.Builder
+		b.WriteRune(bidiFSI)
+
+		// track open embeddings/overrides and isolates, so they can be
+		// closed in the right order
+		var open []rune
+		last := 0
+		for i, r := range s {
+			switch r {
+			case bidiLRE, bidiRLE, bidiLRO, bidiRLO:
+				open = append(open, bidiPDF)
+			case bidiLRI, bidiRLI, bidiFSI:
+				open = append(open, bidiPDI)
+			case bidiPDF:
+				if len(open) > 0 && open[len(open)-1] == bidiPDF {
+					open = open[:len(open)-1]
+				}
+			case bidiPDI:
+				// a PDI closes the last isolate and all embeddings within
+				// it. Without an open isolate it would close the wrapping
+				// isolate, so it gets dropped.
+				j := len(open) - 1
+				for j >= 0 && open[j] != bidiPDI {
+					j--
+				}
+				if j < 0 {
+					b.WriteString(s[last:i])
+					last = i + utf8.RuneLen(r)
+					continue
+				}
+				open = open[:j]
+			}
+		}
+		b.WriteString(s[last:])
+
+		for i := len(open) - 1; i >= 0; i-- {
+			b.WriteRune(open[i])
+		}
+		b.WriteRune(bidiPDI)
+		return b.String()
+	}
+
+	return s
+}
+
+// ApplyCombiningPolicy returns s with its combining marks handled according
+// to p.
+func ApplyCombiningPolicy(s string, p CombiningPolicy) string {
+	switch p {
+	case CombiningStrip:
+		return strings.Map(func(r rune) rune {
+			if unicode.In(r, unicode.Mn, unicode.Me) {
+				return -1
+			}
+			return r
+		}, s)
+
+	case CombiningIsolate:
+		if r, _ := utf8.DecodeRuneInString(s); unicode.In(r, unicode.Mn, unicode.Me) {
+			return "\u00a0" + s
+		}
+	}
+
+	return s
+}
+
+// applyTextPolicies applies the style's text handling options to s.
+func (t Style) applyTextPolicies(s string) string {
+	if t.tabs > 0 {
+		s = ExpandTabs(s, t.tabs)
+	}
+	if t.bidi != BidiPassThrough {
+		s = ApplyBidiPolicy(s, t.bidi)
+	}
+	if t.combining != CombiningPassThrough {
+		s = ApplyCombiningPolicy(s, t.combining)
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestApplyBidiPolicy(t *testing.T) {
+	tests := []struct {
+		in       string
+		policy   BidiPolicy
+		expected string
+	}{
+		{"a\u202eb", BidiPassThrough, "a\u202eb"},
+		{"a\u202eb\u200f\u2067c\u2069", BidiStrip, "abc"},
+		{"", BidiIsolate, ""},
+		{"abc", BidiIsolate, "\u2068abc\u2069"},
+		{"a\u202eb", BidiIsolate, "\u2068a\u202eb\u202c\u2069"},
+		{"a\u202eb\u202cc", BidiIsolate, "\u2068a\u202eb\u202cc\u2069"},
+		{"\u2067a\u202bb", BidiIsolate, "\u2068\u2067a\u202bb\u202c\u2069\u2069"},
+		{"\u2067a\u202bb\u2069", BidiIsolate, "\u2068\u2067a\u202bb\u2069\u2069"},
+		{"\u202ba\u2069b", BidiIsolate, "\u2068\u202bab\u202c\u2069"},
+		{"a\u2069\u202eb", BidiIsolate, "\u2068a\u202eb\u202c\u2069"},
+		{"\u2067a\u2069\u2069b\xff", BidiIsolate, "\u2068\u2067a\u2069b\xff\u2069"},
+	}
+
+	for _, test := range tests {
+		if got := ApplyBidiPolicy(test.in, test.policy); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestApplyCombiningPolicy(t *testing.T) {
+	tests := []struct {
+		in       string
+		policy   CombiningPolicy
+		expected string
+	}{
+		{"e\u0301", CombiningPassThrough, "e\u0301"},
+		{"e\u0301", CombiningStrip, "e"},
+		{"\u0301e", CombiningIsolate, "\u00a0\u0301e"},
+		{"e\u0301", CombiningIsolate, "e\u0301"},
+	}
+
+	for _, test := range tests {
+		if got := ApplyCombiningPolicy(test.in, test.policy); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestStyleTextPolicies(t *testing.T) {
+	s := String("\u0301ab").Combining(CombiningIsolate)
+	if s.Width() != 3 {
+		t.Errorf("expected width of 3, got %d", s.Width())
+	}
+
+	s = String().Bold().Bidi(BidiStrip)
+	exp := "\x1b[1mab\x1b[0m"
+	if got := s.Styled("a\u202eb"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
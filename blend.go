@@ -0,0 +1,70 @@
+package termenv
+
+import (
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Blend returns the color t of the way from a to b, with t from 0 to 1,
+// interpolated in the CIE L*a*b* color space. The result is an RGBColor,
+// which can be converted to any profile. If either color is nil or NoColor,
+// the other one is returned.
+func Blend(a, b Color, t float64) Color {
+	ca, okA := colorfulColor(a)
+	cb, okB := colorfulColor(b)
+	switch {
+	case !okA:
+		return b
+	case !okB:
+		return a
+	}
+	return RGBColor(ca.BlendLab(cb, clampFloat(t, 0, 1)).Clamped().Hex())
+}
+
+// Lighten returns c with its lightness increased by pct of the way to
+// white, with pct from 0 to 1, e.g. to derive a hover variant of a color.
+// The lightness is changed in the HSLuv color space, which keeps the hue and
+// saturation. The result is an RGBColor; nil and NoColor are returned
+// unchanged.
+func Lighten(c Color, pct float64) Color {
+	cc, ok := colorfulColor(c)
+	if !ok {
+		return c
+	}
+	h, s, l := cc.HSLuv()
+	l += (1 - l) * clampFloat(pct, 0, 1)
+	return RGBColor(colorful.HSLuv(h, s, l).Hex())
+}
+
+// Darken returns c with its lightness decreased by pct of the way to black,
+// with pct from 0 to 1, e.g. to derive a disabled variant of a color. Like
+// Lighten, it works in the HSLuv color space.
+func Darken(c Color, pct float64) Color {
+	cc, ok := colorfulColor(c)
+	if !ok {
+		return c
+	}
+	h, s, l := cc.HSLuv()
+	l -= l * clampFloat(pct, 0, 1)
+	return RGBColor(colorful.HSLuv(h, s, l).Hex())
+}
+
+// colorfulColor returns c as a colorful.Color, and whether c is an actual
+// color.
+func colorfulColor(c Color) (colorful.Color, bool) {
+	c = resolveColor(c)
+	switch c.(type) {
+	case ANSIColor, ANSI256Color, RGBColor:
+		return ConvertToRGB(c), true
+	}
+	return colorful.Color{}, false
+}
+
+func clampFloat(v, lo, hi float64) float64 {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,60 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestBlend(t *testing.T) {
+	red, blue := RGBColor("#ff0000"), RGBColor("#0000ff")
+	tests := []struct {
+		name     string
+		a, b     Color
+		t        float64
+		expected Color
+	}{
+		{"start", red, blue, 0, red},
+		{"end", red, blue, 1, blue},
+		{"middle", RGBColor("#000000"), RGBColor("#ffffff"), 0.5, RGBColor("#777777")},
+		{"clamped", red, blue, 2, blue},
+		{"ansi", ANSIColor(0), ANSI256Color(231), 1, RGBColor("#ffffff")},
+		{"no color", nil, blue, 0.5, blue},
+		{"no color b", red, NoColor{}, 0.5, red},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Blend(test.a, test.b, test.t); got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestLightenDarken(t *testing.T) {
+	red := RGBColor("#ff0000")
+	if got := Lighten(red, 1); got != RGBColor("#ffffff") {
+		t.Errorf("expected white, got %v", got)
+	}
+	if got := Darken(red, 1); got != RGBColor("#000000") {
+		t.Errorf("expected black, got %v", got)
+	}
+	if got := Lighten(red, 0); got != red {
+		t.Errorf("expected %v, got %v", red, got)
+	}
+
+	light, dark := ConvertToRGB(Lighten(red, 0.3)), ConvertToRGB(Darken(red, 0.3))
+	_, _, l := ConvertToRGB(red).HSLuv()
+	if _, _, ll := light.HSLuv(); ll <= l {
+		t.Errorf("expected %v to be lighter than %v", light.Hex(), red)
+	}
+	if _, _, dl := dark.HSLuv(); dl >= l {
+		t.Errorf("expected %v to be darker than %v", dark.Hex(), red)
+	}
+
+	if got := Lighten(nil, 0.5); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+	if got := Darken(NoColor{}, 0.5); got != (NoColor{}) {
+		t.Errorf("expected NoColor, got %v", got)
+	}
+}
@@ -0,0 +1,58 @@
+package termenv
+
+import "strings"
+
+// lineMode controls how a Style renders strings spanning multiple lines.
+type lineMode int
+
+const (
+	lineModeNone lineMode = iota
+	lineModeLines
+	lineModeBlock
+)
+
+// Lines makes the Style close its attributes before and re-apply them after
+// every newline, so each line is styled on its own. This prevents background
+// colors from bleeding to the end of the line, and keeps lines styled when
+// they get printed separately, e.g. by a pager.
+func (t Style) Lines() Style {
+	t.lines = lineModeLines
+	return t
+}
+
+// Block styles every line on its own like Lines, and pads all lines with
+// spaces to the width of the widest line, so background colors form a
+// rectangular block.
+func (t Style) Block() Style {
+	t.lines = lineModeBlock
+	return t
+}
+
+// styledLines renders every line of s with the style sequence seq.
+func (t Style) styledLines(seq, s string) string {
+	lines := strings.Split(s, "\n")
+
+	var width int
+	if t.lines == lineModeBlock {
+		for _, l := range lines {
+			if w := StringWidth(l); w > width {
+				width = w
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if t.lines == lineModeBlock {
+			l += strings.Repeat(" ", width-StringWidth(l))
+		}
+		if l == "" {
+			continue
+		}
+		b.WriteString(CSI + seq + "m" + l + CSI + t.reset() + "m")
+	}
+	return b.String()
+}
@@ -0,0 +1,34 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestStyleLines(t *testing.T) {
+	s := String().Background(ANSI.Color("4"))
+
+	exp := "\x1b[44mfoo\x1b[0m\n\n\x1b[44mbar\x1b[0m"
+	if got := s.Lines().Styled("foo\n\nbar"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	exp = "\x1b[44mfoo\nbar\x1b[0m"
+	if got := s.Styled("foo\nbar"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
+
+func TestStyleBlock(t *testing.T) {
+	s := String().Background(ANSI.Color("4")).Block()
+
+	exp := "\x1b[44mfoo   \x1b[0m\n\x1b[44m      \x1b[0m\n\x1b[44mbarbaz\x1b[0m"
+	if got := s.Styled("foo\n\nbarbaz"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	// escape sequences don't count towards the width
+	exp = "\x1b[44mfoo\x1b[0m\n\x1b[44m\x1b[1mab\x1b[0m \x1b[0m"
+	if got := s.Styled("foo\n" + String().Bold().Styled("ab")); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
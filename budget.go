@@ -0,0 +1,66 @@
+package termenv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBudgetExceeded gets returned when a frame exceeds its budget.
+var ErrBudgetExceeded = errors.New("frame budget exceeded")
+
+// Budget limits how much a single frame may write. Zero values are not
+// limited.
+type Budget struct {
+	Bytes        int64
+	SGRSequences int64
+}
+
+// FrameGuard checks that every frame rendered to an Output stays within a
+// budget, catching accidental blowups like quadratic styling in renderers.
+type FrameGuard struct {
+	o      *Output
+	budget Budget
+	report func(error)
+	start  Stats
+}
+
+// GuardFrames returns a new FrameGuard for the output, enabling its stats if
+// necessary. Every frame exceeding the budget gets passed to report, e.g.
+// log.Println, or t.Error in tests. report may be nil.
+func (o *Output) GuardFrames(b Budget, report func(error)) *FrameGuard {
+	if o.stats == nil {
+		WithStats()(o)
+	}
+	return &FrameGuard{
+		o:      o,
+		budget: b,
+		report: report,
+	}
+}
+
+// Begin marks the start of a frame.
+func (g *FrameGuard) Begin() {
+	g.start = g.o.Stats()
+}
+
+// End marks the end of a frame and checks it against the budget. It returns
+// an error wrapping ErrBudgetExceeded if the frame exceeded its budget.
+func (g *FrameGuard) End() error {
+	s := g.o.Stats()
+	bytes := s.Bytes - g.start.Bytes
+	seqs := s.SGRSequences - g.start.SGRSequences
+
+	var err error
+	switch {
+	case g.budget.Bytes > 0 && bytes > g.budget.Bytes:
+		err = fmt.Errorf("%w: %d bytes written, budget is %d", ErrBudgetExceeded, bytes, g.budget.Bytes)
+	case g.budget.SGRSequences > 0 && seqs > g.budget.SGRSequences:
+		err = fmt.Errorf("%w: %d SGR sequences written, budget is %d", ErrBudgetExceeded, seqs, g.budget.SGRSequences)
+	}
+
+	if err != nil && g.report != nil {
+		g.report(err)
+	}
+	g.start = s
+	return err
+}
@@ -0,0 +1,38 @@
+package termenv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFrameGuard(t *testing.T) {
+	o := tempOutput(t)
+
+	var reported []error
+	g := o.GuardFrames(Budget{Bytes: 64, SGRSequences: 2}, func(err error) {
+		reported = append(reported, err)
+	})
+
+	g.Begin()
+	_, _ = o.WriteString(o.String("foo").Bold().String())
+	if err := g.End(); err != nil {
+		t.Errorf("expected frame within budget, got %v", err)
+	}
+
+	g.Begin()
+	_, _ = o.WriteString(strings.Repeat(o.String("x").Bold().String(), 2))
+	if err := g.End(); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", err)
+	}
+
+	g.Begin()
+	_, _ = o.WriteString(strings.Repeat("x", 65))
+	if err := g.End(); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", err)
+	}
+
+	if len(reported) != 2 {
+		t.Errorf("expected 2 reported frames, got %d", len(reported))
+	}
+}
@@ -2,7 +2,8 @@ package termenv
 
 import (
 	"sync"
-	"sync/atomic"
+
+	"github.com/lucasb-eyer/go-colorful"
 )
 
 // init creates the RGB cache singletons
@@ -12,136 +13,163 @@ func init() {
 }
 
 var (
-	ansiCache,
-	sRGBCache *RGBCache
+	ansiCache *Cache[RGBColor, string]
+	sRGBCache *Cache[RGBColor, colorful.Color]
 	ansiCacheInit,
 	sRGBCacheInit sync.Once
 )
 
 // GetANSICache returns the global RGBColor->ANSI sequence cache instance.
 // For use by Style.Foreground, this cache maps RGBColor's to ANSI sequences
-func GetANSICache() *RGBCache {
+func GetANSICache() *Cache[RGBColor, string] {
 	ansiCacheInit.Do(func() {
-		ansiCache = NewRGBCache(20)
+		ansiCache = NewCache[RGBColor, string](20)
 	})
 	return ansiCache
 }
 
 // GetSRGBCache returns the global RGBColor->sRGB cache instance.
 // For use by Style.Styled, this cache maps RGBColor's to colorful.Color structs (stores sRGB data)
-func GetSRGBCache() *RGBCache {
+func GetSRGBCache() *Cache[RGBColor, colorful.Color] {
 	sRGBCacheInit.Do(func() {
-		sRGBCache = NewRGBCache(20)
+		sRGBCache = NewCache[RGBColor, colorful.Color](20)
 	})
 	return sRGBCache
 }
 
-// RGBCache caches computed data given an RGBColor.
+// Cache caches computed data, evicting the least recently used entry once it
+// is full.
 // I added this because my TUI application renders markdown text with glamour (which calls funcs in this package)
 // many times per second over and over again. Since this is the main functionality of my TUI, I profiled this feature
 // and there were 3 funcs in termenv that were using much of the CPU time. Once I realized that my TUI would only ever
 // need a fixed number of terminal colors/styles (computed by this package every time glamour renders markdown), I figured
 // I'd create a cache for these. These caches (and one other perf tweak) led to almost a 2x reduction in CPU time for
 // the code-path I was targeting, and a 5x speedup in the direct callee of these termenv functions I modified.
-type RGBCache struct {
-	data sync.Map
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[K]*cacheNode[K, V]
 
-	capacity,
-	size,
-	counter int64 // atomic counters
+	// doubly-linked list of entries, most recently used first
+	head, tail *cacheNode[K, V]
 }
 
-type entry struct {
-	value      interface{} // go 1.18 generics would be nice to have here!
-	lastAccess int64
+// RGBCache caches arbitrary data given an RGBColor.
+//
+// Deprecated: Use Cache instead.
+type RGBCache = Cache[RGBColor, interface{}]
+
+type cacheNode[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *cacheNode[K, V]
 }
 
-func NewRGBCache(capacity int) *RGBCache {
-	return &RGBCache{
-		capacity: int64(capacity),
+// NewCache returns a new Cache holding at most capacity entries. A capacity
+// of zero or less disables the cache: nothing gets stored.
+func NewCache[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &Cache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*cacheNode[K, V], capacity),
 	}
 }
 
-// Get retrieves a value if key is present and increases the total access count by one
-func (c *RGBCache) Get(key RGBColor) (interface{}, bool) {
-	val, ok := c.data.Load(key)
-	if !ok {
-		return "", false
-	}
+// NewRGBCache returns a new RGBCache holding at most capacity entries.
+//
+// Deprecated: Use NewCache instead.
+func NewRGBCache(capacity int) *RGBCache {
+	return NewCache[RGBColor, interface{}](capacity)
+}
 
-	e := val.(*entry)
-	atomic.StoreInt64(&e.lastAccess, atomic.AddInt64(&c.counter, 1))
+// Get retrieves a value if key is present and marks it as most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	return e.value, true
+	n, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.moveToFront(n)
+	return n.value, true
 }
 
-// Put places a key into the cache if its not already there. It also increments the entry's counter
-func (c *RGBCache) Put(key RGBColor, value interface{}) {
-	accessNum := atomic.AddInt64(&c.counter, 1)
+// Put places a key into the cache, or updates its value, and marks it as most
+// recently used. If the cache is full, the least recently used entry gets
+// evicted.
+func (c *Cache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	if val, ok := c.data.Load(key); ok {
-		e := val.(*entry)
-		e.value = value
-		atomic.StoreInt64(&e.lastAccess, accessNum)
+	if c.capacity == 0 {
 		return
 	}
-
-	// New entry
-	newEntry := &entry{
-		value:      value,
-		lastAccess: accessNum,
+	if n, ok := c.items[key]; ok {
+		n.value = value
+		c.moveToFront(n)
+		return
 	}
 
-	c.data.Store(key, newEntry)
-	newSize := atomic.AddInt64(&c.size, 1)
+	n := &cacheNode[K, V]{key: key, value: value}
+	c.items[key] = n
+	c.pushFront(n)
 
-	// Check if we need to evict
-	if newSize > c.capacity {
+	if len(c.items) > c.capacity {
 		c.evictLRU()
 	}
 }
 
-// Delete removes an item from the cache
-// func (c *RGBCache) Delete(key RGBColor) bool {
-// 	_, existed := c.data.LoadAndDelete(key)
-// 	if existed {
-// 		atomic.AddInt64(&c.size, -1)
-// 	}
-// 	return existed
-// }
-
-// Len returns the number of items in the cache atomically
-// func (c *RGBCache) Len() int {
-// 	return int(atomic.LoadInt64(&c.size))
-// }
-
-// Clear empties the cache. Untested
-// func (c *RGBCache) Clear() {
-// 	c.data.Range(func(key, value interface{}) bool {
-// 		c.data.Delete(key)
-// 		return true
-// 	})
-// 	atomic.StoreInt64(&c.size, 0)
-// }
-
-// evictLRU performs O(n) eviction - finds and removes the least recently used entry
-func (c *RGBCache) evictLRU() {
-	var oldestKey interface{}
-	var oldestAccess int64 = atomic.LoadInt64(&c.counter) + 1 // start with max
-
-	c.data.Range(func(key, value interface{}) bool {
-		e := value.(*entry)
-		lastAccess := atomic.LoadInt64(&e.lastAccess)
-
-		if lastAccess < oldestAccess {
-			oldestAccess = lastAccess
-			oldestKey = key
-		}
-		return true
-	})
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
 
-	if oldestKey != nil {
-		c.data.Delete(oldestKey)
-		atomic.AddInt64(&c.size, -1)
+func (c *Cache[K, V]) pushFront(n *cacheNode[K, V]) {
+	n.prev = nil
+	n.next = c.head
+	if c.head != nil {
+		c.head.prev = n
+	}
+	c.head = n
+	if c.tail == nil {
+		c.tail = n
+	}
+}
+
+func (c *Cache[K, V]) unlink(n *cacheNode[K, V]) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		c.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		c.tail = n.prev
+	}
+	n.prev, n.next = nil, nil
+}
+
+func (c *Cache[K, V]) moveToFront(n *cacheNode[K, V]) {
+	if c.head == n {
+		return
+	}
+	c.unlink(n)
+	c.pushFront(n)
+}
+
+// evictLRU removes the least recently used entry in O(1).
+func (c *Cache[K, V]) evictLRU() {
+	n := c.tail
+	if n == nil {
+		return
 	}
+	c.unlink(n)
+	delete(c.items, n.key)
 }
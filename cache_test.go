@@ -0,0 +1,135 @@
+package termenv
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCacheLRU(t *testing.T) {
+	c := NewCache[string, int](2)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+
+	// b is the least recently used entry
+	c.Put("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Errorf("expected 3, got %d", v)
+	}
+
+	c.Put("a", 4)
+	if v, _ := c.Get("a"); v != 4 {
+		t.Errorf("expected 4, got %d", v)
+	}
+	if v, ok := c.Get("missing"); ok || v != 0 {
+		t.Errorf("expected zero value for missing key, got %d", v)
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		c := NewCache[string, int](capacity)
+		c.Put("a", 1)
+		if _, ok := c.Get("a"); ok || c.Len() != 0 {
+			t.Errorf("capacity %d: expected nothing to be stored", capacity)
+		}
+	}
+}
+
+func TestRGBCache(t *testing.T) {
+	c := NewRGBCache(1)
+	c.Put(RGBColor("#abcdef"), "foo")
+	if v, ok := c.Get(RGBColor("#abcdef")); !ok || v != "foo" {
+		t.Errorf("expected foo, got %v", v)
+	}
+}
+
+// rangeCache is the previous cache design, evicting by ranging over a
+// sync.Map in O(n). It is kept for comparison in benchmarks.
+type rangeCache[K comparable, V any] struct {
+	data sync.Map
+
+	capacity,
+	size,
+	counter int64
+}
+
+type rangeEntry[V any] struct {
+	value      V
+	lastAccess int64
+}
+
+func (c *rangeCache[K, V]) Get(key K) (V, bool) {
+	val, ok := c.data.Load(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := val.(*rangeEntry[V])
+	atomic.StoreInt64(&e.lastAccess, atomic.AddInt64(&c.counter, 1))
+	return e.value, true
+}
+
+func (c *rangeCache[K, V]) Put(key K, value V) {
+	e := &rangeEntry[V]{value: value, lastAccess: atomic.AddInt64(&c.counter, 1)}
+	if _, loaded := c.data.LoadOrStore(key, e); loaded {
+		c.data.Store(key, e)
+		return
+	}
+	if atomic.AddInt64(&c.size, 1) <= c.capacity {
+		return
+	}
+
+	var (
+		oldestKey interface{}
+		found     bool
+	)
+	oldestAccess := atomic.LoadInt64(&c.counter) + 1
+	c.data.Range(func(key, value interface{}) bool {
+		if a := atomic.LoadInt64(&value.(*rangeEntry[V]).lastAccess); a < oldestAccess {
+			oldestAccess, oldestKey, found = a, key, true
+		}
+		return true
+	})
+	if found {
+		c.data.Delete(oldestKey)
+		atomic.AddInt64(&c.size, -1)
+	}
+}
+
+type benchCache interface {
+	Get(int) (string, bool)
+	Put(int, string)
+}
+
+func benchmarkCache(b *testing.B, c benchCache, keys int) {
+	b.Helper()
+	for i := 0; i < b.N; i++ {
+		k := i % keys
+		if _, ok := c.Get(k); !ok {
+			c.Put(k, "value")
+		}
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	const capacity = 256
+
+	// the working set exceeds the capacity, so most lookups evict
+	for _, keys := range []int{capacity / 2, capacity * 2} {
+		b.Run(fmt.Sprintf("lru/keys=%d", keys), func(b *testing.B) {
+			benchmarkCache(b, NewCache[int, string](capacity), keys)
+		})
+		b.Run(fmt.Sprintf("range/keys=%d", keys), func(b *testing.B) {
+			benchmarkCache(b, &rangeCache[int, string]{capacity: capacity}, keys)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package termenv
+
+import "strings"
+
+// Capabilities describes which rendering features a terminal supports.
+type Capabilities struct {
+	Profile Profile
+
+	Bold      bool
+	Faint     bool
+	Italic    bool
+	Underline bool
+	Overline  bool
+	Blink     bool
+	Reverse   bool
+	CrossOut  bool
+
+	// UnderlineStyles reports support for underline styles, like curly
+	// underlines, and UnderlineColor for underline colors.
+	UnderlineStyles bool
+	UnderlineColor  bool
+
+	Hyperlinks bool
+}
+
+// Capabilities returns the rendering features the terminal supports, based
+// on its color profile and the terminal type advertised in the environment.
+func (o *Output) Capabilities() Capabilities {
+	p := o.profile()
+	if p == Ascii {
+		return Capabilities{Profile: Ascii, Hyperlinks: o.SupportsHyperlinks()}
+	}
+
+	c := Capabilities{
+		Profile:   p,
+		Bold:      true,
+		Faint:     true,
+		Italic:    true,
+		Underline: true,
+		Overline:  true,
+		Blink:     true,
+		Reverse:   true,
+		CrossOut:  true,
+
+		Hyperlinks: o.SupportsHyperlinks(),
+	}
+	c.UnderlineStyles = o.supportsUnderlineStyles()
+	c.UnderlineColor = c.UnderlineStyles
+
+	term := o.environ.Getenv("TERM")
+	switch {
+	case term == "linux":
+		// the Linux console renders italics as a color, and blink as a
+		// bright background
+		c.Italic = false
+		c.Overline = false
+		c.Blink = false
+		c.CrossOut = false
+	case isSerialTerm(term):
+		c.Faint = false
+		c.Italic = false
+		c.Overline = false
+		c.CrossOut = false
+	case strings.HasPrefix(term, "screen"):
+		// GNU screen doesn't pass these through
+		c.Italic = false
+		c.Overline = false
+		c.CrossOut = false
+	case term == "xterm-kitty", term == "alacritty":
+		c.Blink = false
+	}
+
+	if o.environ.Getenv("TERM_PROGRAM") == "Apple_Terminal" {
+		c.Overline = false
+	}
+
+	return c
+}
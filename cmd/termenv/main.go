@@ -0,0 +1,205 @@
+// Command termenv reports what termenv detects about the terminal, converts
+// colors between profiles and renders test patterns. Its output is meant to
+// be attached to bug reports, or used in shell scripts.
+//
+// Usage:
+//
+//	termenv query              print the detection report and capabilities
+//	termenv convert COLOR...   convert hex colors or ANSI codes to each profile
+//	termenv demo               render colors and attributes as test patterns
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+var errUsage = errors.New("usage: termenv query | convert [-profile name] COLOR... | demo")
+
+func main() {
+	os.Exit(termenvMain())
+}
+
+func termenvMain() int {
+	restoreConsole, err := termenv.EnableVirtualTerminalProcessing(termenv.DefaultOutput())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer restoreConsole() //nolint:errcheck
+
+	if err := run(termenv.DefaultOutput(), os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, errUsage) {
+			return 2 //nolint:mnd
+		}
+		return 1
+	}
+	return 0
+}
+
+func run(o *termenv.Output, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "query":
+		return query(o)
+	case "convert":
+		return convert(o, args[1:])
+	case "demo":
+		demo(o)
+		return nil
+	}
+	return errUsage
+}
+
+// query prints the detection report, the capabilities and the colors
+// reported by the terminal.
+func query(o *termenv.Output) error {
+	fmt.Fprint(o, o.DetectionReport())
+
+	c := o.Capabilities()
+	fmt.Fprintln(o)
+	fmt.Fprintln(o, "capabilities:")
+	for _, f := range []struct {
+		name string
+		ok   bool
+	}{
+		{"bold", c.Bold},
+		{"faint", c.Faint},
+		{"italic", c.Italic},
+		{"underline", c.Underline},
+		{"overline", c.Overline},
+		{"blink", c.Blink},
+		{"reverse", c.Reverse},
+		{"crossout", c.CrossOut},
+		{"underline styles", c.UnderlineStyles},
+		{"underline color", c.UnderlineColor},
+		{"hyperlinks", c.Hyperlinks},
+	} {
+		fmt.Fprintf(o, "  %-18s%t\n", f.name+":", f.ok)
+	}
+
+	fmt.Fprintln(o)
+	fmt.Fprintf(o, "foreground:      %s\n", colorName(o.ForegroundColor()))
+	fmt.Fprintf(o, "background:      %s\n", colorName(o.BackgroundColor()))
+	fmt.Fprintf(o, "dark background: %t\n", o.HasDarkBackground())
+	return nil
+}
+
+// convert prints the given colors converted to each profile, or to the one
+// selected with -profile.
+func convert(o *termenv.Output, args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	name := fs.String("profile", "", "convert to this profile only: truecolor, ansi256, ansi or ascii")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %s", errUsage, err)
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+
+	profiles := []termenv.Profile{termenv.TrueColor, termenv.ANSI256, termenv.ANSI}
+	if *name != "" {
+		p, ok := parseProfile(*name)
+		if !ok {
+			return fmt.Errorf("unknown profile %q", *name)
+		}
+		profiles = []termenv.Profile{p}
+	}
+
+	for _, s := range fs.Args() {
+		if termenv.TrueColor.Color(s) == nil {
+			return fmt.Errorf("invalid color %q", s)
+		}
+
+		var fields []string
+		if len(profiles) > 1 {
+			fields = append(fields, s)
+		}
+		for _, p := range profiles {
+			c := p.Color(s)
+			if len(profiles) == 1 {
+				fields = append(fields, colorName(c))
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s=%s", strings.ToLower(p.Name()), colorName(c)))
+		}
+		fmt.Fprintln(o, strings.Join(fields, " "))
+	}
+	return nil
+}
+
+func parseProfile(s string) (termenv.Profile, bool) {
+	for _, p := range []termenv.Profile{termenv.TrueColor, termenv.ANSI256, termenv.ANSI, termenv.Ascii} {
+		if strings.EqualFold(s, p.Name()) {
+			return p, true
+		}
+	}
+	return termenv.Ascii, false
+}
+
+// colorName returns the hex value of RGB colors and the code of ANSI colors.
+func colorName(c termenv.Color) string {
+	switch v := c.(type) {
+	case termenv.RGBColor:
+		return string(v)
+	case termenv.ANSIColor:
+		return fmt.Sprintf("%d", int(v))
+	case termenv.ANSI256Color:
+		return fmt.Sprintf("%d", int(v))
+	}
+	return "none"
+}
+
+// demo renders the basic and extended colors, a true color gradient and the
+// text attributes, using the output's profile.
+func demo(o *termenv.Output) {
+	fmt.Fprintln(o, o.String("ANSI colors").Bold())
+	for i := 0; i < 16; i++ {
+		if i == 8 {
+			fmt.Fprintln(o)
+		}
+		fmt.Fprint(o, o.String(fmt.Sprintf(" %2d ", i)).Background(o.Color(fmt.Sprint(i))))
+	}
+	fmt.Fprintln(o)
+
+	fmt.Fprintln(o)
+	fmt.Fprintln(o, o.String("ANSI256 colors").Bold())
+	for i := 16; i < 256; i++ {
+		fmt.Fprint(o, o.String("  ").Background(o.Color(fmt.Sprint(i))))
+		if (i-16)%36 == 35 || i == 255 {
+			fmt.Fprintln(o)
+		}
+	}
+
+	fmt.Fprintln(o)
+	fmt.Fprintln(o, o.String("TrueColor gradient").Bold())
+	fmt.Fprintln(o, o.Gradient(strings.Repeat("█", 72),
+		termenv.RGBColor("#ff0000"), termenv.RGBColor("#ffff00"), termenv.RGBColor("#00ff00"),
+		termenv.RGBColor("#00ffff"), termenv.RGBColor("#0000ff"), termenv.RGBColor("#ff00ff")))
+
+	fmt.Fprintln(o)
+	fmt.Fprintln(o, o.String("Attributes").Bold())
+	fmt.Fprintln(o, strings.Join([]string{
+		o.String("bold").Bold().String(),
+		o.String("faint").Faint().String(),
+		o.String("italic").Italic().String(),
+		o.String("underline").Underline().String(),
+		o.Adapt(o.String("curly").UnderlineStyle(termenv.UnderlineCurly).UnderlineColor(o.Color("#ff0000"))).String(),
+		o.String("overline").Overline().String(),
+		o.String("blink").Blink().String(),
+		o.String("reverse").Reverse().String(),
+		o.String("crossout").CrossOut().String(),
+		o.Hyperlink("https://github.com/muesli/termenv", "hyperlink"),
+	}, " "))
+}
@@ -25,6 +25,42 @@ type Color interface {
 	Sequence(bg bool) string
 }
 
+// BuiltinColor is implemented by the colors of this package only: NoColor,
+// ANSIColor, ANSI256Color, RGBColor and AdaptiveColor. Types of other
+// packages can't implement it, other than by embedding one of these colors;
+// they implement CustomColor to take part in color conversions.
+type BuiltinColor interface {
+	Color
+	builtinColor()
+}
+
+// CustomColor is a Color defined outside of this package, e.g. a color of an
+// application's palette. Profile.ConvertColor, ConvertToRGB and the other
+// functions converting colors use its RGB value, like for an RGBColor.
+type CustomColor interface {
+	Color
+	// ToRGB returns the RGB value of the color, e.g. "#ff8800".
+	ToRGB() RGBColor
+}
+
+func (NoColor) builtinColor()       {}
+func (ANSIColor) builtinColor()     {}
+func (ANSI256Color) builtinColor()  {}
+func (RGBColor) builtinColor()      {}
+func (AdaptiveColor) builtinColor() {}
+
+// resolveColor returns the RGB value of a CustomColor, and any other color
+// as is.
+func resolveColor(c Color) Color {
+	if _, ok := c.(BuiltinColor); ok {
+		return c
+	}
+	if cc, ok := c.(CustomColor); ok {
+		return cc.ToRGB()
+	}
+	return c
+}
+
 // NoColor is a nop for terminals that don't support colors.
 type NoColor struct{}
 
@@ -46,19 +82,111 @@ func (c ANSI256Color) String() string {
 	return ansiHex[c]
 }
 
+// RGB returns the red, green and blue values of the color in the xterm
+// palette. It returns black for invalid colors.
+func (c ANSIColor) RGB() (r, g, b uint8) {
+	if c < 0 || c > 15 {
+		return 0, 0, 0
+	}
+	v := ansiRGB[c]
+	return v[0], v[1], v[2]
+}
+
+// RGB returns the red, green and blue values of the color, as listed in
+// ANSI256Palette. It returns black for invalid colors.
+func (c ANSI256Color) RGB() (r, g, b uint8) {
+	if c < 0 || c > 255 {
+		return 0, 0, 0
+	}
+	v := ansiRGB[c]
+	return v[0], v[1], v[2]
+}
+
 // RGBColor is a hex-encoded color, e.g. "#abcdef".
 type RGBColor string
 
-// ConvertToRGB converts a Color to a colorful.Color.
+// NewRGBColor returns the RGBColor with the given red, green and blue
+// values.
+func NewRGBColor(r, g, b uint8) RGBColor {
+	const digits = "0123456789abcdef"
+	return RGBColor([]byte{
+		'#',
+		digits[r>>4], digits[r&0xf],
+		digits[g>>4], digits[g&0xf],
+		digits[b>>4], digits[b&0xf],
+	})
+}
+
+// Values returns the red, green and blue values of the color. Both the
+// "#rrggbb" and the short "#rgb" form are accepted.
+func (c RGBColor) Values() (r, g, b uint8, err error) {
+	s := string(c)
+	if len(s) == 0 || s[0] != '#' {
+		return 0, 0, 0, ErrInvalidColor
+	}
+
+	var v [6]uint8
+	switch len(s) {
+	case 7: //nolint:mnd
+		for i := range v {
+			if v[i], err = hexDigit(s[i+1]); err != nil {
+				return 0, 0, 0, err
+			}
+		}
+	case 4: //nolint:mnd
+		for i := 0; i < 3; i++ {
+			d, err := hexDigit(s[i+1])
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			v[2*i], v[2*i+1] = d, d
+		}
+	default:
+		return 0, 0, 0, ErrInvalidColor
+	}
+	return v[0]<<4 | v[1], v[2]<<4 | v[3], v[4]<<4 | v[5], nil
+}
+
+func hexDigit(c byte) (uint8, error) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', nil
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, nil //nolint:mnd
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, nil //nolint:mnd
+	}
+	return 0, ErrInvalidColor
+}
+
+// ToANSI256 returns the closest color of the 6×6×6 color cube or the
+// grayscale ramp of the ANSI256 palette, as used when converting c to the
+// ANSI256 profile. It returns black for invalid colors.
+func (c RGBColor) ToANSI256() ANSI256Color {
+	r, g, b, err := c.Values()
+	if err != nil {
+		return ANSI256Color(0)
+	}
+	return hexToANSI256Color(colorful.Color{
+		R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255, //nolint:mnd
+	})
+}
+
+// ConvertToRGB converts a Color to a colorful.Color. For an AdaptiveColor,
+// the Dark variant is used.
 func ConvertToRGB(c Color) colorful.Color {
 	var hex string
-	switch v := c.(type) {
+	switch v := resolveColor(c).(type) {
 	case RGBColor:
 		hex = string(v)
 	case ANSIColor:
 		hex = ansiHex[v]
 	case ANSI256Color:
 		hex = ansiHex[v]
+	case AdaptiveColor:
+		if rc := v.Resolve(true); rc != nil {
+			return ConvertToRGB(rc)
+		}
 	}
 
 	ch, _ := colorful.Hex(hex)
@@ -99,7 +227,7 @@ func (c ANSI256Color) Sequence(bg bool) string {
 
 // Sequence returns the ANSI Sequence for the color.
 func (c RGBColor) Sequence(bg bool) string {
-	f, err := colorful.Hex(string(c))
+	r, g, b, err := c.Values()
 	if err != nil {
 		return ""
 	}
@@ -108,7 +236,7 @@ func (c RGBColor) Sequence(bg bool) string {
 	if bg {
 		prefix = Background
 	}
-	return fmt.Sprintf("%s;2;%d;%d;%d", prefix, uint8(f.R*255), uint8(f.G*255), uint8(f.B*255)) //nolint:mnd
+	return fmt.Sprintf("%s;2;%d;%d;%d", prefix, r, g, b)
 }
 
 func xTermColor(s string) (RGBColor, error) {
@@ -141,12 +269,22 @@ func xTermColor(s string) (RGBColor, error) {
 }
 
 func ansi256ToANSIColor(c ANSI256Color) ANSIColor {
+	return ansi256ToPaletteColor(c, nil)
+}
+
+// ansi256ToPaletteColor returns the color of the 16-color palette closest to
+// c. A nil palette stands for the default xterm palette.
+func ansi256ToPaletteColor(c ANSI256Color, pal *Palette) ANSIColor {
 	var r int
 	md := math.MaxFloat64
 
 	h, _ := colorful.Hex(ansiHex[c])
 	for i := 0; i <= 15; i++ {
-		hb, _ := colorful.Hex(ansiHex[i])
+		hex := ansiHex[i]
+		if pal != nil {
+			hex = string(pal[i])
+		}
+		hb, _ := colorful.Hex(hex)
 		d := h.DistanceHSLuv(hb)
 
 		if d < md {
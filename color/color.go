@@ -0,0 +1,584 @@
+// Package color is a drop-in replacement for github.com/fatih/color, backed
+// by termenv's profile detection and color conversion.
+//
+// Existing code keeps working after swapping the import path, and gains
+// graceful degradation plus 24-bit colors through RGB and BgRGB, which are
+// converted to the best color the terminal supports.
+package color
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+var (
+	// NoColor defines if the output is colorized or not. It's dynamically set
+	// based on the terminal's capabilities and the NO_COLOR/CLICOLOR
+	// environment variables.
+	NoColor = termenv.EnvColorProfile() == termenv.Ascii
+
+	// Output defines the standard output of the print functions.
+	Output io.Writer = os.Stdout
+
+	// Error defines a color supporting writer for os.Stderr.
+	Error io.Writer = os.Stderr
+)
+
+// Attribute defines a single SGR code.
+type Attribute int
+
+// Base attributes.
+const (
+	Reset Attribute = iota
+	Bold
+	Faint
+	Italic
+	Underline
+	BlinkSlow
+	BlinkRapid
+	ReverseVideo
+	Concealed
+	CrossedOut
+)
+
+// Foreground text colors.
+const (
+	FgBlack Attribute = iota + 30
+	FgRed
+	FgGreen
+	FgYellow
+	FgBlue
+	FgMagenta
+	FgCyan
+	FgWhite
+)
+
+// Foreground Hi-Intensity text colors.
+const (
+	FgHiBlack Attribute = iota + 90
+	FgHiRed
+	FgHiGreen
+	FgHiYellow
+	FgHiBlue
+	FgHiMagenta
+	FgHiCyan
+	FgHiWhite
+)
+
+// Background text colors.
+const (
+	BgBlack Attribute = iota + 40
+	BgRed
+	BgGreen
+	BgYellow
+	BgBlue
+	BgMagenta
+	BgCyan
+	BgWhite
+)
+
+// Background Hi-Intensity text colors.
+const (
+	BgHiBlack Attribute = iota + 100
+	BgHiRed
+	BgHiGreen
+	BgHiYellow
+	BgHiBlue
+	BgHiMagenta
+	BgHiCyan
+	BgHiWhite
+)
+
+// Extended color parameters, followed by 2 and the red, green and blue values.
+const (
+	foreground Attribute = 38
+	background Attribute = 48
+)
+
+// Color defines a custom color object which is defined by SGR parameters.
+type Color struct {
+	params  []Attribute
+	noColor *bool
+}
+
+// Set sets the given parameters immediately. It will change the color of
+// output with the given SGR parameters until color.Unset() is called.
+func Set(p ...Attribute) *Color {
+	c := New(p...)
+	c.Set()
+	return c
+}
+
+// Unset resets all escape attributes and clears the output. Usually should
+// be called after Set().
+func Unset() {
+	if NoColor {
+		return
+	}
+	_, _ = fmt.Fprint(Output, termenv.CSI+termenv.ResetSeq+"m")
+}
+
+// New returns a newly created color object.
+func New(value ...Attribute) *Color {
+	c := &Color{}
+	c.Add(value...)
+	return c
+}
+
+// RGB returns a new foreground color in 24-bit RGB, converted to the best
+// color the terminal supports.
+func RGB(r, g, b int) *Color {
+	return New().AddRGB(r, g, b)
+}
+
+// BgRGB returns a new background color in 24-bit RGB, converted to the best
+// color the terminal supports.
+func BgRGB(r, g, b int) *Color {
+	return New().AddBgRGB(r, g, b)
+}
+
+// Set sets the SGR sequence on standard output, so everything printed
+// afterwards gets colorized until Unset is called.
+func (c *Color) Set() *Color {
+	return c.SetWriter(Output)
+}
+
+// Unset resets all escape attributes on standard output.
+func (c *Color) Unset() {
+	c.UnsetWriter(Output)
+}
+
+// SetWriter is used to set the SGR sequence with the given writer.
+func (c *Color) SetWriter(w io.Writer) *Color {
+	if c.isNoColorSet() {
+		return c
+	}
+	_, _ = fmt.Fprint(w, c.format())
+	return c
+}
+
+// UnsetWriter resets all escape attributes on the given writer.
+func (c *Color) UnsetWriter(w io.Writer) {
+	if c.isNoColorSet() {
+		return
+	}
+	_, _ = fmt.Fprint(w, c.unformat())
+}
+
+// Add is used to chain SGR parameters. Use as many as parameters to combine
+// and create custom color objects. Example: Add(color.FgRed, color.Underline).
+func (c *Color) Add(value ...Attribute) *Color {
+	c.params = append(c.params, value...)
+	return c
+}
+
+// AddRGB is used to chain foreground RGB SGR parameters.
+func (c *Color) AddRGB(r, g, b int) *Color {
+	c.params = append(c.params, foreground, 2, Attribute(r), Attribute(g), Attribute(b))
+	return c
+}
+
+// AddBgRGB is used to chain background RGB SGR parameters.
+func (c *Color) AddBgRGB(r, g, b int) *Color {
+	c.params = append(c.params, background, 2, Attribute(r), Attribute(g), Attribute(b))
+	return c
+}
+
+func rgbHex(r, g, b Attribute) string {
+	return fmt.Sprintf("#%02x%02x%02x", uint8(r), uint8(g), uint8(b)) //nolint:gosec
+}
+
+// sequence returns the SGR parameters of c. RGB colors are converted to the
+// best color the terminal supports.
+func (c *Color) sequence() string {
+	p := c.profile()
+	groups := c.groups()
+	seq := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if len(g) == 1 {
+			seq = append(seq, strconv.Itoa(int(g[0])))
+			continue
+		}
+		if col := p.Color(rgbHex(g[2], g[3], g[4])); col != nil {
+			seq = append(seq, col.Sequence(g[0] == background))
+		}
+	}
+	return strings.Join(seq, ";")
+}
+
+// groups splits the parameters of c into single attributes and RGB colors.
+//
+//nolint:mnd
+func (c *Color) groups() [][]Attribute {
+	var groups [][]Attribute
+	for i := 0; i < len(c.params); i++ {
+		a := c.params[i]
+		if (a == foreground || a == background) && i+4 < len(c.params) && c.params[i+1] == 2 {
+			groups = append(groups, c.params[i:i+5])
+			i += 4
+			continue
+		}
+		groups = append(groups, c.params[i:i+1])
+	}
+	return groups
+}
+
+// format returns the sequence setting the attributes of c.
+func (c *Color) format() string {
+	return termenv.CSI + c.sequence() + "m"
+}
+
+// unformat returns the sequence resetting all attributes.
+func (c *Color) unformat() string {
+	return termenv.CSI + termenv.ResetSeq + "m"
+}
+
+// DisableColor disables the color output. Useful to not change any existing
+// code and still being able to output. Can be used for flags like
+// "--no-color". To enable back use EnableColor() method.
+func (c *Color) DisableColor() {
+	c.noColor = boolPtr(true)
+}
+
+// EnableColor enables the color output. Use it in conjunction with
+// DisableColor(). Otherwise, this method has no side effects.
+func (c *Color) EnableColor() {
+	c.noColor = boolPtr(false)
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func (c *Color) isNoColorSet() bool {
+	if c.noColor != nil {
+		return *c.noColor
+	}
+	return NoColor
+}
+
+// profile returns the profile to render with. If colors have been enabled
+// explicitly for an output that isn't a terminal, ANSI is used.
+func (c *Color) profile() termenv.Profile {
+	p := termenv.DefaultOutput().Profile
+	if p == termenv.Ascii {
+		p = termenv.ANSI
+	}
+	return p
+}
+
+// wrap wraps the s string with the colors attributes.
+func (c *Color) wrap(s string) string {
+	if c.isNoColorSet() || len(c.params) == 0 {
+		return s
+	}
+	return c.format() + s + c.unformat()
+}
+
+// Equals returns a boolean value indicating whether two colors are equal,
+// i.e. have the same attributes, in any order.
+func (c *Color) Equals(c2 *Color) bool {
+	if c == nil || c2 == nil {
+		return c == c2
+	}
+	if len(c.params) != len(c2.params) {
+		return false
+	}
+
+	count := make(map[string]int, len(c.params))
+	for _, g := range c.groups() {
+		count[fmt.Sprint(g)]++
+	}
+	for _, g := range c2.groups() {
+		k := fmt.Sprint(g)
+		if count[k] == 0 {
+			return false
+		}
+		count[k]--
+	}
+	return true
+}
+
+// sprintln is like fmt.Sprintln, but without the trailing newline, so the
+// newline doesn't get colorized.
+func sprintln(a ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+}
+
+// Fprint formats using the default formats for its operands and writes to w.
+func (c *Color) Fprint(w io.Writer, a ...interface{}) (n int, err error) {
+	return fmt.Fprint(w, c.wrap(fmt.Sprint(a...)))
+}
+
+// Print formats using the default formats for its operands and writes to
+// standard output.
+func (c *Color) Print(a ...interface{}) (n int, err error) {
+	return c.Fprint(Output, a...)
+}
+
+// Fprintf formats according to a format specifier and writes to w.
+func (c *Color) Fprintf(w io.Writer, format string, a ...interface{}) (n int, err error) {
+	return fmt.Fprint(w, c.wrap(fmt.Sprintf(format, a...)))
+}
+
+// Printf formats according to a format specifier and writes to standard
+// output.
+func (c *Color) Printf(format string, a ...interface{}) (n int, err error) {
+	return c.Fprintf(Output, format, a...)
+}
+
+// Fprintln formats using the default formats for its operands and writes to
+// w. Spaces are always added between operands and a newline is appended.
+func (c *Color) Fprintln(w io.Writer, a ...interface{}) (n int, err error) {
+	return fmt.Fprintln(w, c.wrap(sprintln(a...)))
+}
+
+// Println formats using the default formats for its operands and writes to
+// standard output. Spaces are always added between operands and a newline is
+// appended.
+func (c *Color) Println(a ...interface{}) (n int, err error) {
+	return c.Fprintln(Output, a...)
+}
+
+// Sprint is just like Print, but returns a string instead of printing it.
+func (c *Color) Sprint(a ...interface{}) string {
+	return c.wrap(fmt.Sprint(a...))
+}
+
+// Sprintln is just like Println, but returns a string instead of printing it.
+func (c *Color) Sprintln(a ...interface{}) string {
+	return c.wrap(sprintln(a...)) + "\n"
+}
+
+// Sprintf is just like Printf, but returns a string instead of printing it.
+func (c *Color) Sprintf(format string, a ...interface{}) string {
+	return c.wrap(fmt.Sprintf(format, a...))
+}
+
+// FprintFunc returns a new function that prints the passed arguments as
+// colorized with color.Fprint().
+func (c *Color) FprintFunc() func(w io.Writer, a ...interface{}) {
+	return func(w io.Writer, a ...interface{}) {
+		_, _ = c.Fprint(w, a...)
+	}
+}
+
+// PrintFunc returns a new function that prints the passed arguments as
+// colorized with color.Print().
+func (c *Color) PrintFunc() func(a ...interface{}) {
+	return func(a ...interface{}) {
+		_, _ = c.Print(a...)
+	}
+}
+
+// FprintfFunc returns a new function that prints the passed arguments as
+// colorized with color.Fprintf().
+func (c *Color) FprintfFunc() func(w io.Writer, format string, a ...interface{}) {
+	return func(w io.Writer, format string, a ...interface{}) {
+		_, _ = c.Fprintf(w, format, a...)
+	}
+}
+
+// PrintfFunc returns a new function that prints the passed arguments as
+// colorized with color.Printf().
+func (c *Color) PrintfFunc() func(format string, a ...interface{}) {
+	return func(format string, a ...interface{}) {
+		_, _ = c.Printf(format, a...)
+	}
+}
+
+// FprintlnFunc returns a new function that prints the passed arguments as
+// colorized with color.Fprintln().
+func (c *Color) FprintlnFunc() func(w io.Writer, a ...interface{}) {
+	return func(w io.Writer, a ...interface{}) {
+		_, _ = c.Fprintln(w, a...)
+	}
+}
+
+// PrintlnFunc returns a new function that prints the passed arguments as
+// colorized with color.Println().
+func (c *Color) PrintlnFunc() func(a ...interface{}) {
+	return func(a ...interface{}) {
+		_, _ = c.Println(a...)
+	}
+}
+
+// SprintFunc returns a new function that returns colorized strings for the
+// given arguments with fmt.Sprint().
+func (c *Color) SprintFunc() func(a ...interface{}) string {
+	return c.Sprint
+}
+
+// SprintfFunc returns a new function that returns colorized strings for the
+// given arguments with fmt.Sprintf().
+func (c *Color) SprintfFunc() func(format string, a ...interface{}) string {
+	return c.Sprintf
+}
+
+// SprintlnFunc returns a new function that returns colorized strings for the
+// given arguments with fmt.Sprintln().
+func (c *Color) SprintlnFunc() func(a ...interface{}) string {
+	return c.Sprintln
+}
+
+func printColor(format string, p Attribute, a ...interface{}) {
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+	_, _ = New(p).Printf(format, a...)
+}
+
+func printString(format string, p Attribute, a ...interface{}) string {
+	return New(p).Sprintf(format, a...)
+}
+
+// Black is a convenient helper function to print with black foreground. A
+// newline is appended to format by default.
+func Black(format string, a ...interface{}) { printColor(format, FgBlack, a...) }
+
+// Red is a convenient helper function to print with red foreground. A
+// newline is appended to format by default.
+func Red(format string, a ...interface{}) { printColor(format, FgRed, a...) }
+
+// Green is a convenient helper function to print with green foreground. A
+// newline is appended to format by default.
+func Green(format string, a ...interface{}) { printColor(format, FgGreen, a...) }
+
+// Yellow is a convenient helper function to print with yellow foreground.
+// A newline is appended to format by default.
+func Yellow(format string, a ...interface{}) { printColor(format, FgYellow, a...) }
+
+// Blue is a convenient helper function to print with blue foreground. A
+// newline is appended to format by default.
+func Blue(format string, a ...interface{}) { printColor(format, FgBlue, a...) }
+
+// Magenta is a convenient helper function to print with magenta foreground.
+// A newline is appended to format by default.
+func Magenta(format string, a ...interface{}) { printColor(format, FgMagenta, a...) }
+
+// Cyan is a convenient helper function to print with cyan foreground. A
+// newline is appended to format by default.
+func Cyan(format string, a ...interface{}) { printColor(format, FgCyan, a...) }
+
+// White is a convenient helper function to print with white foreground. A
+// newline is appended to format by default.
+func White(format string, a ...interface{}) { printColor(format, FgWhite, a...) }
+
+// BlackString is a convenient helper function to return a string with black
+// foreground.
+func BlackString(format string, a ...interface{}) string { return printString(format, FgBlack, a...) }
+
+// RedString is a convenient helper function to return a string with red
+// foreground.
+func RedString(format string, a ...interface{}) string { return printString(format, FgRed, a...) }
+
+// GreenString is a convenient helper function to return a string with green
+// foreground.
+func GreenString(format string, a ...interface{}) string { return printString(format, FgGreen, a...) }
+
+// YellowString is a convenient helper function to return a string with
+// yellow foreground.
+func YellowString(format string, a ...interface{}) string { return printString(format, FgYellow, a...) }
+
+// BlueString is a convenient helper function to return a string with blue
+// foreground.
+func BlueString(format string, a ...interface{}) string { return printString(format, FgBlue, a...) }
+
+// MagentaString is a convenient helper function to return a string with
+// magenta foreground.
+func MagentaString(format string, a ...interface{}) string {
+	return printString(format, FgMagenta, a...)
+}
+
+// CyanString is a convenient helper function to return a string with cyan
+// foreground.
+func CyanString(format string, a ...interface{}) string { return printString(format, FgCyan, a...) }
+
+// WhiteString is a convenient helper function to return a string with white
+// foreground.
+func WhiteString(format string, a ...interface{}) string { return printString(format, FgWhite, a...) }
+
+// HiBlack is a convenient helper function to print with hi-intensity black
+// foreground. A newline is appended to format by default.
+func HiBlack(format string, a ...interface{}) { printColor(format, FgHiBlack, a...) }
+
+// HiRed is a convenient helper function to print with hi-intensity red
+// foreground. A newline is appended to format by default.
+func HiRed(format string, a ...interface{}) { printColor(format, FgHiRed, a...) }
+
+// HiGreen is a convenient helper function to print with hi-intensity green
+// foreground. A newline is appended to format by default.
+func HiGreen(format string, a ...interface{}) { printColor(format, FgHiGreen, a...) }
+
+// HiYellow is a convenient helper function to print with hi-intensity yellow
+// foreground. A newline is appended to format by default.
+func HiYellow(format string, a ...interface{}) { printColor(format, FgHiYellow, a...) }
+
+// HiBlue is a convenient helper function to print with hi-intensity blue
+// foreground. A newline is appended to format by default.
+func HiBlue(format string, a ...interface{}) { printColor(format, FgHiBlue, a...) }
+
+// HiMagenta is a convenient helper function to print with hi-intensity magenta
+// foreground. A newline is appended to format by default.
+func HiMagenta(format string, a ...interface{}) { printColor(format, FgHiMagenta, a...) }
+
+// HiCyan is a convenient helper function to print with hi-intensity cyan
+// foreground. A newline is appended to format by default.
+func HiCyan(format string, a ...interface{}) { printColor(format, FgHiCyan, a...) }
+
+// HiWhite is a convenient helper function to print with hi-intensity white
+// foreground. A newline is appended to format by default.
+func HiWhite(format string, a ...interface{}) { printColor(format, FgHiWhite, a...) }
+
+// HiBlackString is a convenient helper function to return a string with
+// hi-intensity black foreground.
+func HiBlackString(format string, a ...interface{}) string {
+	return printString(format, FgHiBlack, a...)
+}
+
+// HiRedString is a convenient helper function to return a string with
+// hi-intensity red foreground.
+func HiRedString(format string, a ...interface{}) string {
+	return printString(format, FgHiRed, a...)
+}
+
+// HiGreenString is a convenient helper function to return a string with
+// hi-intensity green foreground.
+func HiGreenString(format string, a ...interface{}) string {
+	return printString(format, FgHiGreen, a...)
+}
+
+// HiYellowString is a convenient helper function to return a string with
+// hi-intensity yellow foreground.
+func HiYellowString(format string, a ...interface{}) string {
+	return printString(format, FgHiYellow, a...)
+}
+
+// HiBlueString is a convenient helper function to return a string with
+// hi-intensity blue foreground.
+func HiBlueString(format string, a ...interface{}) string {
+	return printString(format, FgHiBlue, a...)
+}
+
+// HiMagentaString is a convenient helper function to return a string with
+// hi-intensity magenta foreground.
+func HiMagentaString(format string, a ...interface{}) string {
+	return printString(format, FgHiMagenta, a...)
+}
+
+// HiCyanString is a convenient helper function to return a string with
+// hi-intensity cyan foreground.
+func HiCyanString(format string, a ...interface{}) string {
+	return printString(format, FgHiCyan, a...)
+}
+
+// HiWhiteString is a convenient helper function to return a string with
+// hi-intensity white foreground.
+func HiWhiteString(format string, a ...interface{}) string {
+	return printString(format, FgHiWhite, a...)
+}
@@ -0,0 +1,89 @@
+package color
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestColor(t *testing.T) {
+	c := New(FgRed, Bold)
+	c.EnableColor()
+
+	exp := "\x1b[31;1mfoo\x1b[0m"
+	if s := c.Sprint("foo"); s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+
+	var buf bytes.Buffer
+	_, _ = c.Fprintln(&buf, "foo")
+	if buf.String() != exp+"\n" {
+		t.Errorf("expected %q, got %q", exp+"\n", buf.String())
+	}
+
+	c.DisableColor()
+	if s := c.Sprint("foo"); s != "foo" {
+		t.Errorf("expected plain output, got %q", s)
+	}
+}
+
+func TestRGB(t *testing.T) {
+	defer termenv.SetDefaultOutput(termenv.DefaultOutput())
+
+	c := RGB(255, 0, 0)
+	c.EnableColor()
+
+	termenv.SetDefaultOutput(termenv.NewOutput(nil, termenv.WithProfile(termenv.TrueColor)))
+	exp := "\x1b[38;2;255;0;0mfoo\x1b[0m"
+	if s := c.Sprint("foo"); s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+
+	termenv.SetDefaultOutput(termenv.NewOutput(nil, termenv.WithProfile(termenv.ANSI256)))
+	exp = "\x1b[38;5;196mfoo\x1b[0m"
+	if s := c.Sprint("foo"); s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+}
+
+func TestEquals(t *testing.T) {
+	if !New(FgRed, Bold).Equals(New(Bold, FgRed)) {
+		t.Error("expected colors with the same attributes to be equal")
+	}
+	if New(FgRed).Equals(New(FgBlue)) {
+		t.Error("expected red and blue to differ")
+	}
+	if New(FgRed, Bold).Equals(New(FgRed, FgRed)) {
+		t.Error("expected colors with different attributes to differ")
+	}
+	if RGB(255, 0, 0).Equals(RGB(0, 0, 255)) {
+		t.Error("expected different RGB colors to differ")
+	}
+	if New(FgRed).Equals(nil) {
+		t.Error("expected a color to differ from nil")
+	}
+}
+
+func TestSetWriter(t *testing.T) {
+	c := New(FgHiGreen, Concealed)
+	c.EnableColor()
+
+	var buf bytes.Buffer
+	c.SetWriter(&buf)
+	buf.WriteString("foo")
+	c.UnsetWriter(&buf)
+
+	exp := "\x1b[92;8mfoo\x1b[0m"
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+
+	c.DisableColor()
+	buf.Reset()
+	c.SetWriter(&buf)
+	c.UnsetWriter(&buf)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
@@ -62,3 +62,169 @@ func TestXTermColor(t *testing.T) {
 		})
 	}
 }
+
+func TestANSI256Palette(t *testing.T) {
+	tests := []struct {
+		c       ANSI256Color
+		r, g, b uint8
+	}{
+		{0, 0, 0, 0},
+		{9, 0xff, 0, 0},
+		{16, 0, 0, 0},
+		{208, 0xff, 0x87, 0},
+		{231, 0xff, 0xff, 0xff},
+		{232, 8, 8, 8},
+		{255, 0xee, 0xee, 0xee},
+		{256, 0, 0, 0},
+		{-1, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		r, g, b := test.c.RGB()
+		if r != test.r || g != test.g || b != test.b {
+			t.Errorf("%d: expected %d,%d,%d, got %d,%d,%d", test.c, test.r, test.g, test.b, r, g, b)
+		}
+	}
+
+	for i, c := range ANSI256Palette {
+		if c != RGBColor(ANSI256Color(i).String()) {
+			t.Errorf("%d: expected %s, got %s", i, ANSI256Color(i).String(), c)
+		}
+	}
+	if r, g, b := ANSIColor(12).RGB(); r != 0 || g != 0 || b != 0xff {
+		t.Errorf("expected 0,0,255, got %d,%d,%d", r, g, b)
+	}
+}
+
+func TestRGBColorToANSI256(t *testing.T) {
+	tests := []struct {
+		c        RGBColor
+		expected ANSI256Color
+	}{
+		{"#ff8700", 208},
+		{"#ff0000", 196},
+		{"#ffffff", 231},
+		{"#5f87ff", 69},
+		{"invalid", 0},
+	}
+
+	for _, test := range tests {
+		if got := test.c.ToANSI256(); got != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.c, test.expected, got)
+		}
+		if test.c != "invalid" && ANSI256.ConvertColor(test.c) != test.expected {
+			t.Errorf("%s: expected ToANSI256 to match ConvertColor", test.c)
+		}
+	}
+}
+
+func TestRGBColorValues(t *testing.T) {
+	tests := []struct {
+		c       RGBColor
+		r, g, b uint8
+		err     bool
+	}{
+		{"#ff8700", 0xff, 0x87, 0, false},
+		{"#ABCDEF", 0xab, 0xcd, 0xef, false},
+		{"#f80", 0xff, 0x88, 0, false},
+		{"#ff870", 0, 0, 0, true},
+		{"ff8700", 0, 0, 0, true},
+		{"#gg8700", 0, 0, 0, true},
+		{"", 0, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		r, g, b, err := test.c.Values()
+		if (err != nil) != test.err {
+			t.Errorf("%q: unexpected error %v", test.c, err)
+		}
+		if r != test.r || g != test.g || b != test.b {
+			t.Errorf("%q: expected %d,%d,%d, got %d,%d,%d", test.c, test.r, test.g, test.b, r, g, b)
+		}
+	}
+
+	if c := NewRGBColor(0xff, 0x87, 0x0a); c != "#ff870a" {
+		t.Errorf("expected #ff870a, got %s", c)
+	}
+	for i := 0; i < 256; i++ {
+		v := uint8(i)
+		if r, g, b, err := NewRGBColor(v, v, 255-v).Values(); err != nil || r != v || g != v || b != 255-v {
+			t.Errorf("%d: round trip failed: %d,%d,%d %v", i, r, g, b, err)
+		}
+	}
+}
+
+func TestConvertColor(t *testing.T) {
+	c := RGBColor("#ff8700")
+	if got := ANSI256.ConvertColor(c); got != ANSI256Color(208) {
+		t.Errorf("expected 208, got %v", got)
+	}
+	if got := TrueColor.ConvertColor(c); got != c {
+		t.Errorf("expected %v, got %v", c, got)
+	}
+	if got := Ascii.ConvertColor(c); got != (NoColor{}) {
+		t.Errorf("expected NoColor, got %v", got)
+	}
+	if got := ANSI256.ConvertColor(RGBColor("invalid")); got != nil {
+		t.Errorf("expected nil for an invalid color, got %v", got)
+	}
+}
+
+func TestConvertIgnoresHex(t *testing.T) {
+	c := RGBColor("#ff8700")
+	if got := ANSI256.Convert(c, ""); got != ANSI256Color(208) {
+		t.Errorf("expected 208, got %v", got)
+	}
+	if got := ANSI256.Convert(c, "#000000"); got != ANSI256Color(208) {
+		t.Errorf("expected 208, got %v", got)
+	}
+}
+
+// brandColor is a color of an application's palette.
+type brandColor int
+
+func (c brandColor) Sequence(bg bool) string {
+	return c.ToRGB().Sequence(bg)
+}
+
+func (c brandColor) ToRGB() RGBColor {
+	return []RGBColor{"#ff8700", "#5f00af"}[c]
+}
+
+func TestCustomColor(t *testing.T) {
+	var c Color = brandColor(0)
+	if _, ok := c.(BuiltinColor); ok {
+		t.Error("expected a custom color not to be a BuiltinColor")
+	}
+	if _, ok := Color(RGBColor("#ff8700")).(BuiltinColor); !ok {
+		t.Error("expected RGBColor to be a BuiltinColor")
+	}
+
+	if got := ANSI256.ConvertColor(c); got != ANSI256Color(208) {
+		t.Errorf("expected 208, got %v", got)
+	}
+	if got := TrueColor.ConvertColor(c); got != RGBColor("#ff8700") {
+		t.Errorf("expected #ff8700, got %v", got)
+	}
+	if got, want := ANSI.ConvertColor(brandColor(1)), ANSI.ConvertColor(RGBColor("#5f00af")); got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := ConvertToRGB(c).Hex(); got != "#ff8700" {
+		t.Errorf("expected #ff8700, got %s", got)
+	}
+	if got := NewColorSpec(c); got != (ColorSpec{Type: ColorRGB, Hex: "#ff8700"}) {
+		t.Errorf("unexpected spec %+v", got)
+	}
+
+	s := Style{profile: TrueColor}.UnderlineColor(c)
+	if got := s.Sequence(); got != "58;2;255;135;0" {
+		t.Errorf("expected 58;2;255;135;0, got %q", got)
+	}
+}
+
+func BenchmarkRGBColorValues(b *testing.B) {
+	c := RGBColor("#ff8700")
+	for i := 0; i < b.N; i++ {
+		_, _, _, _ = c.Values()
+	}
+}
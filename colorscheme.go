@@ -0,0 +1,91 @@
+package termenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Color scheme update sequences (DEC mode 2031).
+const (
+	EnableColorSchemeUpdatesSeq  = "?2031h"
+	DisableColorSchemeUpdatesSeq = "?2031l"
+	RequestColorSchemeSeq        = "?996n"
+)
+
+// ColorScheme is the color scheme a terminal reports.
+type ColorScheme int
+
+// Color schemes, as reported by the terminal.
+const (
+	ColorSchemeDark  ColorScheme = 1
+	ColorSchemeLight ColorScheme = 2
+)
+
+// ColorSchemeEvent is sent when the terminal's color scheme changed, if
+// color scheme updates are enabled, or in response to RequestColorScheme.
+type ColorSchemeEvent struct {
+	Scheme ColorScheme
+}
+
+func (ColorSchemeEvent) isEvent() {}
+
+// EnableColorSchemeUpdates makes the terminal report every change between its
+// dark and light color scheme. The reports are read as ColorSchemeEvents.
+func (o Output) EnableColorSchemeUpdates() {
+	fmt.Fprint(o.w, CSI+EnableColorSchemeUpdatesSeq) //nolint:errcheck
+}
+
+// DisableColorSchemeUpdates stops the terminal from reporting color scheme
+// changes.
+func (o Output) DisableColorSchemeUpdates() {
+	fmt.Fprint(o.w, CSI+DisableColorSchemeUpdatesSeq) //nolint:errcheck
+}
+
+// RequestColorScheme asks the terminal to report its current color scheme.
+// The answer is read as a ColorSchemeEvent.
+func (o Output) RequestColorScheme() {
+	fmt.Fprint(o.w, CSI+RequestColorSchemeSeq) //nolint:errcheck
+}
+
+// decodeColorScheme decodes a "CSI ? 997 ; n n" color scheme report.
+func decodeColorScheme(params string) (Event, bool) {
+	switch strings.TrimPrefix(params, "?997;") {
+	case "1":
+		return ColorSchemeEvent{Scheme: ColorSchemeDark}, true
+	case "2":
+		return ColorSchemeEvent{Scheme: ColorSchemeLight}, true
+	}
+	return nil, false
+}
+
+// ColorSchemeUpdates reads events from ir in the background and sends every
+// reported color scheme to the returned channel. All other events get
+// forwarded to other, unless it is nil. Both channels get closed when reading
+// from ir fails, e.g. at the end of the input.
+//
+// Applications can call Redetect when receiving an update, to refresh the
+// detected background color.
+func ColorSchemeUpdates(ir *InputReader, other chan<- Event) <-chan ColorScheme {
+	ch := make(chan ColorScheme)
+	go func() {
+		defer close(ch)
+		if other != nil {
+			defer close(other)
+		}
+
+		for {
+			ev, err := ir.ReadEvent()
+			if err != nil {
+				return
+			}
+			if cs, ok := ev.(ColorSchemeEvent); ok {
+				ch <- cs.Scheme
+				continue
+			}
+			if other != nil {
+				other <- ev
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,52 @@
+package termenv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColorSchemeSeqs(t *testing.T) {
+	o := tempOutput(t)
+	o.EnableColorSchemeUpdates()
+	o.RequestColorScheme()
+	o.DisableColorSchemeUpdates()
+	verify(t, o, "\x1b[?2031h\x1b[?996n\x1b[?2031l")
+}
+
+func TestDecodeColorScheme(t *testing.T) {
+	evs := readEvents(t, "\x1b[?997;1n\x1b[?997;2n\x1b[?997;3n")
+	exp := []Event{
+		ColorSchemeEvent{Scheme: ColorSchemeDark},
+		ColorSchemeEvent{Scheme: ColorSchemeLight},
+		UnknownEvent{Seq: "\x1b[?997;3n"},
+	}
+	if len(evs) != len(exp) {
+		t.Fatalf("expected %d events, got %d", len(exp), len(evs))
+	}
+	for i := range exp {
+		if evs[i] != exp[i] {
+			t.Errorf("expected %#v, got %#v", exp[i], evs[i])
+		}
+	}
+}
+
+func TestColorSchemeUpdates(t *testing.T) {
+	ir := NewInputReader(strings.NewReader("a\x1b[?997;2nb"))
+	other := make(chan Event, 2)
+
+	var schemes []ColorScheme
+	for cs := range ColorSchemeUpdates(ir, other) {
+		schemes = append(schemes, cs)
+	}
+	if len(schemes) != 1 || schemes[0] != ColorSchemeLight {
+		t.Errorf("expected a single light scheme, got %v", schemes)
+	}
+
+	var keys []Event
+	for ev := range other {
+		keys = append(keys, ev)
+	}
+	if len(keys) != 2 || keys[0] != (KeyEvent{Type: KeyRune, Rune: 'a'}) {
+		t.Errorf("expected two forwarded key events, got %v", keys)
+	}
+}
@@ -0,0 +1,117 @@
+package termenv
+
+import (
+	"strings"
+)
+
+// ColumnsOption sets an option on Columns.
+type ColumnsOption = func(*columns)
+
+type columns struct {
+	separator string
+	across    bool
+}
+
+// WithColumnSeparator returns a new ColumnsOption replacing the default
+// separator of two spaces between columns, e.g. with " │ ".
+func WithColumnSeparator(sep string) ColumnsOption {
+	return func(c *columns) {
+		c.separator = sep
+	}
+}
+
+// WithColumnsAcross returns a new ColumnsOption filling the rows before the
+// columns, like ls -x. By default items are listed down the columns.
+func WithColumnsAcross() ColumnsOption {
+	return func(c *columns) {
+		c.across = true
+	}
+}
+
+// Columns lays out items in as few rows as fit into width cells, like ls.
+// Items may be styled: escape sequences don't count towards their width.
+// Columns are as wide as their widest item, and items wider than width get
+// a row of their own.
+func Columns(items []string, width int, opts ...ColumnsOption) string {
+	c := &columns{separator: "  "}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(items) == 0 {
+		return ""
+	}
+
+	widths := make([]int, len(items))
+	for i, item := range items {
+		widths[i] = StringWidth(item)
+	}
+	sepWidth := StringWidth(c.separator)
+
+	var (
+		rows      int
+		colWidths []int
+	)
+	for rows = 1; rows < len(items); rows++ {
+		colWidths = c.columnWidths(widths, rows)
+		total := sepWidth * (len(colWidths) - 1)
+		for _, w := range colWidths {
+			total += w
+		}
+		if total <= width {
+			break
+		}
+	}
+	if rows == len(items) {
+		colWidths = c.columnWidths(widths, rows)
+	}
+
+	var b strings.Builder
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			b.WriteByte('\n')
+		}
+		for col := range colWidths {
+			i := c.index(row, col, rows, len(colWidths))
+			if i >= len(items) {
+				break
+			}
+			if col > 0 {
+				b.WriteString(c.separator)
+			}
+			b.WriteString(items[i])
+
+			// don't pad the last item of a row
+			if next := c.index(row, col+1, rows, len(colWidths)); col+1 < len(colWidths) && next < len(items) {
+				b.WriteString(strings.Repeat(" ", colWidths[col]-widths[i]))
+			}
+		}
+	}
+	return b.String()
+}
+
+// columnWidths returns the widths of the columns when laying out items of
+// the given widths in rows.
+func (c *columns) columnWidths(widths []int, rows int) []int {
+	cols := (len(widths) + rows - 1) / rows
+	colWidths := make([]int, cols)
+	for i, w := range widths {
+		var col int
+		if c.across {
+			col = i % cols
+		} else {
+			col = i / rows
+		}
+		if w > colWidths[col] {
+			colWidths[col] = w
+		}
+	}
+	return colWidths
+}
+
+// index returns the index of the item at row and col.
+func (c *columns) index(row, col, rows, cols int) int {
+	if c.across {
+		return row*cols + col
+	}
+	return col*rows + row
+}
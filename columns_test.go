@@ -0,0 +1,32 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	items := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta"}
+	tests := []struct {
+		name     string
+		items    []string
+		width    int
+		opts     []ColumnsOption
+		expected string
+	}{
+		{"empty", nil, 80, nil, ""},
+		{"single row", items, 80, nil, "alpha  beta  gamma  delta  epsilon  zeta"},
+		{"down", items, 20, nil, "alpha  delta\nbeta   epsilon\ngamma  zeta"},
+		{"across", items, 30, []ColumnsOption{WithColumnsAcross()}, "alpha  beta     gamma\ndelta  epsilon  zeta"},
+		{"separator", items, 20, []ColumnsOption{WithColumnSeparator(" | ")}, "alpha | delta\nbeta  | epsilon\ngamma | zeta"},
+		{"narrow", items, 5, nil, "alpha\nbeta\ngamma\ndelta\nepsilon\nzeta"},
+		{"styled", []string{String("a").Bold().String(), "bb", "c"}, 5, nil, "\x1b[1ma\x1b[0m   c\nbb"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Columns(test.items, test.width, test.opts...); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
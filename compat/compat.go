@@ -0,0 +1,503 @@
+// Package compat exposes the API surface of upstream
+// github.com/muesli/termenv, mapped onto this fork's implementation.
+//
+// Projects written against upstream termenv can switch to this fork by
+// replacing their import path with this package, without any other code
+// changes. Types are aliases, so values can be passed freely between code
+// using this package and code using the fork directly.
+package compat
+
+import (
+	"io"
+	"text/template"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// Types.
+type (
+	Color        = termenv.Color
+	NoColor      = termenv.NoColor
+	ANSIColor    = termenv.ANSIColor
+	ANSI256Color = termenv.ANSI256Color
+	RGBColor     = termenv.RGBColor
+	Profile      = termenv.Profile
+	Style        = termenv.Style
+	Output       = termenv.Output
+	OutputOption = termenv.OutputOption
+	Environ      = termenv.Environ
+	File         = termenv.File //nolint:staticcheck
+	RGBCache     = termenv.RGBCache
+)
+
+// Errors.
+var (
+	ErrStatusReport = termenv.ErrStatusReport
+	ErrInvalidColor = termenv.ErrInvalidColor
+)
+
+// Color profiles.
+const (
+	TrueColor = termenv.TrueColor
+	ANSI256   = termenv.ANSI256
+	ANSI      = termenv.ANSI
+	Ascii     = termenv.Ascii //nolint:revive
+)
+
+// ANSI color codes.
+const (
+	ANSIBlack         = termenv.ANSIBlack
+	ANSIRed           = termenv.ANSIRed
+	ANSIGreen         = termenv.ANSIGreen
+	ANSIYellow        = termenv.ANSIYellow
+	ANSIBlue          = termenv.ANSIBlue
+	ANSIMagenta       = termenv.ANSIMagenta
+	ANSICyan          = termenv.ANSICyan
+	ANSIWhite         = termenv.ANSIWhite
+	ANSIBrightBlack   = termenv.ANSIBrightBlack
+	ANSIBrightRed     = termenv.ANSIBrightRed
+	ANSIBrightGreen   = termenv.ANSIBrightGreen
+	ANSIBrightYellow  = termenv.ANSIBrightYellow
+	ANSIBrightBlue    = termenv.ANSIBrightBlue
+	ANSIBrightMagenta = termenv.ANSIBrightMagenta
+	ANSIBrightCyan    = termenv.ANSIBrightCyan
+	ANSIBrightWhite   = termenv.ANSIBrightWhite
+)
+
+// Sequence definitions.
+const (
+	ESC = termenv.ESC
+	BEL = termenv.BEL
+	CSI = termenv.CSI
+	OSC = termenv.OSC
+	ST  = termenv.ST
+
+	Foreground = termenv.Foreground
+	Background = termenv.Background
+
+	ResetSeq     = termenv.ResetSeq
+	BoldSeq      = termenv.BoldSeq
+	FaintSeq     = termenv.FaintSeq
+	ItalicSeq    = termenv.ItalicSeq
+	UnderlineSeq = termenv.UnderlineSeq
+	BlinkSeq     = termenv.BlinkSeq
+	ReverseSeq   = termenv.ReverseSeq
+	CrossOutSeq  = termenv.CrossOutSeq
+	OverlineSeq  = termenv.OverlineSeq
+)
+
+// Cursor positioning.
+const (
+	CursorUpSeq              = termenv.CursorUpSeq
+	CursorDownSeq            = termenv.CursorDownSeq
+	CursorForwardSeq         = termenv.CursorForwardSeq
+	CursorBackSeq            = termenv.CursorBackSeq
+	CursorNextLineSeq        = termenv.CursorNextLineSeq
+	CursorPreviousLineSeq    = termenv.CursorPreviousLineSeq
+	CursorHorizontalSeq      = termenv.CursorHorizontalSeq
+	CursorPositionSeq        = termenv.CursorPositionSeq
+	EraseDisplaySeq          = termenv.EraseDisplaySeq
+	EraseLineSeq             = termenv.EraseLineSeq
+	ScrollUpSeq              = termenv.ScrollUpSeq
+	ScrollDownSeq            = termenv.ScrollDownSeq
+	SaveCursorPositionSeq    = termenv.SaveCursorPositionSeq
+	RestoreCursorPositionSeq = termenv.RestoreCursorPositionSeq
+	ChangeScrollingRegionSeq = termenv.ChangeScrollingRegionSeq
+	InsertLineSeq            = termenv.InsertLineSeq
+	DeleteLineSeq            = termenv.DeleteLineSeq
+
+	// Explicit values for EraseLineSeq.
+	EraseLineRightSeq  = termenv.EraseLineRightSeq
+	EraseLineLeftSeq   = termenv.EraseLineLeftSeq
+	EraseEntireLineSeq = termenv.EraseEntireLineSeq
+
+	// Mouse.
+	EnableMousePressSeq         = termenv.EnableMousePressSeq
+	DisableMousePressSeq        = termenv.DisableMousePressSeq
+	EnableMouseSeq              = termenv.EnableMouseSeq
+	DisableMouseSeq             = termenv.DisableMouseSeq
+	EnableMouseHiliteSeq        = termenv.EnableMouseHiliteSeq
+	DisableMouseHiliteSeq       = termenv.DisableMouseHiliteSeq
+	EnableMouseCellMotionSeq    = termenv.EnableMouseCellMotionSeq
+	DisableMouseCellMotionSeq   = termenv.DisableMouseCellMotionSeq
+	EnableMouseAllMotionSeq     = termenv.EnableMouseAllMotionSeq
+	DisableMouseAllMotionSeq    = termenv.DisableMouseAllMotionSeq
+	EnableMouseExtendedModeSeq  = termenv.EnableMouseExtendedModeSeq
+	DisableMouseExtendedModeSeq = termenv.DisableMouseExtendedModeSeq
+	EnableMousePixelsModeSeq    = termenv.EnableMousePixelsModeSeq
+	DisableMousePixelsModeSeq   = termenv.DisableMousePixelsModeSeq
+
+	// Screen.
+	RestoreScreenSeq = termenv.RestoreScreenSeq
+	SaveScreenSeq    = termenv.SaveScreenSeq
+	AltScreenSeq     = termenv.AltScreenSeq
+	ExitAltScreenSeq = termenv.ExitAltScreenSeq
+
+	// Bracketed paste.
+	EnableBracketedPasteSeq  = termenv.EnableBracketedPasteSeq
+	DisableBracketedPasteSeq = termenv.DisableBracketedPasteSeq
+	StartBracketedPasteSeq   = termenv.StartBracketedPasteSeq
+	EndBracketedPasteSeq     = termenv.EndBracketedPasteSeq
+
+	// Session.
+	SetWindowTitleSeq     = termenv.SetWindowTitleSeq
+	SetForegroundColorSeq = termenv.SetForegroundColorSeq
+	SetBackgroundColorSeq = termenv.SetBackgroundColorSeq
+	SetCursorColorSeq     = termenv.SetCursorColorSeq
+	ShowCursorSeq         = termenv.ShowCursorSeq
+	HideCursorSeq         = termenv.HideCursorSeq
+)
+
+// NewOutput returns a new Output for the given writer.
+func NewOutput(w io.Writer, opts ...OutputOption) *Output {
+	return termenv.NewOutput(w, opts...)
+}
+
+// DefaultOutput returns the default global output.
+func DefaultOutput() *Output {
+	return termenv.DefaultOutput()
+}
+
+// SetDefaultOutput sets the default global output.
+func SetDefaultOutput(o *Output) {
+	termenv.SetDefaultOutput(o)
+}
+
+// WithEnvironment returns a new OutputOption for the given environment.
+func WithEnvironment(environ Environ) OutputOption {
+	return termenv.WithEnvironment(environ)
+}
+
+// WithProfile returns a new OutputOption for the given profile.
+func WithProfile(profile Profile) OutputOption {
+	return termenv.WithProfile(profile)
+}
+
+// WithColorCache returns a new OutputOption with fore- and background color
+// values pre-fetched and cached.
+func WithColorCache(v bool) OutputOption {
+	return termenv.WithColorCache(v)
+}
+
+// WithTTY returns a new OutputOption to assume whether or not the output is a
+// TTY.
+func WithTTY(v bool) OutputOption {
+	return termenv.WithTTY(v)
+}
+
+// WithUnsafe returns a new OutputOption with unsafe mode enabled.
+func WithUnsafe() OutputOption {
+	return termenv.WithUnsafe()
+}
+
+// NewRGBCache creates a new RGBCache with the given capacity.
+func NewRGBCache(capacity int) *RGBCache {
+	return termenv.NewRGBCache(capacity)
+}
+
+// GetANSICache returns the global RGBColor->ANSI sequence cache instance.
+// Unlike the RGBCache of older versions, its values are typed.
+func GetANSICache() *termenv.Cache[RGBColor, string] {
+	return termenv.GetANSICache()
+}
+
+// GetSRGBCache returns the global RGBColor->sRGB cache instance. Unlike the
+// RGBCache of older versions, its values are typed.
+func GetSRGBCache() *termenv.Cache[RGBColor, colorful.Color] {
+	return termenv.GetSRGBCache()
+}
+
+// String returns a new Style.
+func String(s ...string) Style {
+	return termenv.String(s...)
+}
+
+// ColorProfile returns the supported color profile.
+func ColorProfile() Profile {
+	return termenv.ColorProfile()
+}
+
+// EnvColorProfile returns the color profile based on environment variables.
+func EnvColorProfile() Profile {
+	return termenv.EnvColorProfile()
+}
+
+// EnvNoColor returns true if the environment variables explicitly disable
+// color output.
+func EnvNoColor() bool {
+	return termenv.EnvNoColor()
+}
+
+// ForegroundColor returns the terminal's default foreground color.
+func ForegroundColor() Color {
+	return termenv.ForegroundColor()
+}
+
+// BackgroundColor returns the terminal's default background color.
+func BackgroundColor() Color {
+	return termenv.BackgroundColor()
+}
+
+// HasDarkBackground returns whether terminal uses a dark-ish background.
+func HasDarkBackground() bool {
+	return termenv.HasDarkBackground()
+}
+
+// ConvertToRGB converts a Color to a colorful.Color.
+func ConvertToRGB(c Color) colorful.Color {
+	return termenv.ConvertToRGB(c)
+}
+
+// TemplateFuncs contains a few useful template helpers.
+func TemplateFuncs(p Profile) template.FuncMap {
+	return termenv.TemplateFuncs(p)
+}
+
+// Hyperlink creates a hyperlink using OSC8.
+func Hyperlink(link, name string) string {
+	return termenv.Hyperlink(link, name)
+}
+
+// Notify triggers a notification using OSC777.
+func Notify(title, body string) {
+	termenv.Notify(title, body)
+}
+
+// Copy copies text to clipboard using OSC 52 escape sequence.
+func Copy(str string) {
+	termenv.Copy(str)
+}
+
+// CopyPrimary copies text to primary clipboard (X11) using OSC 52 escape
+// sequence.
+func CopyPrimary(str string) {
+	termenv.CopyPrimary(str)
+}
+
+// Legacy screen functions, writing to the default output.
+
+// SetForegroundColor sets the default foreground color.
+func SetForegroundColor(color Color) {
+	termenv.DefaultOutput().SetForegroundColor(color)
+}
+
+// SetBackgroundColor sets the default background color.
+func SetBackgroundColor(color Color) {
+	termenv.DefaultOutput().SetBackgroundColor(color)
+}
+
+// SetCursorColor sets the cursor color.
+func SetCursorColor(color Color) {
+	termenv.DefaultOutput().SetCursorColor(color)
+}
+
+// SetWindowTitle sets the terminal window title.
+func SetWindowTitle(title string) {
+	termenv.DefaultOutput().SetWindowTitle(title)
+}
+
+// SetIconName sets the terminal icon name.
+func SetIconName(name string) {
+	termenv.DefaultOutput().SetIconName(name)
+}
+
+// SaveWindowTitle saves the window title and icon name.
+func SaveWindowTitle() {
+	termenv.DefaultOutput().SaveWindowTitle()
+}
+
+// RestoreWindowTitle restores the saved window title and icon name.
+func RestoreWindowTitle() {
+	termenv.DefaultOutput().RestoreWindowTitle()
+}
+
+// MoveCursor moves the cursor to a given position.
+func MoveCursor(row int, column int) {
+	termenv.DefaultOutput().MoveCursor(row, column)
+}
+
+// ChangeScrollingRegion sets the scrolling region of the terminal.
+func ChangeScrollingRegion(top, bottom int) {
+	termenv.DefaultOutput().ChangeScrollingRegion(top, bottom)
+}
+
+// Reset calls Output.Reset on the default output.
+func Reset() {
+	termenv.DefaultOutput().Reset()
+}
+
+// RestoreScreen calls Output.RestoreScreen on the default output.
+func RestoreScreen() {
+	termenv.DefaultOutput().RestoreScreen()
+}
+
+// SaveScreen calls Output.SaveScreen on the default output.
+func SaveScreen() {
+	termenv.DefaultOutput().SaveScreen()
+}
+
+// AltScreen calls Output.AltScreen on the default output.
+func AltScreen() {
+	termenv.DefaultOutput().AltScreen()
+}
+
+// ExitAltScreen calls Output.ExitAltScreen on the default output.
+func ExitAltScreen() {
+	termenv.DefaultOutput().ExitAltScreen()
+}
+
+// ClearScreen calls Output.ClearScreen on the default output.
+func ClearScreen() {
+	termenv.DefaultOutput().ClearScreen()
+}
+
+// HideCursor calls Output.HideCursor on the default output.
+func HideCursor() {
+	termenv.DefaultOutput().HideCursor()
+}
+
+// ShowCursor calls Output.ShowCursor on the default output.
+func ShowCursor() {
+	termenv.DefaultOutput().ShowCursor()
+}
+
+// SaveCursorPosition calls Output.SaveCursorPosition on the default output.
+func SaveCursorPosition() {
+	termenv.DefaultOutput().SaveCursorPosition()
+}
+
+// RestoreCursorPosition calls Output.RestoreCursorPosition on the default output.
+func RestoreCursorPosition() {
+	termenv.DefaultOutput().RestoreCursorPosition()
+}
+
+// ClearLine calls Output.ClearLine on the default output.
+func ClearLine() {
+	termenv.DefaultOutput().ClearLine()
+}
+
+// ClearLineLeft calls Output.ClearLineLeft on the default output.
+func ClearLineLeft() {
+	termenv.DefaultOutput().ClearLineLeft()
+}
+
+// ClearLineRight calls Output.ClearLineRight on the default output.
+func ClearLineRight() {
+	termenv.DefaultOutput().ClearLineRight()
+}
+
+// EnableMousePress calls Output.EnableMousePress on the default output.
+func EnableMousePress() {
+	termenv.DefaultOutput().EnableMousePress()
+}
+
+// DisableMousePress calls Output.DisableMousePress on the default output.
+func DisableMousePress() {
+	termenv.DefaultOutput().DisableMousePress()
+}
+
+// EnableMouse calls Output.EnableMouse on the default output.
+func EnableMouse() {
+	termenv.DefaultOutput().EnableMouse()
+}
+
+// DisableMouse calls Output.DisableMouse on the default output.
+func DisableMouse() {
+	termenv.DefaultOutput().DisableMouse()
+}
+
+// EnableMouseHilite calls Output.EnableMouseHilite on the default output.
+func EnableMouseHilite() {
+	termenv.DefaultOutput().EnableMouseHilite()
+}
+
+// DisableMouseHilite calls Output.DisableMouseHilite on the default output.
+func DisableMouseHilite() {
+	termenv.DefaultOutput().DisableMouseHilite()
+}
+
+// EnableMouseCellMotion calls Output.EnableMouseCellMotion on the default output.
+func EnableMouseCellMotion() {
+	termenv.DefaultOutput().EnableMouseCellMotion()
+}
+
+// DisableMouseCellMotion calls Output.DisableMouseCellMotion on the default output.
+func DisableMouseCellMotion() {
+	termenv.DefaultOutput().DisableMouseCellMotion()
+}
+
+// EnableMouseAllMotion calls Output.EnableMouseAllMotion on the default output.
+func EnableMouseAllMotion() {
+	termenv.DefaultOutput().EnableMouseAllMotion()
+}
+
+// DisableMouseAllMotion calls Output.DisableMouseAllMotion on the default output.
+func DisableMouseAllMotion() {
+	termenv.DefaultOutput().DisableMouseAllMotion()
+}
+
+// EnableBracketedPaste calls Output.EnableBracketedPaste on the default output.
+func EnableBracketedPaste() {
+	termenv.DefaultOutput().EnableBracketedPaste()
+}
+
+// DisableBracketedPaste calls Output.DisableBracketedPaste on the default output.
+func DisableBracketedPaste() {
+	termenv.DefaultOutput().DisableBracketedPaste()
+}
+
+// CursorUp calls Output.CursorUp on the default output.
+func CursorUp(n int) {
+	termenv.DefaultOutput().CursorUp(n)
+}
+
+// CursorDown calls Output.CursorDown on the default output.
+func CursorDown(n int) {
+	termenv.DefaultOutput().CursorDown(n)
+}
+
+// CursorForward calls Output.CursorForward on the default output.
+func CursorForward(n int) {
+	termenv.DefaultOutput().CursorForward(n)
+}
+
+// CursorBack calls Output.CursorBack on the default output.
+func CursorBack(n int) {
+	termenv.DefaultOutput().CursorBack(n)
+}
+
+// CursorNextLine calls Output.CursorNextLine on the default output.
+func CursorNextLine(n int) {
+	termenv.DefaultOutput().CursorNextLine(n)
+}
+
+// CursorPrevLine calls Output.CursorPrevLine on the default output.
+func CursorPrevLine(n int) {
+	termenv.DefaultOutput().CursorPrevLine(n)
+}
+
+// ClearLines calls Output.ClearLines on the default output.
+func ClearLines(n int) {
+	termenv.DefaultOutput().ClearLines(n)
+}
+
+// InsertLines calls Output.InsertLines on the default output.
+func InsertLines(n int) {
+	termenv.DefaultOutput().InsertLines(n)
+}
+
+// DeleteLines calls Output.DeleteLines on the default output.
+func DeleteLines(n int) {
+	termenv.DefaultOutput().DeleteLines(n)
+}
+
+// ScrollUp calls Output.ScrollUp on the default output.
+func ScrollUp(n int) {
+	termenv.DefaultOutput().ScrollUp(n)
+}
+
+// ScrollDown calls Output.ScrollDown on the default output.
+func ScrollDown(n int) {
+	termenv.DefaultOutput().ScrollDown(n)
+}
@@ -0,0 +1,16 @@
+//go:build js || plan9 || aix
+// +build js plan9 aix
+
+package compat
+
+import (
+	"io"
+
+	"github.com/muesli/termenv"
+)
+
+// EnableVirtualTerminalProcessing is a no-op on non-Windows platforms. It
+// returns a non-nil no-op function and no error.
+func EnableVirtualTerminalProcessing(w io.Writer) (func() error, error) {
+	return termenv.EnableVirtualTerminalProcessing(w)
+}
@@ -0,0 +1,195 @@
+package compat_test
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/muesli/termenv/compat"
+)
+
+// The types of the upstream API.
+var (
+	_ compat.Color        = compat.NoColor{}
+	_ compat.ANSIColor    = compat.ANSIRed
+	_ compat.ANSI256Color = 0
+	_ compat.RGBColor     = ""
+	_ compat.Profile      = compat.TrueColor
+	_ compat.Style        = compat.String()
+	_ *compat.Output      = compat.DefaultOutput()
+	_ compat.OutputOption = compat.WithUnsafe()
+	_ compat.Environ      = nil
+	_ compat.File         = nil
+	_ *compat.RGBCache    = compat.NewRGBCache(1)
+)
+
+// TestAPI fails to compile if an identifier of the upstream API is missing.
+func TestAPI(t *testing.T) {
+	// constants
+	_ = compat.ANSI
+	_ = compat.ANSI256
+	_ = compat.ANSIBlack
+	_ = compat.ANSIBlue
+	_ = compat.ANSIBrightBlack
+	_ = compat.ANSIBrightBlue
+	_ = compat.ANSIBrightCyan
+	_ = compat.ANSIBrightGreen
+	_ = compat.ANSIBrightMagenta
+	_ = compat.ANSIBrightRed
+	_ = compat.ANSIBrightWhite
+	_ = compat.ANSIBrightYellow
+	_ = compat.ANSICyan
+	_ = compat.ANSIGreen
+	_ = compat.ANSIMagenta
+	_ = compat.ANSIRed
+	_ = compat.ANSIWhite
+	_ = compat.ANSIYellow
+	_ = compat.Ascii
+	_ = compat.TrueColor
+	_ = compat.ESC
+	_ = compat.BEL
+	_ = compat.CSI
+	_ = compat.OSC
+	_ = compat.ST
+	_ = compat.Foreground
+	_ = compat.Background
+	_ = compat.ResetSeq
+	_ = compat.BoldSeq
+	_ = compat.FaintSeq
+	_ = compat.ItalicSeq
+	_ = compat.UnderlineSeq
+	_ = compat.BlinkSeq
+	_ = compat.ReverseSeq
+	_ = compat.CrossOutSeq
+	_ = compat.OverlineSeq
+
+	// screen, cursor and mouse sequences
+	_ = compat.CursorUpSeq
+	_ = compat.CursorDownSeq
+	_ = compat.CursorForwardSeq
+	_ = compat.CursorBackSeq
+	_ = compat.CursorNextLineSeq
+	_ = compat.CursorPreviousLineSeq
+	_ = compat.CursorHorizontalSeq
+	_ = compat.CursorPositionSeq
+	_ = compat.EraseDisplaySeq
+	_ = compat.EraseLineSeq
+	_ = compat.ScrollUpSeq
+	_ = compat.ScrollDownSeq
+	_ = compat.SaveCursorPositionSeq
+	_ = compat.RestoreCursorPositionSeq
+	_ = compat.ChangeScrollingRegionSeq
+	_ = compat.InsertLineSeq
+	_ = compat.DeleteLineSeq
+	_ = compat.EraseLineRightSeq
+	_ = compat.EraseLineLeftSeq
+	_ = compat.EraseEntireLineSeq
+	_ = compat.EnableMousePressSeq
+	_ = compat.DisableMousePressSeq
+	_ = compat.EnableMouseSeq
+	_ = compat.DisableMouseSeq
+	_ = compat.EnableMouseHiliteSeq
+	_ = compat.DisableMouseHiliteSeq
+	_ = compat.EnableMouseCellMotionSeq
+	_ = compat.DisableMouseCellMotionSeq
+	_ = compat.EnableMouseAllMotionSeq
+	_ = compat.DisableMouseAllMotionSeq
+	_ = compat.EnableMouseExtendedModeSeq
+	_ = compat.DisableMouseExtendedModeSeq
+	_ = compat.EnableMousePixelsModeSeq
+	_ = compat.DisableMousePixelsModeSeq
+	_ = compat.RestoreScreenSeq
+	_ = compat.SaveScreenSeq
+	_ = compat.AltScreenSeq
+	_ = compat.ExitAltScreenSeq
+	_ = compat.EnableBracketedPasteSeq
+	_ = compat.DisableBracketedPasteSeq
+	_ = compat.StartBracketedPasteSeq
+	_ = compat.EndBracketedPasteSeq
+	_ = compat.SetWindowTitleSeq
+	_ = compat.SetForegroundColorSeq
+	_ = compat.SetBackgroundColorSeq
+	_ = compat.SetCursorColorSeq
+	_ = compat.ShowCursorSeq
+	_ = compat.HideCursorSeq
+
+	// errors
+	_ = compat.ErrStatusReport
+	_ = compat.ErrInvalidColor
+
+	// functions
+	_ = compat.BackgroundColor
+	_ = compat.ColorProfile
+	_ = compat.DefaultOutput
+	_ = compat.EnvColorProfile
+	_ = compat.ForegroundColor
+	_ = compat.GetANSICache
+	_ = compat.GetSRGBCache
+	_ = compat.NewOutput
+	_ = compat.NewRGBCache
+	_ = compat.String
+	_ = compat.WithColorCache
+	_ = compat.WithEnvironment
+	_ = compat.WithProfile
+	_ = compat.WithTTY
+	_ = compat.WithUnsafe
+	_ = compat.SetDefaultOutput
+	_ = compat.EnvNoColor
+	_ = compat.HasDarkBackground
+	_ = compat.ConvertToRGB
+	_ = compat.TemplateFuncs
+	_ = compat.Hyperlink
+	_ = compat.Notify
+	_ = compat.Copy
+	_ = compat.CopyPrimary
+	_ = compat.EnableVirtualTerminalProcessing
+	_ = compat.SetForegroundColor
+	_ = compat.SetBackgroundColor
+	_ = compat.SetCursorColor
+	_ = compat.SetWindowTitle
+	_ = compat.MoveCursor
+	_ = compat.ChangeScrollingRegion
+	_ = compat.Reset
+	_ = compat.RestoreScreen
+	_ = compat.SaveScreen
+	_ = compat.AltScreen
+	_ = compat.ExitAltScreen
+	_ = compat.ClearScreen
+	_ = compat.HideCursor
+	_ = compat.ShowCursor
+	_ = compat.SaveCursorPosition
+	_ = compat.RestoreCursorPosition
+	_ = compat.ClearLine
+	_ = compat.ClearLineLeft
+	_ = compat.ClearLineRight
+	_ = compat.ClearLines
+	_ = compat.InsertLines
+	_ = compat.DeleteLines
+	_ = compat.CursorUp
+	_ = compat.CursorDown
+	_ = compat.CursorForward
+	_ = compat.CursorBack
+	_ = compat.CursorNextLine
+	_ = compat.CursorPrevLine
+	_ = compat.EnableMousePress
+	_ = compat.DisableMousePress
+	_ = compat.EnableMouse
+	_ = compat.DisableMouse
+	_ = compat.EnableMouseHilite
+	_ = compat.DisableMouseHilite
+	_ = compat.EnableMouseCellMotion
+	_ = compat.DisableMouseCellMotion
+	_ = compat.EnableMouseAllMotion
+	_ = compat.DisableMouseAllMotion
+	_ = compat.EnableBracketedPaste
+	_ = compat.DisableBracketedPaste
+}
+
+func TestAliases(t *testing.T) {
+	var c termenv.Color = compat.RGBColor("#ff0000")
+	if _, ok := c.(termenv.RGBColor); !ok {
+		t.Errorf("expected compat.RGBColor to be termenv.RGBColor, got %T", c)
+	}
+	if compat.CursorUpSeq != termenv.CursorUpSeq {
+		t.Errorf("expected %q, got %q", termenv.CursorUpSeq, compat.CursorUpSeq)
+	}
+}
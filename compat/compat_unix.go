@@ -0,0 +1,19 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris zos
+
+package compat
+
+import (
+	"io"
+
+	"github.com/muesli/termenv"
+)
+
+// OSCTimeout is the timeout for OSC queries.
+const OSCTimeout = termenv.OSCTimeout
+
+// EnableVirtualTerminalProcessing is a no-op on non-Windows platforms. It
+// returns a non-nil no-op function and no error.
+func EnableVirtualTerminalProcessing(w io.Writer) (func() error, error) {
+	return termenv.EnableVirtualTerminalProcessing(w)
+}
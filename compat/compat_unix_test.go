@@ -0,0 +1,14 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris zos
+
+package compat_test
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv/compat"
+)
+
+func TestUnixAPI(t *testing.T) {
+	_ = compat.OSCTimeout
+}
@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package compat
+
+import "github.com/muesli/termenv"
+
+// EnableWindowsANSIConsole enables virtual terminal processing on Windows
+// platforms. It returns the original console mode and an error if one
+// occurred.
+func EnableWindowsANSIConsole() (uint32, error) {
+	return termenv.EnableWindowsANSIConsole()
+}
+
+// RestoreWindowsConsole restores the console mode to a previous state.
+func RestoreWindowsConsole(mode uint32) error {
+	return termenv.RestoreWindowsConsole(mode)
+}
+
+// EnableVirtualTerminalProcessing enables virtual terminal processing on
+// Windows for o and returns a function that restores o to its previous state.
+func EnableVirtualTerminalProcessing(o *Output) (restoreFunc func() error, err error) {
+	return termenv.EnableVirtualTerminalProcessing(o)
+}
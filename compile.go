@@ -0,0 +1,51 @@
+package termenv
+
+// CompiledStyle is a Style frozen into the sequences rendered before and
+// after the text. Rendering with it only concatenates strings.
+type CompiledStyle struct {
+	style  Style
+	prefix string
+	suffix string
+}
+
+// Compile freezes t into a CompiledStyle, computing its sequences once.
+func (t Style) Compile() CompiledStyle {
+	c := CompiledStyle{style: t}
+	if t.profile == Ascii || len(t.styles) == 0 {
+		return c
+	}
+	if seq := t.sequence(); seq != "" {
+		c.prefix = CSI + seq + "m"
+		c.suffix = CSI + t.reset() + "m"
+	}
+	return c
+}
+
+// Prefix returns the sequence rendered before the text.
+func (c CompiledStyle) Prefix() string {
+	return c.prefix
+}
+
+// Suffix returns the sequence rendered after the text.
+func (c CompiledStyle) Suffix() string {
+	return c.suffix
+}
+
+// Style returns the Style c was compiled from.
+func (c CompiledStyle) Style() Style {
+	return c.style
+}
+
+// Wrap renders s with the compiled style. It renders the same as Styled on
+// the original Style.
+func (c CompiledStyle) Wrap(s string) string {
+	t := c.style
+	if t.lines != lineModeNone || t.tabs > 0 || t.link != "" ||
+		t.bidi != BidiPassThrough || t.combining != CombiningPassThrough {
+		return t.Styled(s)
+	}
+	if s == "" || c.prefix == "" {
+		return s
+	}
+	return c.prefix + s + c.suffix
+}
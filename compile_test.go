@@ -0,0 +1,47 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	bold := String().Bold()
+	for _, style := range []Style{
+		String(),
+		bold,
+		bold.Italic().Foreground(TrueColor.Color("#abcdef")),
+		bold.ScopedReset(),
+		bold.Lines(),
+		bold.TabWidth(4),
+		Ascii.String().Bold(),
+	} {
+		c := style.Compile()
+		for _, s := range []string{"", "foo", "a\tb\nc"} {
+			if exp, got := style.Styled(s), c.Wrap(s); got != exp {
+				t.Errorf("expected %q, got %q", exp, got)
+			}
+		}
+	}
+}
+
+func TestCompiledStyleAffixes(t *testing.T) {
+	c := String().Bold().Italic().ScopedReset().Compile()
+	if exp := "\x1b[1;3m"; c.Prefix() != exp {
+		t.Errorf("expected prefix %q, got %q", exp, c.Prefix())
+	}
+	if exp := "\x1b[22;23m"; c.Suffix() != exp {
+		t.Errorf("expected suffix %q, got %q", exp, c.Suffix())
+	}
+
+	c = Ascii.String().Bold().Compile()
+	if c.Prefix() != "" || c.Suffix() != "" {
+		t.Errorf("expected no sequences, got %q and %q", c.Prefix(), c.Suffix())
+	}
+}
+
+func BenchmarkCompiledStyle(b *testing.B) {
+	c := String().Bold().Italic().Foreground(TrueColor.Color("#abcdef")).Compile()
+	for i := 0; i < b.N; i++ {
+		_ = c.Wrap("token")
+	}
+}
@@ -0,0 +1,23 @@
+package termenv
+
+import (
+	"context"
+)
+
+// outputKey is the context key of the Output stored by WithOutput.
+type outputKey struct{}
+
+// WithOutput returns a copy of ctx carrying out, e.g. the Output of an SSH
+// session, so code deep in a call stack can retrieve it with OutputFrom.
+func WithOutput(ctx context.Context, out *Output) context.Context {
+	return context.WithValue(ctx, outputKey{}, out)
+}
+
+// OutputFrom returns the Output stored in ctx by WithOutput, or the default
+// output if there is none.
+func OutputFrom(ctx context.Context) *Output {
+	if out, ok := ctx.Value(outputKey{}).(*Output); ok && out != nil {
+		return out
+	}
+	return output
+}
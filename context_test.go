@@ -0,0 +1,18 @@
+package termenv
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOutputFrom(t *testing.T) {
+	if OutputFrom(context.Background()) != DefaultOutput() {
+		t.Error("expected the default output")
+	}
+
+	o := NewOutput(nil, WithProfile(ANSI))
+	ctx := WithOutput(context.Background(), o)
+	if OutputFrom(ctx) != o {
+		t.Error("expected the output stored in the context")
+	}
+}
@@ -0,0 +1,128 @@
+package termenv
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+)
+
+// convertChunkSize is the size from which ConvertStream hands the lines read
+// so far to a worker.
+const convertChunkSize = 64 << 10
+
+// convertJob is a chunk of lines to be converted by a worker. The result gets
+// sent on res.
+type convertJob struct {
+	data []byte
+	res  chan []byte
+}
+
+// ConvertStream copies r to w, re-encoding all colors written for profile
+// from for profile to, e.g. to convert gigabytes of CI logs. The input is
+// split into chunks of whole lines, which parallelism workers convert
+// concurrently; their results are written in order. If parallelism is less
+// than 1, one worker per CPU is used. Memory use is bounded by a few chunks
+// per worker, unless single lines are larger than that.
+//
+// If to supports all colors of from, the input is copied as is. Escape
+// sequences spanning lines are passed through without being converted. See
+// Profile.ConvertStream for converting sequentially.
+func ConvertStream(r io.Reader, w io.Writer, from, to Profile, parallelism int) error {
+	if to <= from {
+		_, err := io.Copy(w, r)
+		return err //nolint:wrapcheck
+	}
+	if parallelism < 1 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		jobs  = make(chan convertJob, parallelism)
+		order = make(chan chan []byte, 2*parallelism) //nolint:mnd
+		done  = make(chan struct{})
+	)
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			for job := range jobs {
+				select {
+				case <-done:
+					// the results won't be written anymore
+					job.res <- nil
+					continue
+				default:
+				}
+
+				var buf bytes.Buffer
+				ew := NewEmulationWriter(&buf, to, false)
+				_, _ = ew.Write(job.data)
+				_ = ew.Flush()
+				job.res <- buf.Bytes()
+			}
+		}()
+	}
+
+	// read chunks of lines and queue them, in order
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(order)
+		defer close(jobs)
+
+		br := bufio.NewReaderSize(r, convertChunkSize)
+		for {
+			chunk, err := readLines(br, convertChunkSize)
+			if len(chunk) > 0 {
+				res := make(chan []byte, 1)
+				select {
+				case order <- res:
+				case <-done:
+					readErr <- nil
+					return
+				}
+				jobs <- convertJob{data: chunk, res: res}
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	var writeErr error
+	for res := range order {
+		data := <-res
+		if writeErr != nil {
+			continue
+		}
+		if _, err := w.Write(data); err != nil {
+			writeErr = err
+			close(done)
+		}
+	}
+	if err := <-readErr; err != nil {
+		return err
+	}
+	return writeErr //nolint:wrapcheck
+}
+
+// readLines reads whole lines from br until at least size bytes are read,
+// or the input ends.
+func readLines(br *bufio.Reader, size int) ([]byte, error) {
+	var chunk []byte
+	for {
+		line, err := br.ReadSlice('\n')
+		chunk = append(chunk, line...)
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			// the line continues
+		case err != nil:
+			return chunk, err //nolint:wrapcheck
+		case len(chunk) >= size:
+			return chunk, nil
+		}
+	}
+}
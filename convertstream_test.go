@@ -0,0 +1,68 @@
+package termenv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestConvertStreamParallel(t *testing.T) {
+	var in strings.Builder
+	for i := 0; i < 20000; i++ {
+		in.WriteString(TrueColor.String("line").Foreground(NewRGBColor(uint8(i), uint8(i>>8), 128)).String())
+		if i%1000 == 0 {
+			// a line longer than a chunk
+			in.WriteString(strings.Repeat("x", convertChunkSize+10))
+		}
+		in.WriteString("\n")
+	}
+	in.WriteString("\x1b[38;2;255;0;0mno trailing newline")
+
+	var exp bytes.Buffer
+	if _, err := ANSI256.ConvertStream(&exp, strings.NewReader(in.String())); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, parallelism := range []int{0, 1, 4} {
+		var buf bytes.Buffer
+		if err := ConvertStream(iotest.HalfReader(strings.NewReader(in.String())), &buf, TrueColor, ANSI256, parallelism); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), exp.Bytes()) {
+			t.Errorf("parallelism %d: output differs from the sequential conversion", parallelism)
+		}
+	}
+
+	// nothing to convert
+	var buf bytes.Buffer
+	if err := ConvertStream(strings.NewReader(in.String()), &buf, ANSI256, TrueColor, 4); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != in.String() {
+		t.Error("expected the input to be copied as is")
+	}
+}
+
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestConvertStreamErrors(t *testing.T) {
+	in := strings.Repeat("\x1b[38;2;1;2;3mfoo\x1b[0m\n", 20000)
+	if err := ConvertStream(strings.NewReader(in), &failingWriter{n: 2}, TrueColor, ANSI, 4); err == nil || err.Error() != "disk full" {
+		t.Errorf("expected the write error, got %v", err)
+	}
+
+	r := iotest.TimeoutReader(strings.NewReader(in))
+	if err := ConvertStream(r, &bytes.Buffer{}, TrueColor, ANSI, 4); !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("expected the read error, got %v", err)
+	}
+}
@@ -1,28 +1,91 @@
 package termenv
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/aymanbagabas/go-osc52/v2"
 )
 
+// ErrCopyLimit gets returned by CopyWithOptions when the text exceeds the
+// limit set with WithCopyLimit.
+var ErrCopyLimit = errors.New("text exceeds copy limit")
+
+// XTermCopyLimit is the largest text, in bytes, whose base64 encoding stays
+// below the 100000 bytes xterm accepts in an OSC 52 sequence. Use it with
+// WithCopyLimit to catch texts xterm would silently drop.
+const XTermCopyLimit = 74994
+
+// CopyOption sets an option on CopyWithOptions and CopyPrimaryWithOptions.
+type CopyOption = func(*copier)
+
+type copier struct {
+	limit  int
+	chunks bool
+}
+
+// WithCopyLimit returns a new CopyOption limiting the size of the text sent
+// to n bytes. Larger text is not copied, and ErrCopyLimit is returned, as
+// terminals silently drop sequences exceeding their own limit. Zero or a
+// negative n disables the limit, which is the default.
+func WithCopyLimit(n int) CopyOption {
+	return func(c *copier) {
+		c.limit = n
+	}
+}
+
+// WithCopyChunks returns a new CopyOption sending the encoded text in
+// chunks of 76 bytes, each wrapped in a DCS sequence, which passes through
+// GNU screen and its limited buffer. It is enabled automatically when
+// running in screen.
+func WithCopyChunks() CopyOption {
+	return func(c *copier) {
+		c.chunks = true
+	}
+}
+
 // Copy copies text to clipboard using OSC 52 escape sequence.
 func (o Output) Copy(str string) {
-	s := osc52.New(str)
-	if strings.HasPrefix(o.environ.Getenv("TERM"), "screen") {
-		s = s.Screen()
-	}
-	_, _ = s.WriteTo(o)
+	_ = o.CopyWithOptions(str)
 }
 
 // CopyPrimary copies text to primary clipboard (X11) using OSC 52 escape
 // sequence.
 func (o Output) CopyPrimary(str string) {
-	s := osc52.New(str).Primary()
-	if strings.HasPrefix(o.environ.Getenv("TERM"), "screen") {
+	_ = o.CopyPrimaryWithOptions(str)
+}
+
+// CopyWithOptions copies text to clipboard using OSC 52 escape sequence,
+// like Copy, applying the given options. It returns ErrCopyLimit if the text
+// exceeds the limit set with WithCopyLimit, or the write error.
+func (o Output) CopyWithOptions(str string, opts ...CopyOption) error {
+	return o.copy(str, osc52.New(str), opts)
+}
+
+// CopyPrimaryWithOptions copies text to primary clipboard (X11) using OSC 52
+// escape sequence, like CopyPrimary, applying the given options. It returns
+// ErrCopyLimit if the text exceeds the limit set with WithCopyLimit, or the
+// write error.
+func (o Output) CopyPrimaryWithOptions(str string, opts ...CopyOption) error {
+	return o.copy(str, osc52.New(str).Primary(), opts)
+}
+
+func (o Output) copy(str string, s osc52.Sequence, opts []CopyOption) error {
+	c := &copier{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.limit > 0 && len(str) > c.limit {
+		return fmt.Errorf("%w: %d bytes exceed %d bytes", ErrCopyLimit, len(str), c.limit)
+	}
+	if c.chunks || strings.HasPrefix(o.environ.Getenv("TERM"), "screen") ||
+		o.environ.Getenv("STY") != "" {
 		s = s.Screen()
 	}
-	_, _ = s.WriteTo(o)
+	_, err := o.WriteString(s.String())
+	return err
 }
 
 // Copy copies text to clipboard using OSC 52 escape sequence.
@@ -35,3 +98,15 @@ func Copy(str string) {
 func CopyPrimary(str string) {
 	output.CopyPrimary(str)
 }
+
+// CopyWithOptions copies text to clipboard using OSC 52 escape sequence,
+// applying the given options.
+func CopyWithOptions(str string, opts ...CopyOption) error {
+	return output.CopyWithOptions(str, opts...)
+}
+
+// CopyPrimaryWithOptions copies text to primary clipboard (X11) using OSC 52
+// escape sequence, applying the given options.
+func CopyPrimaryWithOptions(str string, opts ...CopyOption) error {
+	return output.CopyPrimaryWithOptions(str, opts...)
+}
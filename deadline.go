@@ -0,0 +1,130 @@
+package termenv
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrWriteTimeout gets returned when a write to the terminal didn't complete
+// within the output's write deadline.
+var ErrWriteTimeout = errors.New("write timeout")
+
+// maximum number of bytes buffered while the terminal is stalled.
+const maxStalledBuffer = 1 << 20
+
+// WritePolicy decides what happens to writes while the terminal is stalled,
+// e.g. because the user paused the output with ctrl+s.
+type WritePolicy int
+
+const (
+	// WriteDrop drops all writes while a previous write is still pending,
+	// returning ErrWriteTimeout.
+	WriteDrop WritePolicy = iota
+	// WriteBuffer buffers writes while a previous write is still pending
+	// and flushes them once the terminal accepts data again. Writes get
+	// dropped once the buffer is full.
+	WriteBuffer
+)
+
+// WithWriteDeadline returns a new OutputOption limiting how long a write may
+// block. Writes taking longer than d return, while the data keeps getting
+// written in the background. Further writes are handled according to the
+// given policy until the terminal accepts data again, so renderers stay
+// responsive when the terminal stalls. An error from such a background write
+// is returned by the next write.
+func WithWriteDeadline(d time.Duration, policy WritePolicy) OutputOption {
+	return func(o *Output) {
+		o.w = &deadlineWriter{
+			w:       o.Writer(),
+			timeout: d,
+			policy:  policy,
+		}
+	}
+}
+
+// deadlineWriter writes to w in the background, waiting at most timeout for
+// each write to complete.
+type deadlineWriter struct {
+	w       io.Writer
+	timeout time.Duration
+	policy  WritePolicy
+
+	mu   sync.Mutex
+	busy bool
+	buf  []byte
+	err  error // error of the last flush, not yet returned
+}
+
+func (dw *deadlineWriter) unwrap() io.Writer {
+	return dw.w
+}
+
+func (dw *deadlineWriter) Write(p []byte) (int, error) {
+	dw.mu.Lock()
+	if err := dw.err; err != nil {
+		// a write timed out and failed in the background
+		dw.err = nil
+		dw.mu.Unlock()
+		return 0, err
+	}
+	if dw.busy {
+		defer dw.mu.Unlock()
+		if dw.policy == WriteBuffer && len(dw.buf)+len(p) <= maxStalledBuffer {
+			dw.buf = append(dw.buf, p...)
+			return len(p), nil
+		}
+		return 0, ErrWriteTimeout
+	}
+	dw.busy = true
+	dw.mu.Unlock()
+
+	data := append([]byte{}, p...)
+	done := make(chan struct{})
+	go func() {
+		dw.flush(data)
+		close(done)
+	}()
+
+	timer := time.NewTimer(dw.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		dw.mu.Lock()
+		err := dw.err
+		dw.err = nil
+		dw.mu.Unlock()
+		if err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	case <-timer.C:
+		if dw.policy == WriteBuffer {
+			return len(p), nil
+		}
+		return 0, ErrWriteTimeout
+	}
+}
+
+// flush writes data and everything buffered in the meantime, and stores the
+// first error in dw.err.
+func (dw *deadlineWriter) flush(data []byte) {
+	var err error
+	for {
+		if _, werr := dw.w.Write(data); werr != nil && err == nil {
+			err = werr
+		}
+
+		dw.mu.Lock()
+		if len(dw.buf) == 0 {
+			dw.busy = false
+			dw.err = err
+			dw.mu.Unlock()
+			return
+		}
+		data, dw.buf = dw.buf, nil
+		dw.mu.Unlock()
+	}
+}
@@ -0,0 +1,88 @@
+package termenv
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWriteDeadlineDrop(t *testing.T) {
+	r, w := io.Pipe()
+	o := NewOutput(w, WithWriteDeadline(10*time.Millisecond, WriteDrop), WithProfile(Ascii))
+
+	if _, err := o.WriteString("foo"); !errors.Is(err, ErrWriteTimeout) {
+		t.Fatalf("expected ErrWriteTimeout, got %v", err)
+	}
+
+	start := time.Now()
+	if _, err := o.WriteString("bar"); !errors.Is(err, ErrWriteTimeout) {
+		t.Fatalf("expected ErrWriteTimeout, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("expected writes to be dropped immediately while stalled")
+	}
+
+	b := make([]byte, 3)
+	if _, err := io.ReadFull(r, b); err != nil || string(b) != "foo" {
+		t.Errorf("expected pending write to complete, got %q, %v", b, err)
+	}
+}
+
+func TestWriteDeadlineBuffer(t *testing.T) {
+	r, w := io.Pipe()
+	o := NewOutput(w, WithWriteDeadline(10*time.Millisecond, WriteBuffer), WithProfile(Ascii))
+
+	for _, s := range []string{"foo", "bar", "baz"} {
+		if n, err := o.WriteString(s); err != nil || n != len(s) {
+			t.Fatalf("unexpected write result: %d, %v", n, err)
+		}
+	}
+
+	b := make([]byte, 9)
+	if _, err := io.ReadFull(r, b); err != nil || string(b) != "foobarbaz" {
+		t.Errorf("expected buffered writes to be flushed in order, got %q, %v", b, err)
+	}
+}
+
+// stalledWriter blocks until release is closed, then fails.
+type stalledWriter struct {
+	release chan struct{}
+}
+
+func (w stalledWriter) Write([]byte) (int, error) {
+	<-w.release
+	return 0, io.ErrClosedPipe
+}
+
+func TestWriteDeadlineBackgroundError(t *testing.T) {
+	w := stalledWriter{release: make(chan struct{})}
+	o := NewOutput(w, WithWriteDeadline(10*time.Millisecond, WriteBuffer), WithProfile(Ascii))
+	dw := o.w.(*deadlineWriter)
+
+	if n, err := o.WriteString("foo"); err != nil || n != 3 {
+		t.Fatalf("unexpected write result: %d, %v", n, err)
+	}
+	close(w.release)
+	for busy := true; busy; {
+		dw.mu.Lock()
+		busy = dw.busy
+		dw.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := o.WriteString("bar"); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("expected the background error, got %v", err)
+	}
+}
+
+func TestWriteDeadlineWriter(t *testing.T) {
+	o := tempOutput(t)
+	WithWriteDeadline(time.Second, WriteDrop)(o)
+
+	if o.TTY() == nil {
+		t.Error("expected the underlying file to be accessible")
+	}
+	o.Reset()
+	verify(t, o, "\x1b[0m")
+}
@@ -0,0 +1,130 @@
+package termenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// detectionVars are the environment variables color profile detection
+// considers.
+var detectionVars = []string{
+	"TERM",
+	"COLORTERM",
+	"TERM_PROGRAM",
+	"NO_COLOR",
+	"CLICOLOR",
+	"CLICOLOR_FORCE",
+	"FORCE_COLOR",
+	"CI",
+	"GOOGLE_CLOUD_SHELL",
+	"ConEmuANSI",
+	"ANSICON",
+}
+
+// Signal is an environment variable considered by the color profile
+// detection.
+type Signal struct {
+	Name  string
+	Value string
+	Set   bool
+}
+
+// Detection explains how the color profile of an Output was chosen.
+type Detection struct {
+	// Profile is the profile in use.
+	Profile Profile
+	// Detected is false if the profile was set with WithProfile.
+	Detected bool
+	// TTY reports whether the output is considered a terminal.
+	TTY bool
+	// SafeMode reports whether safe mode is enabled, see ForceSafe.
+	SafeMode bool
+	// Signals lists the environment variables considered.
+	Signals []Signal
+	// Reason describes the decision.
+	Reason string
+}
+
+// String formats the report as human-readable text, one line per signal,
+// e.g. to be printed by a --debug flag.
+func (r Detection) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "profile:   %s\n", r.Profile.Name())
+	fmt.Fprintf(&b, "reason:    %s\n", r.Reason)
+	fmt.Fprintf(&b, "tty:       %t\n", r.TTY)
+	fmt.Fprintf(&b, "safe mode: %t\n", r.SafeMode)
+	for _, s := range r.Signals {
+		if s.Set {
+			fmt.Fprintf(&b, "%s=%q\n", s.Name, s.Value)
+		} else {
+			fmt.Fprintf(&b, "%s (unset)\n", s.Name)
+		}
+	}
+	return b.String()
+}
+
+// DetectionReport returns a report explaining why the default output uses
+// its color profile. Printing it answers most "why is my output not colored"
+// questions.
+func DetectionReport() Detection {
+	return output.DetectionReport()
+}
+
+// DetectionReport returns a report explaining why o uses its color profile.
+func (o *Output) DetectionReport() Detection {
+	r := Detection{
+		Profile:  o.profile(),
+		Detected: o.detect,
+		TTY:      o.isTTY(),
+		SafeMode: o.SafeMode(),
+	}
+
+	env := map[string]string{}
+	for _, kv := range o.environ.Environ() {
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			env[kv[:i]] = kv[i+1:]
+		}
+	}
+	for _, name := range detectionVars {
+		v, ok := env[name]
+		if !ok {
+			// not every Environ lists its variables
+			v = o.environ.Getenv(name)
+			ok = v != ""
+		}
+		r.Signals = append(r.Signals, Signal{Name: name, Value: v, Set: ok})
+	}
+
+	r.Reason = o.detectionReason()
+	return r
+}
+
+// detectionReason describes which rule of EnvColorProfile chose the profile.
+func (o *Output) detectionReason() string {
+	if !o.detect {
+		return "profile set explicitly"
+	}
+	if p, ok := o.forcedColor(); ok {
+		return fmt.Sprintf("FORCE_COLOR selects %s", p.Name())
+	}
+	if o.environ.Getenv("NO_COLOR") != "" {
+		return "NO_COLOR is set"
+	}
+	if o.EnvNoColor() {
+		return "CLICOLOR=0 disables colors"
+	}
+
+	p := o.ColorProfile()
+	switch {
+	case o.cliColorForced() && p == Ascii:
+		return "CLICOLOR_FORCE enables colors"
+	case o.SafeMode() && p < ANSI:
+		return "safe mode enables ANSI colors"
+	case !o.isTTY():
+		if o.environ.Getenv("CI") != "" {
+			return "CI is set, so the output is not considered a terminal"
+		}
+		return "output is not a terminal"
+	}
+	return fmt.Sprintf("terminal supports %s", p.Name())
+}
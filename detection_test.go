@@ -0,0 +1,65 @@
+package termenv
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDetectionReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ map[string]string
+		tty     bool
+		opts    []OutputOption
+		reason  string
+	}{
+		{"explicit", nil, true, []OutputOption{WithProfile(ANSI)}, "profile set explicitly"},
+		{"force_color", map[string]string{"FORCE_COLOR": "2"}, false, nil, "FORCE_COLOR selects ANSI256"},
+		{"no_color", map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, true, nil, "NO_COLOR is set"},
+		{"clicolor", map[string]string{"CLICOLOR": "0"}, true, nil, "CLICOLOR=0 disables colors"},
+		{"clicolor_force", map[string]string{"CLICOLOR_FORCE": "1"}, false, nil, "CLICOLOR_FORCE enables colors"},
+		{"pipe", map[string]string{"TERM": "xterm-256color"}, false, nil, "output is not a terminal"},
+		{"ci", map[string]string{"CI": "true"}, false, nil, "CI is set, so the output is not considered a terminal"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := append([]OutputOption{
+				WithTTY(test.tty),
+				WithEnvironment(mapEnv(test.environ)),
+			}, test.opts...)
+			o := NewOutput(io.Discard, opts...)
+
+			r := o.DetectionReport()
+			if r.Reason != test.reason {
+				t.Errorf("expected reason %q, got %q", test.reason, r.Reason)
+			}
+			if r.Profile != o.Profile {
+				t.Errorf("expected profile %s, got %s", o.Profile.Name(), r.Profile.Name())
+			}
+			for _, s := range r.Signals {
+				v, ok := test.environ[s.Name]
+				if s.Set != ok || s.Value != v {
+					t.Errorf("expected signal %s=%q (set: %t), got %q (set: %t)", s.Name, v, ok, s.Value, s.Set)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectionReportString(t *testing.T) {
+	o := NewOutput(io.Discard, WithTTY(true), WithEnvironment(mapEnv{"TERM": "xterm-256color"}))
+	s := o.DetectionReport().String()
+
+	for _, exp := range []string{
+		"profile:   " + o.Profile.Name() + "\n",
+		"tty:       true\n",
+		"TERM=\"xterm-256color\"\n",
+		"NO_COLOR (unset)\n",
+	} {
+		if !strings.Contains(s, exp) {
+			t.Errorf("expected report to contain %q, got:\n%s", exp, s)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package termenv
+
+import (
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// bayer4 is a 4x4 ordered dithering threshold matrix.
+var bayer4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// ConvertDithered transforms a given Color to a Color supported within the
+// Profile, like Convert. Instead of always picking the nearest color, it
+// applies ordered dithering: depending on the cell position x, y, it picks
+// either the nearest or the second nearest color, in proportion to their
+// distances. Rendering a gradient with it shows smooth ramps instead of
+// bands on terminals without true color support. No randomness is involved:
+// the result only depends on the color and the cell position, so rendered
+// output stays reproducible, e.g. in golden tests.
+//
+// Only RGB colors get dithered; other colors are converted as by Convert.
+func (p Profile) ConvertDithered(c Color, x, y int) Color {
+	return p.convertDithered(c, x, y, nil)
+}
+
+// ConvertDithered transforms a given Color to a Color supported by the
+// output's profile, applying ordered dithering. See Profile.ConvertDithered.
+func (o *Output) ConvertDithered(c Color, x, y int) Color {
+	return o.profile().convertDithered(c, x, y, o.palette)
+}
+
+//nolint:mnd
+func (p Profile) convertDithered(c Color, x, y int, pal *Palette) Color {
+	rgb, ok := resolveColor(c).(RGBColor)
+	if !ok {
+		return p.convert(c, pal)
+	}
+	if p == Ascii || p == TrueColor {
+		return p.convert(c, pal)
+	}
+	h, err := colorful.Hex(string(rgb))
+	if err != nil {
+		return nil
+	}
+
+	// the 16 base colors are often customized, so only the 6x6x6 cube and
+	// the grayscale ramp are used for ANSI256
+	first, last := 16, 255
+	hex := func(i int) string {
+		return ansiHex[i]
+	}
+	if p == ANSI {
+		first, last = 0, 15
+		if pal != nil {
+			hex = func(i int) string {
+				return string(pal[i])
+			}
+		}
+	}
+
+	a, b := -1, -1
+	var da, db float64
+	for i := first; i <= last; i++ {
+		ch, _ := colorful.Hex(hex(i))
+		d := h.DistanceLab(ch)
+		switch {
+		case a < 0 || d < da:
+			b, db = a, da
+			a, da = i, d
+		case b < 0 || d < db:
+			b, db = i, d
+		}
+	}
+
+	idx := a
+	if da > 0 && b >= 0 {
+		t := da / (da + db)
+		if t > (bayer4[y&3][x&3]+0.5)/16 {
+			idx = b
+		}
+	}
+
+	if p == ANSI {
+		return ANSIColor(idx)
+	}
+	return ANSI256Color(idx)
+}
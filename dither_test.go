@@ -0,0 +1,37 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestConvertDithered(t *testing.T) {
+	// exact palette entries never get dithered
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if c := ANSI256.ConvertDithered(RGBColor("#5f87af"), x, y); c != ANSI256Color(67) {
+				t.Errorf("expected %v, got %v", ANSI256Color(67), c)
+			}
+		}
+	}
+
+	// a color between two palette entries mixes both of them
+	seen := map[Color]int{}
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			seen[ANSI256.ConvertDithered(RGBColor("#4a4a4a"), x, y)]++
+		}
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected two different colors, got %v", seen)
+	}
+
+	if c := TrueColor.ConvertDithered(RGBColor("#4a4a4a"), 0, 0); c != RGBColor("#4a4a4a") {
+		t.Errorf("expected true color to be kept, got %v", c)
+	}
+	if c := ANSI.ConvertDithered(ANSI256Color(196), 0, 0); c != ANSI.ConvertColor(ANSI256Color(196)) {
+		t.Errorf("expected non-RGB colors to be converted as usual, got %v", c)
+	}
+	if _, ok := ANSI.ConvertDithered(RGBColor("#4a4a4a"), 1, 2).(ANSIColor); !ok {
+		t.Error("expected an ANSI color")
+	}
+}
@@ -0,0 +1,64 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// DEC line attributes, sent with a plain ESC prefix.
+const (
+	DoubleHeightTopSeq    = "#3" // DECDHL, top half
+	DoubleHeightBottomSeq = "#4" // DECDHL, bottom half
+	SingleWidthSeq        = "#5" // DECSWL
+	DoubleWidthSeq        = "#6" // DECDWL
+)
+
+// DoubleWidthLine renders s on a line of double-width characters, e.g. for a
+// banner-style heading. The line attribute applies to the entire line the
+// cursor is on, so s should be printed on a line of its own. Every character
+// occupies two cells; see DoubleLineWidth.
+func DoubleWidthLine(s string) string {
+	return string(ESC) + DoubleWidthSeq + s
+}
+
+// DoubleHeightLine renders s in double-width, double-height characters. The
+// terminal draws the top and bottom halves of the characters on separate
+// lines, so top and bottom need to be printed on two consecutive lines.
+func DoubleHeightLine(s string) (top, bottom string) {
+	return string(ESC) + DoubleHeightTopSeq + s, string(ESC) + DoubleHeightBottomSeq + s
+}
+
+// DoubleLineWidth returns the number of cells s occupies on a double-width
+// or double-height line, ignoring escape sequences.
+func DoubleLineWidth(s string) int {
+	return 2 * StringWidth(s) //nolint:mnd
+}
+
+// TruncateDoubleLine truncates s, so it fits a double-width or double-height
+// line of a terminal with the given number of columns. Terminals don't wrap
+// these lines, but cut them off at half the columns.
+func TruncateDoubleLine(s string, columns int) string {
+	limit := columns / 2 //nolint:mnd
+	if StringWidth(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	width := 0
+	walkText(s, func(text string) {
+		g := uniseg.NewGraphemes(text)
+		for g.Next() {
+			w := g.Width()
+			if width+w > limit {
+				width = limit + 1
+				return
+			}
+			width += w
+			b.WriteString(g.Str())
+		}
+	}, func(seq string) {
+		b.WriteString(seq)
+	})
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestDoubleLines(t *testing.T) {
+	if got, exp := DoubleWidthLine("Title"), "\x1b#6Title"; got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	top, bottom := DoubleHeightLine("Title")
+	if exp := "\x1b#3Title"; top != exp {
+		t.Errorf("expected top %q, got %q", exp, top)
+	}
+	if exp := "\x1b#4Title"; bottom != exp {
+		t.Errorf("expected bottom %q, got %q", exp, bottom)
+	}
+
+	if w := DoubleLineWidth(String("Title").Bold().String()); w != 10 {
+		t.Errorf("expected width 10, got %d", w)
+	}
+}
+
+func TestTruncateDoubleLine(t *testing.T) {
+	bold := String().Bold()
+	tests := []struct {
+		in       string
+		columns  int
+		expected string
+	}{
+		{"Title", 10, "Title"},
+		{"Title", 8, "Titl"},
+		{"Title", 7, "Tit"},
+		{"日本語", 8, "日本"},
+		{bold.Styled("Title"), 6, "\x1b[1mTit\x1b[0m"},
+	}
+
+	for _, test := range tests {
+		if got := TruncateDoubleLine(test.in, test.columns); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
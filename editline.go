@@ -0,0 +1,61 @@
+package termenv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Line editing primitives. These are meant for readline-like packages that
+// redraw parts of a prompt line in place. Each primitive is emitted with a
+// single write, and no-ops are elided entirely.
+
+// InsertChars inserts n blank cells at the cursor position, shifting the
+// remainder of the line to the right.
+func (o Output) InsertChars(n int) {
+	if n <= 0 {
+		return
+	}
+	fmt.Fprintf(o.w, CSI+InsertCharSeq, n) //nolint:errcheck
+}
+
+// DeleteChars deletes n cells at the cursor position, shifting the remainder
+// of the line to the left.
+func (o Output) DeleteChars(n int) {
+	if n <= 0 {
+		return
+	}
+	fmt.Fprintf(o.w, CSI+DeleteCharSeq, n) //nolint:errcheck
+}
+
+// KillToEnd erases the line from the cursor position to its end.
+func (o Output) KillToEnd() {
+	fmt.Fprint(o.w, CSI+EraseLineRightSeq) //nolint:errcheck
+}
+
+// InsertString inserts s at the cursor position without overwriting the text
+// to its right.
+func (o Output) InsertString(s string) {
+	w := uniseg.StringWidth(s)
+	if w == 0 {
+		return
+	}
+	_, _ = o.WriteString(fmt.Sprintf(CSI+InsertCharSeq, w) + s)
+}
+
+// InsertChar inserts r at the cursor position without overwriting the text to
+// its right.
+func (o Output) InsertChar(r rune) {
+	o.InsertString(string(r))
+}
+
+// RedrawFromColumn moves the cursor to the given (1-based) column, writes s
+// and erases whatever was left on the line after it.
+func (o Output) RedrawFromColumn(column int, s string) {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(CSI+CursorHorizontalSeq, column))
+	b.WriteString(s)
+	b.WriteString(CSI + EraseLineRightSeq)
+	_, _ = o.WriteString(b.String())
+}
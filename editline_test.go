@@ -0,0 +1,36 @@
+package termenv
+
+import "testing"
+
+func TestInsertChars(t *testing.T) {
+	o := tempOutput(t)
+	o.InsertChars(3)
+	o.InsertChars(0)
+	verify(t, o, "\x1b[3@")
+}
+
+func TestDeleteChars(t *testing.T) {
+	o := tempOutput(t)
+	o.DeleteChars(2)
+	o.DeleteChars(-1)
+	verify(t, o, "\x1b[2P")
+}
+
+func TestKillToEnd(t *testing.T) {
+	o := tempOutput(t)
+	o.KillToEnd()
+	verify(t, o, "\x1b[0K")
+}
+
+func TestInsertChar(t *testing.T) {
+	o := tempOutput(t)
+	o.InsertChar('x')
+	o.InsertChar('世')
+	verify(t, o, "\x1b[1@x\x1b[2@世")
+}
+
+func TestRedrawFromColumn(t *testing.T) {
+	o := tempOutput(t)
+	o.RedrawFromColumn(5, "foo")
+	verify(t, o, "\x1b[5Gfoo\x1b[0K")
+}
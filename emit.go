@@ -0,0 +1,106 @@
+package termenv
+
+// SeqKind is the kind of an escape sequence, determined by its introducer.
+type SeqKind int
+
+// Sequence kinds.
+const (
+	SeqCSI SeqKind = iota
+	SeqOSC
+	SeqDCS
+	SeqAPC
+	SeqPM
+	SeqSOS
+	SeqSS2
+	SeqSS3
+)
+
+// Introducer returns the sequence introducer of the kind, e.g. CSI.
+func (k SeqKind) Introducer() string {
+	switch k {
+	case SeqCSI:
+		return CSI
+	case SeqOSC:
+		return OSC
+	case SeqDCS:
+		return DCS
+	case SeqAPC:
+		return APC
+	case SeqPM:
+		return PM
+	case SeqSOS:
+		return SOS
+	case SeqSS2:
+		return SS2
+	case SeqSS3:
+		return SS3
+	}
+	return ""
+}
+
+// IsString returns whether sequences of this kind carry a string payload and
+// need to be closed by a terminator.
+func (k SeqKind) IsString() bool {
+	switch k {
+	case SeqOSC, SeqDCS, SeqAPC, SeqPM, SeqSOS:
+		return true
+	}
+	return false
+}
+
+// String returns the name of the kind, e.g. "CSI".
+func (k SeqKind) String() string {
+	switch k {
+	case SeqCSI:
+		return "CSI"
+	case SeqOSC:
+		return "OSC"
+	case SeqDCS:
+		return "DCS"
+	case SeqAPC:
+		return "APC"
+	case SeqPM:
+		return "PM"
+	case SeqSOS:
+		return "SOS"
+	case SeqSS2:
+		return "SS2"
+	case SeqSS3:
+		return "SS3"
+	}
+	return "Unknown"
+}
+
+// Emit builds an escape sequence of the given kind, for sequences termenv
+// doesn't wrap yet. The meaning of params and payload depends on the kind:
+//
+//   - CSI, SS2, SS3: params are followed by payload, which holds the
+//     intermediate and final bytes, e.g. Emit(SeqCSI, "2", "J").
+//   - OSC, APC: params and payload get separated by a semicolon, e.g.
+//     Emit(SeqOSC, "2", "title").
+//   - DCS, PM, SOS: params are directly followed by payload, e.g.
+//     Emit(SeqDCS, "+q", "544e").
+//
+// String sequences are always terminated by ST, which unlike BEL is valid for
+// all of them.
+func Emit(kind SeqKind, params, payload string) string {
+	s := kind.Introducer() + params
+	switch kind {
+	case SeqOSC, SeqAPC:
+		if payload != "" {
+			s += ";" + payload
+		}
+	default:
+		s += payload
+	}
+
+	if kind.IsString() {
+		s += ST
+	}
+	return s
+}
+
+// WriteSequence writes an escape sequence built by Emit to the output.
+func (o Output) WriteSequence(kind SeqKind, params, payload string) (int, error) {
+	return o.WriteString(Emit(kind, params, payload))
+}
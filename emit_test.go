@@ -0,0 +1,34 @@
+package termenv
+
+import "testing"
+
+func TestEmit(t *testing.T) {
+	tests := []struct {
+		kind     SeqKind
+		params   string
+		payload  string
+		expected string
+	}{
+		{SeqCSI, "2", "J", "\x1b[2J"},
+		{SeqCSI, "?25", "l", "\x1b[?25l"},
+		{SeqOSC, "2", "title", "\x1b]2;title\x1b\\"},
+		{SeqOSC, "8;;", "", "\x1b]8;;\x1b\\"},
+		{SeqDCS, "+q", "544e", "\x1bP+q544e\x1b\\"},
+		{SeqAPC, "Ga=T", "AAAA", "\x1b_Ga=T;AAAA\x1b\\"},
+		{SeqSS3, "", "P", "\x1bOP"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.kind.String(), func(t *testing.T) {
+			if s := Emit(test.kind, test.params, test.payload); s != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, s)
+			}
+		})
+	}
+}
+
+func TestWriteSequence(t *testing.T) {
+	o := tempOutput(t)
+	_, _ = o.WriteSequence(SeqOSC, "0", "title")
+	verify(t, o, "\x1b]0;title\x1b\\")
+}
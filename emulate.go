@@ -0,0 +1,159 @@
+package termenv
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// EmulationWriter re-encodes everything written to it for a less capable
+// color profile, e.g. to record demos of how an application degrades on
+// terminals without true color support.
+type EmulationWriter struct {
+	w       io.Writer
+	profile Profile
+	banner  bool
+	started bool
+	pending string
+}
+
+// NewEmulationWriter returns a new EmulationWriter writing to w, re-encoding
+// all colors for the given profile. If banner is set, a line naming the
+// simulated profile is written before the first output.
+func NewEmulationWriter(w io.Writer, p Profile, banner bool) *EmulationWriter {
+	return &EmulationWriter{
+		w:       w,
+		profile: p,
+		banner:  banner,
+	}
+}
+
+// Write re-encodes p and writes it to the underlying writer. Escape sequences
+// split across multiple writes are held back until they are complete.
+func (e *EmulationWriter) Write(p []byte) (int, error) {
+	var b strings.Builder
+	if e.banner && !e.started {
+		b.WriteString(CSI + ReverseSeq + "m Simulated profile: " + e.profile.Name() + " " + CSI + ResetSeq + "m\r\n")
+	}
+	e.started = true
+
+	s := e.pending + string(p)
+	e.pending = ""
+
+	start := 0
+	for i := 0; i < len(s); {
+		if s[i] != ESC {
+			i++
+			continue
+		}
+
+		seq, n, err := ParseSequence(s[i:])
+		if err != nil {
+			if isIncompleteSequence(s[i:]) {
+				b.WriteString(s[start:i])
+				e.pending = s[i:]
+				start = len(s)
+				break
+			}
+			i++
+			continue
+		}
+
+		b.WriteString(s[start:i])
+		if seq.Kind == SeqCSI && seq.Final == 'm' && seq.Intermediate == "" {
+			b.WriteString(e.sgr(seq.Params))
+		} else {
+			b.WriteString(s[i : i+n])
+		}
+		i += n
+		start = i
+	}
+	b.WriteString(s[start:])
+
+	if _, err := io.WriteString(e.w, b.String()); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	return len(p), nil
+}
+
+// sgr re-encodes the parameters of an SGR sequence for the profile.
+func (e *EmulationWriter) sgr(params string) string {
+	if e.profile == Ascii {
+		return ""
+	}
+	if params == "" {
+		return CSI + "m"
+	}
+
+	ps := strings.Split(params, ";")
+	out := make([]string, 0, len(ps))
+	for i := 0; i < len(ps); i++ {
+		n, err := strconv.Atoi(ps[i])
+		if err != nil {
+			out = append(out, ps[i])
+			continue
+		}
+
+		var (
+			c  Color
+			bg bool
+		)
+		switch {
+		case (n == 38 || n == 48) && i+2 < len(ps) && ps[i+1] == "5": //nolint:mnd
+			idx, _ := strconv.Atoi(ps[i+2])
+			c, bg = ANSI256Color(idx), n == 48 //nolint:mnd
+			i += 2
+		case (n == 38 || n == 48) && i+4 < len(ps) && ps[i+1] == "2": //nolint:mnd
+			r, _ := strconv.Atoi(ps[i+2])
+			g, _ := strconv.Atoi(ps[i+3])
+			bl, _ := strconv.Atoi(ps[i+4])
+			c, bg = RGBColor(fmt.Sprintf("#%02x%02x%02x", r, g, bl)), n == 48 //nolint:mnd
+			i += 4
+		default:
+			out = append(out, ps[i])
+			continue
+		}
+
+		if c = e.profile.ConvertColor(c); c != nil {
+			if seq := c.Sequence(bg); seq != "" {
+				out = append(out, seq)
+			}
+		}
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+	return CSI + strings.Join(out, ";") + "m"
+}
+
+// isIncompleteSequence returns whether s is the beginning of an escape
+// sequence that got cut off, as opposed to a malformed one.
+//
+//nolint:mnd
+func isIncompleteSequence(s string) bool {
+	if len(s) < 2 {
+		return true
+	}
+	if len(s) > maxStringSeqLen {
+		return false
+	}
+
+	switch s[:2] {
+	case CSI:
+		for i := 2; i < len(s); i++ {
+			if s[i] < 0x20 || s[i] > 0x3f {
+				return false
+			}
+		}
+		return true
+	case DCS:
+		return !strings.Contains(s, ST)
+	case OSC, APC, PM, SOS:
+		return !strings.Contains(s, ST) && (s[:2] != OSC || !strings.ContainsRune(s, BEL))
+	case SS2, SS3:
+		return len(s) == 2
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEmulationWriter(t *testing.T) {
+	tests := []struct {
+		profile  Profile
+		in       string
+		expected string
+	}{
+		{ANSI256, "\x1b[1;38;2;255;0;0mfoo\x1b[0m", "\x1b[1;38;5;196mfoo\x1b[0m"},
+		{ANSI, "\x1b[48;5;196mfoo\x1b[m", "\x1b[101mfoo\x1b[m"},
+		{ANSI, "\x1b[31;4mfoo", "\x1b[31;4mfoo"},
+		{Ascii, "\x1b[1;31mfoo\x1b[0m\x1b[2J", "foo\x1b[2J"},
+		{TrueColor, "\x1b[38;2;1;2;3mfoo", "\x1b[38;2;1;2;3mfoo"},
+		{ANSI, "\x1b]2;title\abar", "\x1b]2;title\abar"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		w := NewEmulationWriter(&buf, test.profile, false)
+		if _, err := w.Write([]byte(test.in)); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.profile.Name(), test.expected, buf.String())
+		}
+	}
+}
+
+func TestEmulationWriterSplit(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewEmulationWriter(&buf, ANSI256, true)
+
+	for _, s := range []string{"a\x1b[38;2;", "255;0;0mb", "\x1b"} {
+		if n, err := w.Write([]byte(s)); err != nil || n != len(s) {
+			t.Fatalf("unexpected write result: %d, %v", n, err)
+		}
+	}
+
+	exp := "\x1b[7m Simulated profile: ANSI256 \x1b[0m\r\na\x1b[38;5;196mb"
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+}
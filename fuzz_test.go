@@ -0,0 +1,175 @@
+package termenv
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// The seed corpora in testdata/fuzz hold excerpts of output captured from
+// real terminal programs, e.g. ls, git, grep, gcc, htop and vim, as well as
+// cut-off and malformed sequences.
+
+// fuzzCorpus returns the string seeds of the given fuzz target, so other
+// tests can run against the captured output, too.
+func fuzzCorpus(t *testing.T, target string) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "fuzz", target, "*"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no seed corpus for %s: %v", target, err)
+	}
+	var seeds []string
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			if v := strings.TrimSuffix(strings.TrimPrefix(line, "string("), ")"); v != line {
+				s, err := strconv.Unquote(v)
+				if err != nil {
+					t.Fatalf("%s: %v", file, err)
+				}
+				seeds = append(seeds, s)
+			}
+		}
+	}
+	return seeds
+}
+
+func FuzzParseSequence(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		seq, n, err := ParseSequence(s)
+		if err != nil {
+			return
+		}
+		if n < 2 || n > len(s) {
+			t.Fatalf("invalid length %d for %q", n, s)
+		}
+
+		// the encoded sequence parses to the same sequence
+		enc := seq.String()
+		again, m, err := ParseSequence(enc)
+		if err != nil || m != len(enc) || again != seq {
+			t.Fatalf("%q: re-parsing %q resulted in %+v, %d, %v; expected %+v", s, enc, again, m, err, seq)
+		}
+	})
+}
+
+func FuzzParseSGR(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		// StyleFromSequence parses SGR parameters, ParseStyledText the SGR
+		// sequences of rendered text
+		o := NewOutput(nil, WithProfile(TrueColor))
+		if st, err := o.StyleFromSequence(s); err == nil {
+			again, err := o.StyleFromSequence(st.Sequence())
+			if err != nil || again.Sequence() != st.Sequence() {
+				t.Fatalf("%q: normalized sequence %q is not stable, got %q, %v", s, st.Sequence(), again.Sequence(), err)
+			}
+		}
+
+		// malformed sequences are kept as text, and may form new sequences
+		// with the text around them, e.g. "\x1b[\x1b[AA"
+		text := ParseStyledText(s)
+		if strings.ContainsRune(text.String(), ESC) {
+			return
+		}
+		rendered := ParseStyledText(text.Render(TrueColor))
+		if rendered.String() != text.String() {
+			t.Fatalf("%q: text changed when rendered: %q, expected %q", s, rendered.String(), text.String())
+		}
+	})
+}
+
+func FuzzStrip(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		plain := Strip(s)
+		if len(plain) > len(s) {
+			t.Fatalf("%q: stripped text %q is longer than the input", s, plain)
+		}
+		// malformed sequences are kept as text, see FuzzParseSGR
+		if again := Strip(plain); again != plain && !strings.ContainsRune(plain, ESC) {
+			t.Fatalf("%q: stripping is not idempotent: %q, then %q", s, plain, again)
+		}
+		if got := ParseStyledText(s).String(); got != plain {
+			t.Fatalf("%q: ParseStyledText returned %q, expected %q", s, got, plain)
+		}
+		var buf bytes.Buffer
+		if _, err := StripStream(&buf, strings.NewReader(s)); err != nil || buf.String() != plain {
+			t.Fatalf("%q: StripStream returned %q, %v, expected %q", s, buf.String(), err, plain)
+		}
+	})
+}
+
+func FuzzStringWidth(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		w := StringWidth(s)
+		if w < 0 {
+			t.Fatalf("%q: negative width %d", s, w)
+		}
+		if exp := uniseg.StringWidth(Strip(s)); w != exp {
+			t.Fatalf("%q: width %d, expected the width of the plain text, %d", s, w, exp)
+		}
+	})
+}
+
+func FuzzWrap(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string, width int) {
+		if width < 1 || width > 200 {
+			return
+		}
+		// wrapping only moves text around: without whitespace and
+		// hyphenation, the plain text stays the same
+		squash := func(s string) string {
+			return strings.Map(func(r rune) rune {
+				switch r {
+				case ' ', '\n', '-', softHyphen:
+					return -1
+				}
+				return r
+			}, Strip(s))
+		}
+		for _, mode := range []WrapMode{WrapWords, WrapUnicode} {
+			wrapped := WrapText(s, width, WithWrapMode(mode))
+			if squash(wrapped) != squash(s) {
+				t.Fatalf("%q (width %d, mode %d): text changed: %q", s, width, mode, wrapped)
+			}
+		}
+	})
+}
+
+func FuzzNotify(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		var buf bytes.Buffer
+		o := NewOutput(&buf, WithEnvironment(mapEnv{"TERM": "xterm-kitty"}))
+		o.Notify(s, s)
+
+		// the notification must neither end early nor leak sequences
+		seq := buf.String()
+		prefix := OSC + "777;notify;"
+		if !strings.HasPrefix(seq, prefix) || !strings.HasSuffix(seq, ST) {
+			t.Fatalf("%q: malformed notification %q", s, seq)
+		}
+		payload := strings.TrimSuffix(strings.TrimPrefix(seq, prefix), ST)
+		for _, r := range payload {
+			if r < ' ' || r == 0x7f || (r >= 0x80 && r < 0xa0) {
+				t.Fatalf("%q: control character %U left in %q", s, r, payload)
+			}
+		}
+		if utf8.ValidString(s) && !utf8.ValidString(payload) {
+			t.Fatalf("%q: sanitizing produced invalid UTF-8 %q", s, payload)
+		}
+
+		// the title can't contain the field separator
+		if title, body, ok := strings.Cut(payload, ";"); !ok || strings.Count(body, ";") != strings.Count(s, ";") {
+			t.Fatalf("%q: notification title %q and body %q are not separated", s, title, body)
+		}
+	})
+}
@@ -1,6 +1,6 @@
 module github.com/muesli/termenv
 
-go 1.17
+go 1.18
 
 require (
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1
@@ -8,4 +8,5 @@ require (
 	github.com/mattn/go-isatty v0.0.20
 	github.com/rivo/uniseg v0.4.7
 	golang.org/x/sys v0.30.0
+	golang.org/x/term v0.29.0
 )
@@ -0,0 +1,94 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/rivo/uniseg"
+)
+
+// Gradient renders the text of t with its foreground color fading from one
+// color to the other, keeping the other attributes of t.
+func (t Style) Gradient(from, to Color) string {
+	return t.gradient(t.string, []Color{from, to})
+}
+
+// Gradient renders s with its foreground color fading through the given
+// color stops, for the default output.
+func Gradient(s string, stops ...Color) string {
+	return output.Gradient(s, stops...)
+}
+
+// Gradient renders s with its foreground color fading through the given
+// color stops, spread evenly over the text.
+//
+// The colors are interpolated in the CIE L*a*b* color space, which looks
+// even to the eye, and then converted to the color profile of o. Adjacent
+// characters sharing the converted color share a sequence, so the gradient
+// becomes a few bands of color on ANSI256 and ANSI terminals.
+func (o *Output) Gradient(s string, stops ...Color) string {
+	return o.String().gradient(s, stops)
+}
+
+// gradient renders s with t and a foreground gradient through stops.
+func (t Style) gradient(s string, stops []Color) string {
+	s = t.applyTextPolicies(s)
+	if s == "" || t.profile == Ascii || len(stops) == 0 {
+		return t.Styled(s)
+	}
+
+	var graphemes []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		graphemes = append(graphemes, g.Str())
+	}
+	colors := make([]colorful.Color, len(stops))
+	for i, c := range stops {
+		colors[i] = ConvertToRGB(c)
+	}
+
+	var (
+		b    strings.Builder
+		last string
+		fg   Style
+	)
+	for i, gr := range graphemes {
+		var pos float64
+		if len(graphemes) > 1 {
+			pos = float64(i) / float64(len(graphemes)-1)
+		}
+		c := RGBColor(gradientAt(colors, pos).Clamped().Hex())
+		seq := t.profile.ConvertColor(c).Sequence(false)
+
+		switch {
+		case i == 0:
+			fg = t.add(seq)
+			b.WriteString(CSI + fg.sequence() + "m")
+		case seq != last:
+			b.WriteString(CSI + seq + "m")
+		}
+		last = seq
+		b.WriteString(gr)
+	}
+	b.WriteString(CSI + fg.reset() + "m")
+
+	if t.link != "" {
+		return t.linked(b.String())
+	}
+	return b.String()
+}
+
+// gradientAt returns the color at pos, from 0 to 1, of the gradient through
+// colors.
+func gradientAt(colors []colorful.Color, pos float64) colorful.Color {
+	if len(colors) == 1 {
+		return colors[0]
+	}
+
+	span := pos * float64(len(colors)-1)
+	i := int(span)
+	if i >= len(colors)-1 {
+		return colors[len(colors)-1]
+	}
+	return colors[i].BlendLab(colors[i+1], span-float64(i))
+}
@@ -0,0 +1,62 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	red, blue := RGBColor("#ff0000"), RGBColor("#0000ff")
+	tests := []struct {
+		name     string
+		style    Style
+		stops    []Color
+		in       string
+		expected string
+	}{
+		{
+			"true color",
+			TrueColor.String(), []Color{red, blue}, "abc",
+			"\x1b[38;2;255;0;0ma\x1b[38;2;202;0;136mb\x1b[38;2;0;0;255mc\x1b[0m",
+		},
+		{
+			"attributes",
+			TrueColor.String().Bold(), []Color{red, blue}, "ab",
+			"\x1b[1;38;2;255;0;0ma\x1b[38;2;0;0;255mb\x1b[0m",
+		},
+		{
+			"three stops",
+			TrueColor.String(), []Color{red, RGBColor("#00ff00"), red}, "abc",
+			"\x1b[38;2;255;0;0ma\x1b[38;2;0;255;0mb\x1b[38;2;255;0;0mc\x1b[0m",
+		},
+		{
+			"bands",
+			ANSI.String(), []Color{red, RGBColor("#ff1010")}, "abc",
+			"\x1b[91mabc\x1b[0m",
+		},
+		{
+			"single character",
+			TrueColor.String(), []Color{red, blue}, "a",
+			"\x1b[38;2;255;0;0ma\x1b[0m",
+		},
+		{
+			"graphemes",
+			TrueColor.String(), []Color{red, blue}, "ée",
+			"\x1b[38;2;255;0;0mé\x1b[38;2;0;0;255me\x1b[0m",
+		},
+		{"ascii", Ascii.String(), []Color{red, blue}, "abc", "abc"},
+		{"no stops", TrueColor.String(), nil, "abc", "abc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.style.gradient(test.in, test.stops)
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+
+	if got, exp := TrueColor.String("ab").Gradient(red, blue), "\x1b[38;2;255;0;0ma\x1b[38;2;0;0;255mb\x1b[0m"; got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
@@ -0,0 +1,83 @@
+package termenv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidGrepColors gets returned when a GREP_COLORS-style capability
+// can't be parsed.
+var ErrInvalidGrepColors = errors.New("invalid color capability")
+
+// GrepColors is a parsed GREP_COLORS-style capability string, as used by
+// grep (e.g. "ms=01;31:fn=35:ne") and GCC (e.g. "error=01;31:note=01;36").
+type GrepColors struct {
+	// Styles maps capabilities with a value, e.g. "ms", to their styles.
+	Styles map[string]Style
+	// Flags holds the boolean capabilities that are set, e.g. "rv" or
+	// "ne".
+	Flags map[string]bool
+}
+
+// ParseGrepColors parses a GREP_COLORS-style capability string.
+func ParseGrepColors(s string) (*GrepColors, error) {
+	return output.ParseGrepColors(s)
+}
+
+// ParseGrepColors parses a GREP_COLORS-style capability string: a
+// colon-separated list of either name=SGR pairs, or boolean names.
+func (o *Output) ParseGrepColors(s string) (*GrepColors, error) {
+	c := &GrepColors{
+		Styles: map[string]Style{},
+		Flags:  map[string]bool{},
+	}
+
+	for _, entry := range strings.Split(s, ":") {
+		if entry == "" {
+			continue
+		}
+		i := strings.IndexByte(entry, '=')
+		if i < 0 {
+			c.Flags[entry] = true
+			continue
+		}
+		if i == 0 || !isSGRParams(entry[i+1:]) {
+			return nil, fmt.Errorf("%s: %q", ErrInvalidGrepColors, entry)
+		}
+		c.Styles[entry[:i]] = o.sgrStyle(entry[i+1:])
+	}
+
+	return c, nil
+}
+
+// GrepColors parses the GREP_COLORS environment variable. It returns nil if
+// the variable is not set.
+func (o *Output) GrepColors() (*GrepColors, error) {
+	return o.parseGrepColorsEnv("GREP_COLORS")
+}
+
+// GCCColors parses the GCC_COLORS environment variable. It returns nil if the
+// variable is not set.
+func (o *Output) GCCColors() (*GrepColors, error) {
+	return o.parseGrepColorsEnv("GCC_COLORS")
+}
+
+func (o *Output) parseGrepColorsEnv(name string) (*GrepColors, error) {
+	v := o.environ.Getenv(name)
+	if v == "" {
+		return nil, nil //nolint:nilnil
+	}
+	return o.ParseGrepColors(v)
+}
+
+// Style returns the style of the capability name, or an unstyled Style if it
+// is not set.
+func (c *GrepColors) Style(name string) Style {
+	return c.Styles[name]
+}
+
+// Flag returns whether the boolean capability name is set.
+func (c *GrepColors) Flag(name string) bool {
+	return c.Flags[name]
+}
@@ -0,0 +1,52 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestParseGrepColors(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{}), WithProfile(ANSI))
+	c, err := o.ParseGrepColors("ms=01;31:mc=01;31:sl=:fn=35:ne")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"ms", "\x1b[01;31mfoo\x1b[0m"},
+		{"fn", "\x1b[35mfoo\x1b[0m"},
+		{"sl", "foo"},
+		{"cx", "foo"},
+	}
+	for _, test := range tests {
+		if got := c.Style(test.name).Styled("foo"); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, got)
+		}
+	}
+
+	if !c.Flag("ne") || c.Flag("rv") {
+		t.Errorf("expected ne to be set and rv to be unset, got %v", c.Flags)
+	}
+
+	if _, err := o.ParseGrepColors("ms=red"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestGCCColors(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{"GCC_COLORS": "error=01;31:note=01;36"}), WithProfile(ANSI))
+	c, err := o.GCCColors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "\x1b[01;31mfoo\x1b[0m"
+	if got := c.Style("error").Styled("foo"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	if c, err := o.GrepColors(); c != nil || err != nil {
+		t.Errorf("expected nil for unset GREP_COLORS, got %v, %v", c, err)
+	}
+}
@@ -0,0 +1,132 @@
+package termenv
+
+import (
+	"regexp"
+	"strings"
+)
+
+// HighlightMatches renders all matches of re in the visible text of s with
+// the highlight style hl. s may already be styled: hl is applied on top of
+// its styles, and the original style is restored after each match.
+//
+// Matches are searched in the text without its escape sequences, so they
+// may span styled fragments.
+func HighlightMatches(s string, re *regexp.Regexp, hl Style) string {
+	plain, seqs, _ := splitWrapText(s)
+	return overlayStyle(s, plain, seqs, re.FindAllStringIndex(plain, -1), hl)
+}
+
+// ApplyStyleRange renders the printable runes [start, end) of s with the
+// style overlay, e.g. to paint a selection or a cursor onto pre-rendered
+// content. Runes are counted in the text without its escape sequences. Like
+// with HighlightMatches, overlay is applied on top of the existing styles,
+// which are restored after the range.
+func ApplyStyleRange(s string, start, end int, overlay Style) string {
+	plain, seqs, _ := splitWrapText(s)
+
+	r := [2]int{len(plain), len(plain)}
+	i := 0
+	for pos := range plain {
+		if i == start {
+			r[0] = pos
+		}
+		if i == end {
+			r[1] = pos
+			break
+		}
+		i++
+	}
+	return overlayStyle(s, plain, seqs, [][]int{r[:]}, overlay)
+}
+
+// overlayStyle renders the byte ranges of plain, the visible text of s, with
+// the style overlay. seqs are the escape sequences of s. The ranges must be
+// sorted and must not overlap; empty ranges are ignored.
+func overlayStyle(s, plain string, seqs []wrapSeq, ranges [][]int, overlay Style) string {
+	if overlay.profile == Ascii || len(overlay.styles) == 0 {
+		return s
+	}
+	var nonEmpty [][]int
+	for _, r := range ranges {
+		if r[0] < r[1] {
+			nonEmpty = append(nonEmpty, r)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return s
+	}
+	ranges = nonEmpty
+
+	seq := CSI + overlay.sequence() + "m"
+	var (
+		b       strings.Builder
+		state   sgrState
+		next    int // the next sequence to write
+		inRange bool
+	)
+	writeSeqs := func(pos int) {
+		for ; next < len(seqs) && seqs[next].pos <= pos; next++ {
+			b.WriteString(seqs[next].seq)
+			if state.apply(seqs[next].seq) && inRange {
+				// the original style may have overridden the overlay
+				b.WriteString(seq)
+			}
+		}
+	}
+
+	i := 0 // the next range
+	for pos, r := range plain {
+		if inRange && pos == ranges[i][1] {
+			b.WriteString(CSI + ResetSeq + "m" + state.String())
+			inRange = false
+			i++
+		}
+		writeSeqs(pos)
+		if !inRange && i < len(ranges) && pos == ranges[i][0] {
+			b.WriteString(seq)
+			inRange = true
+		}
+		b.WriteRune(r)
+	}
+	if inRange {
+		b.WriteString(CSI + ResetSeq + "m" + state.String())
+		inRange = false
+	}
+	writeSeqs(len(plain))
+	return b.String()
+}
+
+// sgrState tracks the SGR sequences in effect since the last full reset.
+type sgrState struct {
+	params []string
+}
+
+// apply updates the state with seq, and returns whether seq is an SGR
+// sequence.
+func (st *sgrState) apply(seq string) bool {
+	sq, _, err := ParseSequence(seq)
+	if err != nil || sq.Kind != SeqCSI || sq.Final != 'm' || sq.Intermediate != "" ||
+		strings.ContainsAny(sq.Params, "<=>?") {
+		return false
+	}
+
+	params := sq.Params
+	switch {
+	case params == "" || params == ResetSeq:
+		st.params = nil
+		return true
+	case strings.HasPrefix(params, ResetSeq+";"):
+		st.params = nil
+		params = params[len(ResetSeq)+1:]
+	}
+	st.params = append(st.params, params)
+	return true
+}
+
+// String returns the sequence re-establishing the state.
+func (st *sgrState) String() string {
+	if len(st.params) == 0 {
+		return ""
+	}
+	return CSI + strings.Join(st.params, ";") + "m"
+}
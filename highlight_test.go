@@ -0,0 +1,68 @@
+package termenv
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestHighlightMatches(t *testing.T) {
+	hl := String().Reverse()
+	tests := []struct {
+		name     string
+		in       string
+		re       string
+		expected string
+	}{
+		{"plain", "foo bar foo", "foo", "\x1b[7mfoo\x1b[0m bar \x1b[7mfoo\x1b[0m"},
+		{"no match", "foo", "baz", "foo"},
+		{"empty match", "foo", "x*", "foo"},
+		{"restores style", "\x1b[1mfoo bar\x1b[0m", "o b", "\x1b[1mfo\x1b[7mo b\x1b[0m\x1b[1mar\x1b[0m"},
+		{"accumulated style", "\x1b[1m\x1b[31mfoo\x1b[0m", "o$", "\x1b[1m\x1b[31mfo\x1b[7mo\x1b[0m\x1b[1;31m\x1b[0m"},
+		{"style within match", "f\x1b[32moo\x1b[0m bar", "foo", "\x1b[7mf\x1b[32m\x1b[7moo\x1b[0m\x1b[32m\x1b[0m bar"},
+		{"reset within match", "\x1b[1mfo\x1b[0mo", "foo", "\x1b[1m\x1b[7mfo\x1b[0m\x1b[7mo\x1b[0m"},
+		{"regexp", "a1 b22 c333", `\d+`, "a\x1b[7m1\x1b[0m b\x1b[7m22\x1b[0m c\x1b[7m333\x1b[0m"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := HighlightMatches(test.in, regexp.MustCompile(test.re), hl)
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+			if StringWidth(got) != StringWidth(test.in) {
+				t.Errorf("expected the visible text to be unchanged, got %q", got)
+			}
+		})
+	}
+
+	if got := HighlightMatches("foo", regexp.MustCompile("o"), Ascii.String().Reverse()); got != "foo" {
+		t.Errorf("expected %q, got %q", "foo", got)
+	}
+}
+
+func TestApplyStyleRange(t *testing.T) {
+	sel := String().Reverse()
+	tests := []struct {
+		name       string
+		in         string
+		start, end int
+		expected   string
+	}{
+		{"plain", "foo bar", 1, 3, "f\x1b[7moo\x1b[0m bar"},
+		{"cursor", "foo", 0, 1, "\x1b[7mf\x1b[0moo"},
+		{"to end", "foo", 1, 10, "f\x1b[7moo\x1b[0m"},
+		{"empty", "foo", 2, 2, "foo"},
+		{"out of range", "foo", 5, 6, "foo"},
+		{"runes", "äöü", 1, 2, "ä\x1b[7mö\x1b[0mü"},
+		{"styled", "\x1b[31mfoo\x1b[0m bar", 2, 5, "\x1b[31mfo\x1b[7mo\x1b[0m\x1b[7m b\x1b[0mar"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ApplyStyleRange(test.in, test.start, test.end, sel)
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
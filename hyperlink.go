@@ -1,11 +1,120 @@
 package termenv
 
+import (
+	"strconv"
+	"strings"
+)
+
+// hyperlinkTerms lists the TERM_PROGRAM and TERM values of terminals known
+// to support OSC 8 hyperlinks.
+var hyperlinkTerms = map[string]bool{
+	"alacritty":     true,
+	"contour":       true,
+	"foot":          true,
+	"foot-extra":    true,
+	"ghostty":       true,
+	"iTerm.app":     true,
+	"vscode":        true,
+	"WezTerm":       true,
+	"wezterm":       true,
+	"xterm-ghostty": true,
+	"xterm-kitty":   true,
+}
+
+// WithHyperlinks returns a new OutputOption overriding whether the terminal
+// supports hyperlinks.
+func WithHyperlinks(v bool) OutputOption {
+	return func(o *Output) {
+		o.hyperlinks = &v
+	}
+}
+
+// SupportsHyperlinks returns whether the terminal supports OSC 8 hyperlinks.
+func SupportsHyperlinks() bool {
+	return output.SupportsHyperlinks()
+}
+
+// SupportsHyperlinks returns whether the terminal supports OSC 8 hyperlinks.
+// An override set with WithHyperlinks takes precedence, followed by the
+// FORCE_HYPERLINK environment variable ("0" disables hyperlinks, any other
+// value enables them). Otherwise the terminal is identified by its
+// environment.
+func (o *Output) SupportsHyperlinks() bool {
+	if o.hyperlinks != nil {
+		return *o.hyperlinks
+	}
+	if force := o.environ.Getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0"
+	}
+	if !o.isTTY() {
+		return false
+	}
+
+	// multiplexers don't pass hyperlinks through, whatever the outer
+	// terminal supports
+	term := o.environ.Getenv("TERM")
+	program := o.environ.Getenv("TERM_PROGRAM")
+	if program == "tmux" || strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux") {
+		return false
+	}
+
+	if hyperlinkTerms[program] || hyperlinkTerms[term] {
+		return true
+	}
+	if o.environ.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	// VTE supports hyperlinks since 0.50
+	if v, err := strconv.Atoi(o.environ.Getenv("VTE_VERSION")); err == nil && v >= 5000 { //nolint:mnd
+		return true
+	}
+	return o.environ.Getenv("KONSOLE_VERSION") != ""
+}
+
 // Hyperlink creates a hyperlink using OSC8.
 func Hyperlink(link, name string) string {
 	return output.Hyperlink(link, name)
 }
 
-// Hyperlink creates a hyperlink using OSC8.
+// Hyperlink creates a hyperlink using OSC8. Characters that may not appear in
+// the link, e.g. spaces, get percent-encoded.
+//
+// If the terminal doesn't support hyperlinks, the link is rendered as text
+// following the name, e.g. "name (link)".
 func (o *Output) Hyperlink(link, name string) string {
-	return OSC + "8;;" + link + ST + name + OSC + "8;;" + ST
+	if !o.SupportsHyperlinks() {
+		return hyperlinkText(link, name)
+	}
+	return OSC + "8;;" + EncodeHyperlinkURL(link) + ST + name + OSC + "8;;" + ST
+}
+
+// hyperlinkText formats a hyperlink as plain text.
+func hyperlinkText(link, name string) string {
+	if name == "" || name == link {
+		return link
+	}
+	return name + " (" + link + ")"
+}
+
+// Hyperlink makes the Style render its text as a hyperlink to link, using
+// OSC 8. With the Ascii profile, the link is rendered as text following the
+// styled text instead, e.g. "name (link)".
+func (t Style) Hyperlink(link string) Style {
+	t.link = link
+	return t
+}
+
+// linked wraps the rendered text s in the style's hyperlink.
+func (t Style) linked(s string) string {
+	start, end := t.linkAffixes()
+	return start + s + end
+}
+
+// linkAffixes returns the text rendered before and after the text of a
+// hyperlinked Style.
+func (t Style) linkAffixes() (string, string) {
+	if t.profile == Ascii {
+		return "", " (" + t.link + ")"
+	}
+	return OSC + "8;;" + EncodeHyperlinkURL(t.link) + ST, OSC + "8;;" + ST
 }
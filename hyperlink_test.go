@@ -0,0 +1,102 @@
+package termenv
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSupportsHyperlinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		environ  map[string]string
+		tty      bool
+		opts     []OutputOption
+		expected bool
+	}{
+		{"pipe", map[string]string{"TERM": "xterm-kitty"}, false, nil, false},
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, true, nil, true},
+		{"iterm", map[string]string{"TERM_PROGRAM": "iTerm.app"}, true, nil, true},
+		{"xterm", map[string]string{"TERM": "xterm-256color"}, true, nil, false},
+		{"vte", map[string]string{"TERM": "xterm-256color", "VTE_VERSION": "6003"}, true, nil, true},
+		{"old vte", map[string]string{"TERM": "xterm-256color", "VTE_VERSION": "4601"}, true, nil, false},
+		{"windows terminal", map[string]string{"WT_SESSION": "1"}, true, nil, true},
+		{"tmux", map[string]string{"TERM": "tmux-256color", "TERM_PROGRAM": "tmux", "VTE_VERSION": "6003"}, true, nil, false},
+		{"forced", map[string]string{"FORCE_HYPERLINK": "1"}, false, nil, true},
+		{"forced off", map[string]string{"TERM": "xterm-kitty", "FORCE_HYPERLINK": "0"}, true, nil, false},
+		{"override", map[string]string{"FORCE_HYPERLINK": "1"}, true, []OutputOption{WithHyperlinks(false)}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := append([]OutputOption{
+				WithTTY(test.tty),
+				WithEnvironment(mapEnv(test.environ)),
+			}, test.opts...)
+			o := NewOutput(io.Discard, opts...)
+
+			if got := o.SupportsHyperlinks(); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+			if got := o.Capabilities().Hyperlinks; got != test.expected {
+				t.Errorf("expected capability %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestHyperlinkText(t *testing.T) {
+	o := NewOutput(io.Discard, WithHyperlinks(false))
+	for _, test := range []struct {
+		link, name, expected string
+	}{
+		{"https://example.com", "example", "example (https://example.com)"},
+		{"https://example.com", "https://example.com", "https://example.com"},
+		{"https://example.com", "", "https://example.com"},
+	} {
+		if got := o.Hyperlink(test.link, test.name); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestStyleHyperlink(t *testing.T) {
+	link := String().Bold().Hyperlink("https://example.com/a b")
+	tests := []struct {
+		name     string
+		style    Style
+		in       string
+		expected string
+	}{
+		{"styled", link, "foo", "\x1b]8;;https://example.com/a%20b\x1b\\\x1b[1mfoo\x1b[0m\x1b]8;;\x1b\\"},
+		{"plain", String().Hyperlink("https://example.com"), "foo", "\x1b]8;;https://example.com\x1b\\foo\x1b]8;;\x1b\\"},
+		{"ascii", Ascii.String().Bold().Hyperlink("https://example.com"), "foo", "foo (https://example.com)"},
+		{"empty", link, "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.style.Styled(test.in); got != test.expected {
+				t.Errorf("Styled: expected %q, got %q", test.expected, got)
+			}
+			if got := string(test.style.AppendTo(nil, test.in)); got != test.expected {
+				t.Errorf("AppendTo: expected %q, got %q", test.expected, got)
+			}
+			if got := test.style.Compile().Wrap(test.in); got != test.expected {
+				t.Errorf("Compile: expected %q, got %q", test.expected, got)
+			}
+			if test.in == "" {
+				return
+			}
+
+			var b strings.Builder
+			n, err := test.style.Fprint(&b, test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != test.expected || n != len(got) {
+				t.Errorf("Fprint: expected %q, got %q (%d bytes)", test.expected, got, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,539 @@
+package termenv
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrSequenceTooLong gets returned when an escape sequence read from the
+// terminal exceeds the maximum supported length.
+var ErrSequenceTooLong = errors.New("escape sequence too long")
+
+const (
+	// maximum length of a CSI or SS3 sequence read from the input.
+	maxCSILen = 256
+	// maximum length of an OSC, DCS, or APC string read from the input.
+	maxStringSeqLen = 1 << 20
+	// maximum length of a bracketed paste read from the input.
+	maxPasteLen = 1 << 24
+)
+
+// Event is an input event read from the terminal.
+type Event interface {
+	isEvent()
+}
+
+// KeyType is the type of a key press.
+type KeyType int
+
+// Key types.
+const (
+	// KeyRune is a printable character (or a control character combined with
+	// ModCtrl). The character is stored in KeyEvent.Rune.
+	KeyRune KeyType = iota
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyEscape
+	KeyUp
+	KeyDown
+	KeyRight
+	KeyLeft
+	KeyHome
+	KeyEnd
+	KeyInsert
+	KeyDelete
+	KeyPgUp
+	KeyPgDown
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
+
+var keyNames = map[KeyType]string{
+	KeyEnter:     "enter",
+	KeyTab:       "tab",
+	KeyBackspace: "backspace",
+	KeyEscape:    "esc",
+	KeyUp:        "up",
+	KeyDown:      "down",
+	KeyRight:     "right",
+	KeyLeft:      "left",
+	KeyHome:      "home",
+	KeyEnd:       "end",
+	KeyInsert:    "insert",
+	KeyDelete:    "delete",
+	KeyPgUp:      "pgup",
+	KeyPgDown:    "pgdown",
+	KeyF1:        "f1",
+	KeyF2:        "f2",
+	KeyF3:        "f3",
+	KeyF4:        "f4",
+	KeyF5:        "f5",
+	KeyF6:        "f6",
+	KeyF7:        "f7",
+	KeyF8:        "f8",
+	KeyF9:        "f9",
+	KeyF10:       "f10",
+	KeyF11:       "f11",
+	KeyF12:       "f12",
+}
+
+// KeyMod is a bitmask of key modifiers.
+type KeyMod int
+
+// Key modifiers.
+const (
+	ModShift KeyMod = 1 << iota
+	ModAlt
+	ModCtrl
+	ModMeta
+	ModSuper
+	ModHyper
+)
+
+// KeyEvent is a key press.
+type KeyEvent struct {
+	Type   KeyType
+	Rune   rune
+	Mod    KeyMod
+	Action KeyAction
+}
+
+func (KeyEvent) isEvent() {}
+
+// String returns a human readable representation of the key, e.g. "ctrl+a".
+func (k KeyEvent) String() string {
+	var s strings.Builder
+	if k.Mod&ModCtrl != 0 {
+		s.WriteString("ctrl+")
+	}
+	if k.Mod&ModAlt != 0 {
+		s.WriteString("alt+")
+	}
+	if k.Mod&ModMeta != 0 {
+		s.WriteString("meta+")
+	}
+	if k.Mod&ModSuper != 0 {
+		s.WriteString("super+")
+	}
+	if k.Mod&ModHyper != 0 {
+		s.WriteString("hyper+")
+	}
+	if k.Mod&ModShift != 0 {
+		s.WriteString("shift+")
+	}
+	if k.Type == KeyRune {
+		s.WriteRune(k.Rune)
+	} else {
+		s.WriteString(keyNames[k.Type])
+	}
+	return s.String()
+}
+
+// MouseEvent is a mouse report. X and Y are 1-based cell coordinates, Seq
+// holds the raw report sequence.
+type MouseEvent struct {
+	X, Y   int
+	Button MouseButton
+	Action MouseAction
+	Mod    KeyMod
+	Seq    string
+}
+
+func (MouseEvent) isEvent() {}
+
+// PasteEvent is a block of text pasted into the terminal, if bracketed paste
+// is enabled. The text is sanitized: escape and other control characters are
+// removed, so it can't inject sequences into the application.
+type PasteEvent struct {
+	Text string
+}
+
+func (PasteEvent) isEvent() {}
+
+// PasteEndEvent is an end-of-paste marker without a preceding start marker.
+type PasteEndEvent struct{}
+
+func (PasteEndEvent) isEvent() {}
+
+// FocusEvent is sent when the terminal gains or loses focus, if focus
+// reporting is enabled.
+type FocusEvent struct {
+	Focused bool
+}
+
+func (FocusEvent) isEvent() {}
+
+// ResponseEvent is a terminal's answer to a query, e.g. an OSC color
+// response, a cursor position report, or a device attributes report. Seq
+// holds the raw response sequence.
+type ResponseEvent struct {
+	Seq string
+}
+
+func (ResponseEvent) isEvent() {}
+
+// UnknownEvent is an escape sequence the tokenizer does not recognize.
+type UnknownEvent struct {
+	Seq string
+}
+
+func (UnknownEvent) isEvent() {}
+
+// InputReader reads raw bytes from a terminal and turns them into events.
+// The terminal should be in raw mode for the reader to see individual key
+// presses.
+type InputReader struct {
+	r *bufio.Reader
+}
+
+// NewInputReader returns a new InputReader reading from r.
+func NewInputReader(r io.Reader) *InputReader {
+	return &InputReader{
+		r: bufio.NewReader(r),
+	}
+}
+
+// ReadEvent blocks until the next event is available and returns it.
+func (ir *InputReader) ReadEvent() (Event, error) {
+	tok, err := ir.readToken()
+	if err != nil {
+		return nil, err
+	}
+	if tok == CSI+StartBracketedPasteSeq {
+		return ir.readPaste()
+	}
+	return decodeToken(tok), nil
+}
+
+// readPaste collects everything up to the end-of-paste marker into a single
+// PasteEvent.
+func (ir *InputReader) readPaste() (Event, error) {
+	const end = CSI + EndBracketedPasteSeq
+
+	var buf []byte
+	for {
+		b, err := ir.r.ReadByte()
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		buf = append(buf, b)
+
+		if b == end[len(end)-1] && bytes.HasSuffix(buf, []byte(end)) {
+			break
+		}
+		if len(buf) > maxPasteLen {
+			return nil, ErrSequenceTooLong
+		}
+	}
+
+	return PasteEvent{Text: sanitizePaste(string(buf[:len(buf)-len(end)]))}, nil
+}
+
+// sanitizePaste strips escape and other control characters from pasted text,
+// keeping tabs and newlines. Carriage returns are normalized to newlines.
+func sanitizePaste(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\r':
+			return '\n'
+		case r == '\n', r == '\t':
+			return r
+		case r < 0x20, r == 0x7f, r >= 0x80 && r < 0xa0: //nolint:mnd
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// readToken reads the next token from the input: a single (UTF-8 encoded)
+// character, or a complete escape sequence.
+func (ir *InputReader) readToken() (string, error) {
+	b, err := ir.r.ReadByte()
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if b != ESC {
+		return ir.readRune(b)
+	}
+
+	// a lone ESC is the escape key
+	if ir.r.Buffered() == 0 {
+		return string(ESC), nil
+	}
+
+	next, err := ir.r.ReadByte()
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	switch next {
+	case '[':
+		return ir.readCSI()
+	case 'O':
+		// some terminals send modifiers as digits, e.g. "ESC O 5 P"
+		buf := []byte{ESC, 'O'}
+		for {
+			c, err := ir.r.ReadByte()
+			if err != nil {
+				return "", err //nolint:wrapcheck
+			}
+			buf = append(buf, c)
+			if c < '0' || c > '9' || len(buf) > maxCSILen {
+				return string(buf), nil
+			}
+		}
+	case ']', 'P', '_', '^', 'X':
+		return ir.readStringSeq(next)
+	default:
+		// alt-modified character
+		s, err := ir.readRune(next)
+		if err != nil {
+			return "", err
+		}
+		return string(ESC) + s, nil
+	}
+}
+
+// readRune completes the UTF-8 encoded character starting with b.
+func (ir *InputReader) readRune(b byte) (string, error) {
+	if b < utf8.RuneSelf {
+		return string(b), nil
+	}
+	if err := ir.r.UnreadByte(); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	r, _, err := ir.r.ReadRune()
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	return string(r), nil
+}
+
+// readCSI reads the remainder of a CSI sequence, up to and including its
+// final byte.
+func (ir *InputReader) readCSI() (string, error) {
+	buf := []byte(CSI)
+	for {
+		b, err := ir.r.ReadByte()
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+		buf = append(buf, b)
+
+		if b >= 0x40 && b <= 0x7e { //nolint:mnd
+			break
+		}
+		if len(buf) > maxCSILen {
+			return "", ErrSequenceTooLong
+		}
+	}
+
+	// X10 mouse reports carry three raw bytes after "CSI M"
+	if len(buf) == len(CSI)+1 && buf[len(buf)-1] == 'M' {
+		for i := 0; i < 3; i++ {
+			b, err := ir.r.ReadByte()
+			if err != nil {
+				return "", err //nolint:wrapcheck
+			}
+			buf = append(buf, b)
+		}
+	}
+
+	return string(buf), nil
+}
+
+// readStringSeq reads an OSC, DCS, APC, PM, or SOS string, terminated by
+// either BEL or ST.
+func (ir *InputReader) readStringSeq(intro byte) (string, error) {
+	buf := []byte{ESC, intro}
+	for {
+		b, err := ir.r.ReadByte()
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+		buf = append(buf, b)
+
+		if b == BEL && intro == ']' {
+			break
+		}
+		if b == '\\' && buf[len(buf)-2] == ESC {
+			break
+		}
+		if len(buf) > maxStringSeqLen {
+			return "", ErrSequenceTooLong
+		}
+	}
+
+	return string(buf), nil
+}
+
+// decodeToken turns a token returned by readToken into an event.
+func decodeToken(tok string) Event {
+	switch {
+	case tok == string(ESC):
+		return KeyEvent{Type: KeyEscape}
+	case strings.HasPrefix(tok, CSI):
+		return decodeCSI(tok)
+	case strings.HasPrefix(tok, SS3):
+		return decodeSS3(tok)
+	case strings.HasPrefix(tok, OSC),
+		strings.HasPrefix(tok, DCS),
+		strings.HasPrefix(tok, APC):
+		return ResponseEvent{Seq: tok}
+	case strings.HasPrefix(tok, PM),
+		strings.HasPrefix(tok, SOS):
+		return UnknownEvent{Seq: tok}
+	case tok[0] == ESC:
+		k := decodeChar(tok[1:])
+		k.Mod |= ModAlt
+		return k
+	}
+
+	return decodeChar(tok)
+}
+
+// decodeChar decodes a single character, mapping control characters to
+// their respective keys.
+//
+//nolint:mnd
+func decodeChar(s string) KeyEvent {
+	r, _ := utf8.DecodeRuneInString(s)
+	switch r {
+	case '\r', '\n':
+		return KeyEvent{Type: KeyEnter}
+	case '\t':
+		return KeyEvent{Type: KeyTab}
+	case 0x7f, 0x08:
+		return KeyEvent{Type: KeyBackspace}
+	case 0x1b:
+		return KeyEvent{Type: KeyEscape}
+	case 0x00:
+		return KeyEvent{Type: KeyRune, Rune: ' ', Mod: ModCtrl}
+	}
+	if r < 0x20 {
+		return KeyEvent{Type: KeyRune, Rune: r + 'a' - 1, Mod: ModCtrl}
+	}
+
+	return KeyEvent{Type: KeyRune, Rune: r}
+}
+
+// legacy CSI and SS3 final bytes.
+var finalKeys = map[byte]KeyType{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+	'P': KeyF1,
+	'Q': KeyF2,
+	'R': KeyF3,
+	'S': KeyF4,
+}
+
+// legacy "CSI n ~" key codes.
+var tildeKeys = map[int]KeyType{
+	1:  KeyHome,
+	2:  KeyInsert,
+	3:  KeyDelete,
+	4:  KeyEnd,
+	5:  KeyPgUp,
+	6:  KeyPgDown,
+	7:  KeyHome,
+	8:  KeyEnd,
+	11: KeyF1,
+	12: KeyF2,
+	13: KeyF3,
+	14: KeyF4,
+	15: KeyF5,
+	17: KeyF6,
+	18: KeyF7,
+	19: KeyF8,
+	20: KeyF9,
+	21: KeyF10,
+	23: KeyF11,
+	24: KeyF12,
+}
+
+// decodeSS3 decodes "ESC O x" sequences.
+func decodeSS3(tok string) Event {
+	k, ok := finalKeys[tok[len(tok)-1]]
+	if !ok {
+		return UnknownEvent{Seq: tok}
+	}
+
+	var mod KeyMod
+	if m := tok[2 : len(tok)-1]; m != "" {
+		p, err := strconv.Atoi(m)
+		if err != nil {
+			return UnknownEvent{Seq: tok}
+		}
+		mod = decodeModifiers(p)
+	}
+	return KeyEvent{Type: k, Mod: mod}
+}
+
+// decodeCSI decodes "ESC [ ..." sequences.
+func decodeCSI(tok string) Event {
+	if len(tok) == len(CSI)+4 && tok[len(CSI)] == 'M' {
+		// X10 mouse
+		return decodeMouse(tok)
+	}
+
+	params := tok[len(CSI) : len(tok)-1]
+	final := tok[len(tok)-1]
+
+	switch {
+	case (final == 'M' || final == 'm') && strings.HasPrefix(params, "<"):
+		// SGR mouse
+		return decodeMouse(tok)
+	case final == 'M' && strings.Count(params, ";") == 2:
+		// urxvt mouse
+		return decodeMouse(tok)
+	case final == 'I' && params == "":
+		return FocusEvent{Focused: true}
+	case final == 'O' && params == "":
+		return FocusEvent{Focused: false}
+	case final == 'R' && strings.Contains(params, ";"):
+		// cursor position report
+		return ResponseEvent{Seq: tok}
+	case final == 'c' && strings.HasPrefix(params, "?"):
+		// device attributes
+		return ResponseEvent{Seq: tok}
+	case final == 'u' && strings.HasPrefix(params, "?"):
+		// kitty keyboard flags
+		return ResponseEvent{Seq: tok}
+	case final == 'n' && strings.HasPrefix(params, "?997;"):
+		if ev, ok := decodeColorScheme(params); ok {
+			return ev
+		}
+	case final == '~' && params == EndBracketedPasteSeq[:len(EndBracketedPasteSeq)-1]:
+		return PasteEndEvent{}
+	case final == 'Z' && params == "":
+		return KeyEvent{Type: KeyTab, Mod: ModShift}
+	}
+
+	if k, ok := decodeKeyCSI(params, final); ok {
+		return k
+	}
+
+	return UnknownEvent{Seq: tok}
+}
@@ -0,0 +1,95 @@
+package termenv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readEvents(t *testing.T, s string) []Event {
+	t.Helper()
+
+	var events []Event
+	ir := NewInputReader(strings.NewReader(s))
+	for {
+		ev, err := ir.ReadEvent()
+		if errors.Is(err, io.EOF) {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		events = append(events, ev)
+	}
+}
+
+func TestInputTokenizer(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		events []Event
+	}{
+		{"runes", "aö世", []Event{
+			KeyEvent{Type: KeyRune, Rune: 'a'},
+			KeyEvent{Type: KeyRune, Rune: 'ö'},
+			KeyEvent{Type: KeyRune, Rune: '世'},
+		}},
+		{"control", "\x01\r\t\x7f", []Event{
+			KeyEvent{Type: KeyRune, Rune: 'a', Mod: ModCtrl},
+			KeyEvent{Type: KeyEnter},
+			KeyEvent{Type: KeyTab},
+			KeyEvent{Type: KeyBackspace},
+		}},
+		{"escape", "\x1b", []Event{KeyEvent{Type: KeyEscape}}},
+		{"alt", "\x1bx", []Event{KeyEvent{Type: KeyRune, Rune: 'x', Mod: ModAlt}}},
+		{"arrows", "\x1b[A\x1bOB", []Event{
+			KeyEvent{Type: KeyUp},
+			KeyEvent{Type: KeyDown},
+		}},
+		{"tilde", "\x1b[3~\x1b[24~", []Event{
+			KeyEvent{Type: KeyDelete},
+			KeyEvent{Type: KeyF12},
+		}},
+		{"backtab", "\x1b[Z", []Event{KeyEvent{Type: KeyTab, Mod: ModShift}}},
+		{"focus", "\x1b[I\x1b[O", []Event{
+			FocusEvent{Focused: true},
+			FocusEvent{Focused: false},
+		}},
+		{"paste", "\x1b[200~a\x1b[Ab\r\nc\x1b[201~d", []Event{
+			PasteEvent{Text: "a[Ab\nc"},
+			KeyEvent{Type: KeyRune, Rune: 'd'},
+		}},
+		{"stray paste end", "\x1b[201~", []Event{PasteEndEvent{}}},
+		{"mouse", "\x1b[M !!\x1b[<0;1;1M", []Event{
+			MouseEvent{X: 1, Y: 1, Button: MouseLeft, Seq: "\x1b[M !!"},
+			MouseEvent{X: 1, Y: 1, Button: MouseLeft, Seq: "\x1b[<0;1;1M"},
+		}},
+		{"responses", "\x1b]11;rgb:0000/0000/0000\a\x1b[12;40R\x1b[?62;22c", []Event{
+			ResponseEvent{Seq: "\x1b]11;rgb:0000/0000/0000\a"},
+			ResponseEvent{Seq: "\x1b[12;40R"},
+			ResponseEvent{Seq: "\x1b[?62;22c"},
+		}},
+		{"st terminator", "\x1bP>|xterm\x1b\\", []Event{
+			ResponseEvent{Seq: "\x1bP>|xterm\x1b\\"},
+		}},
+		{"unknown", "\x1b[99x", []Event{UnknownEvent{Seq: "\x1b[99x"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events := readEvents(t, test.input)
+			if !reflect.DeepEqual(events, test.events) {
+				t.Errorf("expected %#v, got %#v", test.events, events)
+			}
+		})
+	}
+}
+
+func TestKeyEventString(t *testing.T) {
+	k := KeyEvent{Type: KeyUp, Mod: ModCtrl | ModShift}
+	if k.String() != "ctrl+shift+up" {
+		t.Errorf("expected ctrl+shift+up, got %s", k.String())
+	}
+}
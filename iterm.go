@@ -0,0 +1,68 @@
+package termenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// iTerm2 proprietary escape sequences, sent with OSC 1337.
+const (
+	ITermSetMarkSeq       = "1337;SetMark"
+	ITermAddAnnotationSeq = "1337;AddAnnotation=%s"
+)
+
+// isITerm2 returns whether the terminal is iTerm2. LC_TERMINAL is checked
+// as well, as iTerm2 forwards it to SSH sessions.
+func (o *Output) isITerm2() bool {
+	return o.environ.Getenv("TERM_PROGRAM") == "iTerm.app" ||
+		o.environ.Getenv("LC_TERMINAL") == "iTerm2"
+}
+
+// SetMark sets a mark at the cursor position. iTerm2 lets the user jump
+// between marks in the scrollback. Other terminals are left untouched.
+func SetMark() {
+	output.SetMark()
+}
+
+// SetMark sets a mark at the cursor position. iTerm2 lets the user jump
+// between marks in the scrollback. Other terminals are left untouched.
+func (o *Output) SetMark() {
+	if !o.isITerm2() {
+		return
+	}
+	_, _ = o.WritePassthrough(OSC + ITermSetMarkSeq + ST)
+}
+
+// AddAnnotation attaches text as an annotation to the next length cells
+// starting at the cursor position, or to the rest of the line if length is
+// not positive. It is only sent to iTerm2.
+func AddAnnotation(text string, length int) {
+	output.AddAnnotation(text, length)
+}
+
+// AddAnnotation attaches text as an annotation to the next length cells
+// starting at the cursor position, or to the rest of the line if length is
+// not positive. It is only sent to iTerm2.
+func (o *Output) AddAnnotation(text string, length int) {
+	if !o.isITerm2() {
+		return
+	}
+
+	text = stripControls(text)
+	if length > 0 {
+		text = strconv.Itoa(length) + "|" + text
+	}
+	_, _ = o.WritePassthrough(fmt.Sprintf(OSC+ITermAddAnnotationSeq+ST, text))
+}
+
+// stripControls removes control characters from s, as they would terminate
+// an OSC sequence early.
+func stripControls(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f || (r >= 0x80 && r < 0xa0) { //nolint:mnd
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,34 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestITermMarks(t *testing.T) {
+	iterm := mapEnv{"TERM_PROGRAM": "iTerm.app"}
+	tests := []struct {
+		name     string
+		environ  mapEnv
+		f        func(o *Output)
+		expected string
+	}{
+		{"mark", iterm, func(o *Output) { o.SetMark() }, "\x1b]1337;SetMark\x1b\\"},
+		{"annotation", iterm, func(o *Output) { o.AddAnnotation("build done", 0) }, "\x1b]1337;AddAnnotation=build done\x1b\\"},
+		{"annotation length", iterm, func(o *Output) { o.AddAnnotation("error", 5) }, "\x1b]1337;AddAnnotation=5|error\x1b\\"},
+		{"annotation controls", iterm, func(o *Output) { o.AddAnnotation("a\x1b\\b\a", 0) }, "\x1b]1337;AddAnnotation=a\\b\x1b\\"},
+		{"ssh", mapEnv{"LC_TERMINAL": "iTerm2"}, func(o *Output) { o.SetMark() }, "\x1b]1337;SetMark\x1b\\"},
+		{"other terminal", mapEnv{"TERM": "xterm-kitty"}, func(o *Output) { o.SetMark(); o.AddAnnotation("a", 1) }, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			o := NewOutput(&buf, WithEnvironment(test.environ), WithProfile(TrueColor))
+			test.f(o)
+			if got := buf.String(); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package termenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Sequence definitions.
+const (
+	// Kitty keyboard protocol.
+	// https://sw.kovidgoyal.net/kitty/keyboard-protocol/
+	PushKittyKeyboardSeq = ">%du"
+	PopKittyKeyboardSeq  = "<u"
+
+	// xterm modifyOtherKeys.
+	EnableModifyOtherKeysSeq  = ">4;2m"
+	DisableModifyOtherKeysSeq = ">4;0m"
+)
+
+// Kitty keyboard protocol flags.
+const (
+	KittyDisambiguateEscapeCodes = 1 << iota
+	KittyReportEventTypes
+	KittyReportAlternateKeys
+	KittyReportAllKeysAsEscapeCodes
+	KittyReportAssociatedText
+)
+
+// KeyAction is the kind of key event. Only terminals implementing the kitty
+// keyboard protocol report repeats and releases.
+type KeyAction int
+
+// Key actions.
+const (
+	KeyPress KeyAction = iota
+	KeyRepeat
+	KeyRelease
+)
+
+// KeyboardProtocol is a bitmask of keyboard encodings a terminal understands.
+type KeyboardProtocol int
+
+// Keyboard protocols.
+const (
+	// KeyboardLegacy is the classic VT/xterm encoding. Every terminal
+	// supports it.
+	KeyboardLegacy KeyboardProtocol = 1 << iota
+	// KeyboardModifyOtherKeys is xterm's "CSI 27 ; mod ; key ~" encoding.
+	KeyboardModifyOtherKeys
+	// KeyboardKitty is the kitty keyboard protocol ("CSI key ; mod u").
+	KeyboardKitty
+)
+
+// keyboardProtocols maps TERM_PROGRAM and TERM values to the keyboard
+// encodings these terminals are known to support.
+var keyboardProtocols = map[string]KeyboardProtocol{
+	"alacritty":     KeyboardLegacy | KeyboardKitty,
+	"foot":          KeyboardLegacy | KeyboardKitty,
+	"ghostty":       KeyboardLegacy | KeyboardKitty,
+	"iTerm.app":     KeyboardLegacy | KeyboardModifyOtherKeys | KeyboardKitty,
+	"rio":           KeyboardLegacy | KeyboardKitty,
+	"WezTerm":       KeyboardLegacy | KeyboardModifyOtherKeys | KeyboardKitty,
+	"wezterm":       KeyboardLegacy | KeyboardModifyOtherKeys | KeyboardKitty,
+	"xterm":         KeyboardLegacy | KeyboardModifyOtherKeys,
+	"xterm-ghostty": KeyboardLegacy | KeyboardKitty,
+	"xterm-kitty":   KeyboardLegacy | KeyboardKitty,
+}
+
+// KeyboardProtocols returns the keyboard encodings the terminal is known to
+// support, based on the environment.
+func (o *Output) KeyboardProtocols() KeyboardProtocol {
+	if p, ok := keyboardProtocols[o.environ.Getenv("TERM_PROGRAM")]; ok {
+		return p
+	}
+
+	term := o.environ.Getenv("TERM")
+	if p, ok := keyboardProtocols[term]; ok {
+		return p
+	}
+	if strings.HasPrefix(term, "xterm") {
+		return keyboardProtocols["xterm"]
+	}
+
+	return KeyboardLegacy
+}
+
+// EnableKittyKeyboard pushes the given kitty keyboard protocol flags onto the
+// terminal's stack.
+func (o Output) EnableKittyKeyboard(flags int) {
+	fmt.Fprintf(o.w, CSI+PushKittyKeyboardSeq, flags) //nolint:errcheck
+}
+
+// DisableKittyKeyboard pops the kitty keyboard protocol flags pushed by
+// EnableKittyKeyboard.
+func (o Output) DisableKittyKeyboard() {
+	fmt.Fprint(o.w, CSI+PopKittyKeyboardSeq) //nolint:errcheck
+}
+
+// EnableModifyOtherKeys enables xterm's modifyOtherKeys mode.
+func (o Output) EnableModifyOtherKeys() {
+	fmt.Fprint(o.w, CSI+EnableModifyOtherKeysSeq) //nolint:errcheck
+}
+
+// DisableModifyOtherKeys disables xterm's modifyOtherKeys mode.
+func (o Output) DisableModifyOtherKeys() {
+	fmt.Fprint(o.w, CSI+DisableModifyOtherKeysSeq) //nolint:errcheck
+}
+
+// splitKeyParams splits CSI parameters like "97:65;6:3" into fields and
+// sub-fields. Missing values are returned as -1.
+func splitKeyParams(params string) ([][]int, bool) {
+	if params == "" {
+		return nil, true
+	}
+
+	fields := strings.Split(params, ";")
+	res := make([][]int, len(fields))
+	for i, f := range fields {
+		subs := strings.Split(f, ":")
+		res[i] = make([]int, len(subs))
+		for j, s := range subs {
+			if s == "" {
+				res[i][j] = -1
+				continue
+			}
+			v, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, false
+			}
+			res[i][j] = v
+		}
+	}
+	return res, true
+}
+
+// param returns the given (sub-)parameter or def if it is missing.
+func param(fields [][]int, i, j, def int) int {
+	if i >= len(fields) || j >= len(fields[i]) || fields[i][j] < 0 {
+		return def
+	}
+	return fields[i][j]
+}
+
+// decodeModifiers decodes an xterm/kitty modifier parameter (1 + bitmask of
+// shift, alt, ctrl, super, hyper, meta). Values below 1 carry no modifiers.
+//
+//nolint:mnd
+func decodeModifiers(p int) KeyMod {
+	var mod KeyMod
+	if p < 1 {
+		return mod
+	}
+	p--
+	if p&1 != 0 {
+		mod |= ModShift
+	}
+	if p&2 != 0 {
+		mod |= ModAlt
+	}
+	if p&4 != 0 {
+		mod |= ModCtrl
+	}
+	if p&8 != 0 {
+		mod |= ModSuper
+	}
+	if p&16 != 0 {
+		mod |= ModHyper
+	}
+	if p&32 != 0 {
+		mod |= ModMeta
+	}
+	return mod
+}
+
+// decodeKeyAction decodes a kitty event type parameter.
+func decodeKeyAction(p int) KeyAction {
+	switch p {
+	case 2: //nolint:mnd
+		return KeyRepeat
+	case 3: //nolint:mnd
+		return KeyRelease
+	}
+	return KeyPress
+}
+
+// decodeCodepoint decodes a unicode codepoint sent by the CSI u or
+// modifyOtherKeys encodings.
+func decodeCodepoint(code int, mod KeyMod) KeyEvent {
+	k := decodeChar(string(rune(code)))
+	k.Mod |= mod
+	return k
+}
+
+// decodeKeyCSI decodes key presses sent as CSI sequences, handling
+// modifiers in the legacy xterm ("CSI 1 ; 5 A"), modifyOtherKeys
+// ("CSI 27 ; 5 ; 97 ~"), and CSI u / kitty ("CSI 97 ; 5 u") encodings.
+func decodeKeyCSI(params string, final byte) (KeyEvent, bool) {
+	fields, ok := splitKeyParams(params)
+	if !ok {
+		return KeyEvent{}, false
+	}
+
+	mod := decodeModifiers(param(fields, 1, 0, 1))
+	action := decodeKeyAction(param(fields, 1, 1, 1))
+
+	var k KeyEvent
+	switch final {
+	case 'u':
+		code := param(fields, 0, 0, -1)
+		if code < 0 {
+			return KeyEvent{}, false
+		}
+		k = decodeCodepoint(code, mod)
+
+	case '~':
+		code := param(fields, 0, 0, -1)
+		if code == 27 { //nolint:mnd
+			// modifyOtherKeys
+			c := param(fields, 2, 0, -1)
+			if c < 0 {
+				return KeyEvent{}, false
+			}
+			k = decodeCodepoint(c, mod)
+			break
+		}
+
+		t, ok := tildeKeys[code]
+		if !ok {
+			return KeyEvent{}, false
+		}
+		k = KeyEvent{Type: t, Mod: mod}
+
+	default:
+		t, ok := finalKeys[final]
+		if !ok {
+			return KeyEvent{}, false
+		}
+		k = KeyEvent{Type: t, Mod: mod}
+	}
+
+	k.Action = action
+	return k, true
+}
@@ -0,0 +1,62 @@
+package termenv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyModifiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		event Event
+	}{
+		{"legacy ctrl+up", "\x1b[1;5A", KeyEvent{Type: KeyUp, Mod: ModCtrl}},
+		{"legacy shift+delete", "\x1b[3;2~", KeyEvent{Type: KeyDelete, Mod: ModShift}},
+		{"ss3 ctrl+f1", "\x1bO5P", KeyEvent{Type: KeyF1, Mod: ModCtrl}},
+		{"modifyOtherKeys ctrl+shift+a", "\x1b[27;6;97~", KeyEvent{Type: KeyRune, Rune: 'a', Mod: ModCtrl | ModShift}},
+		{"csi u ctrl+a", "\x1b[97;5u", KeyEvent{Type: KeyRune, Rune: 'a', Mod: ModCtrl}},
+		{"csi u enter", "\x1b[13u", KeyEvent{Type: KeyEnter}},
+		{"csi u zero modifiers", "\x1b[97;0u", KeyEvent{Type: KeyRune, Rune: 'a'}},
+		{"csi u super+x", "\x1b[120;9u", KeyEvent{Type: KeyRune, Rune: 'x', Mod: ModSuper}},
+		{"kitty release", "\x1b[97;1:3u", KeyEvent{Type: KeyRune, Rune: 'a', Action: KeyRelease}},
+		{"kitty repeat arrow", "\x1b[1;1:2D", KeyEvent{Type: KeyLeft, Action: KeyRepeat}},
+		{"kitty alternate keys", "\x1b[97:65;2u", KeyEvent{Type: KeyRune, Rune: 'a', Mod: ModShift}},
+		{"kitty flags response", "\x1b[?1u", ResponseEvent{Seq: "\x1b[?1u"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events := readEvents(t, test.input)
+			if len(events) != 1 || !reflect.DeepEqual(events[0], test.event) {
+				t.Errorf("expected %#v, got %#v", test.event, events)
+			}
+		})
+	}
+}
+
+func TestKeyboardProtocols(t *testing.T) {
+	tests := []struct {
+		environ  map[string]string
+		expected KeyboardProtocol
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, KeyboardLegacy | KeyboardKitty},
+		{map[string]string{"TERM": "xterm-256color"}, KeyboardLegacy | KeyboardModifyOtherKeys},
+		{map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, KeyboardLegacy | KeyboardModifyOtherKeys | KeyboardKitty},
+		{map[string]string{"TERM": "linux"}, KeyboardLegacy},
+	}
+
+	for _, test := range tests {
+		o := NewOutput(nil, WithEnvironment(mapEnv(test.environ)), WithProfile(ANSI))
+		if p := o.KeyboardProtocols(); p != test.expected {
+			t.Errorf("%v: expected %d, got %d", test.environ, test.expected, p)
+		}
+	}
+}
+
+func TestKittyKeyboard(t *testing.T) {
+	o := tempOutput(t)
+	o.EnableKittyKeyboard(KittyDisambiguateEscapeCodes | KittyReportEventTypes)
+	o.DisableKittyKeyboard()
+	verify(t, o, "\x1b[>3u\x1b[<u")
+}
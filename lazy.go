@@ -0,0 +1,49 @@
+package termenv
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WithLazyDetection returns a new OutputOption deferring all terminal queries.
+// Until Refine gets called, the default colors are derived from the
+// environment only, so nothing delays the first render. Register a listener
+// with OnRedetect to adapt to the queried values.
+func WithLazyDetection() OutputOption {
+	return func(o *Output) {
+		o.lazy = true
+	}
+}
+
+// Refine ends lazy detection: it queries the terminal, e.g. for its
+// background color, and notifies all listeners registered with OnRedetect.
+// Call it after the first render. Without lazy detection it is equivalent to
+// Redetect.
+func (o *Output) Refine() Snapshot {
+	o.mu.Lock()
+	o.lazy = false
+	o.mu.Unlock()
+	return o.Redetect()
+}
+
+// envColor returns the default fore- or background color as advertised by
+// the COLORFGBG environment variable, or the common default of light gray on
+// black.
+func (o *Output) envColor(bg bool) Color {
+	if c := strings.Split(o.environ.Getenv("COLORFGBG"), ";"); len(c) > 1 {
+		v := c[0]
+		if bg {
+			v = c[len(c)-1]
+		}
+		if i, err := strconv.Atoi(v); err == nil {
+			return ANSIColor(i)
+		}
+	}
+
+	if bg {
+		// default black
+		return ANSIColor(0)
+	}
+	// default gray
+	return ANSIColor(7)
+}
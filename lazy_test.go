@@ -0,0 +1,39 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestLazyDetection(t *testing.T) {
+	env := mapEnv{"TERM": "xterm-256color", "COLORFGBG": "0;15"}
+	o := NewOutput(nil, WithEnvironment(env), WithTTY(true), WithLazyDetection())
+
+	if c := o.BackgroundColor(); c != ANSIColor(15) {
+		t.Errorf("expected background %v, got %v", ANSIColor(15), c)
+	}
+	if c := o.ForegroundColor(); c != ANSIColor(0) {
+		t.Errorf("expected foreground %v, got %v", ANSIColor(0), c)
+	}
+	if o.HasDarkBackground() {
+		t.Error("expected a light background")
+	}
+
+	var refined bool
+	o.OnRedetect(func(Snapshot) {
+		refined = true
+	})
+	o.Refine()
+	if !refined || o.lazy {
+		t.Error("expected Refine to end lazy detection and notify listeners")
+	}
+}
+
+func TestLazyDetectionDefaults(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{}), WithTTY(true), WithLazyDetection())
+	if c := o.BackgroundColor(); c != ANSIColor(0) {
+		t.Errorf("expected background %v, got %v", ANSIColor(0), c)
+	}
+	if c := o.ForegroundColor(); c != ANSIColor(7) {
+		t.Errorf("expected foreground %v, got %v", ANSIColor(7), c)
+	}
+}
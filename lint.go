@@ -0,0 +1,134 @@
+package termenv
+
+import "strings"
+
+// Warning describes a style attribute the terminal will ignore or misrender.
+type Warning struct {
+	// Attribute is the SGR parameter causing the warning, e.g. "3".
+	Attribute string
+	Message   string
+}
+
+func (w Warning) String() string {
+	return w.Message
+}
+
+// Lint returns the output's warnings for the given style, see Lint.
+func (o *Output) Lint(style Style) []Warning {
+	return Lint(style, o.Capabilities())
+}
+
+// Lint reports the attributes of style a terminal with the given
+// capabilities will ignore or misrender, so applications can adjust their
+// styles or log diagnostics.
+func Lint(style Style, caps Capabilities) []Warning {
+	var warnings []Warning
+	warn := func(attr, msg string) {
+		warnings = append(warnings, Warning{Attribute: attr, Message: msg})
+	}
+
+	for _, seq := range style.styles {
+		switch seq {
+		case "":
+			continue
+		case BoldSeq:
+			if !caps.Bold {
+				warn(seq, "bold is not supported")
+			}
+		case FaintSeq:
+			if !caps.Faint {
+				warn(seq, "faint is not supported")
+			}
+		case ItalicSeq:
+			if !caps.Italic {
+				warn(seq, "italic is not supported")
+			}
+		case UnderlineSeq:
+			if !caps.Underline {
+				warn(seq, "underline is not supported")
+			}
+		case OverlineSeq:
+			if !caps.Overline {
+				warn(seq, "overline is not supported")
+			}
+		case BlinkSeq:
+			if !caps.Blink {
+				warn(seq, "blink is not supported")
+			}
+		case ReverseSeq:
+			if !caps.Reverse {
+				warn(seq, "reverse is not supported")
+			}
+		case CrossOutSeq:
+			if !caps.CrossOut {
+				warn(seq, "crossed-out is not supported")
+			}
+		default:
+			switch {
+			case isUnderlineStyleSeq(seq):
+				if !caps.UnderlineStyles {
+					warn(seq, "underline styles are not supported")
+				}
+			case styleLayer(seq) == layerUnderlineColor:
+				if !caps.UnderlineColor {
+					warn(seq, "underline colors are not supported")
+				}
+			default:
+				if p := colorSeqProfile(seq); p < caps.Profile {
+					warn(seq, p.Name()+" color exceeds the terminal's "+caps.Profile.Name()+" profile")
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// Adapt returns style adapted to the output's terminal, see Adapt.
+func (o *Output) Adapt(style Style) Style {
+	return Adapt(style, o.Capabilities())
+}
+
+// Adapt returns style without the attributes a terminal with the given
+// capabilities doesn't support, so they can't get misrendered. Underline
+// styles fall back to a plain underline. Colors are kept, as they get
+// converted to the terminal's profile when created with Output.Color.
+func Adapt(style Style, caps Capabilities) Style {
+	attrs := map[string]bool{
+		BoldSeq:      caps.Bold,
+		FaintSeq:     caps.Faint,
+		ItalicSeq:    caps.Italic,
+		UnderlineSeq: caps.Underline,
+		OverlineSeq:  caps.Overline,
+		BlinkSeq:     caps.Blink,
+		ReverseSeq:   caps.Reverse,
+		CrossOutSeq:  caps.CrossOut,
+	}
+
+	adapted := style
+	adapted.styles = nil
+	for _, seq := range style.styles {
+		switch {
+		case isUnderlineStyleSeq(seq) && !caps.UnderlineStyles:
+			seq = UnderlineSeq
+		case styleLayer(seq) == layerUnderlineColor && !caps.UnderlineColor:
+			continue
+		}
+		if supported, ok := attrs[seq]; ok && !supported {
+			continue
+		}
+		adapted = adapted.add(seq)
+	}
+	return adapted
+}
+
+// colorSeqProfile returns the profile required to render a color sequence.
+func colorSeqProfile(seq string) Profile {
+	switch {
+	case strings.HasPrefix(seq, Foreground+";2;"), strings.HasPrefix(seq, Background+";2;"):
+		return TrueColor
+	case strings.HasPrefix(seq, Foreground+";5;"), strings.HasPrefix(seq, Background+";5;"):
+		return ANSI256
+	}
+	return ANSI
+}
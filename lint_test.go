@@ -0,0 +1,37 @@
+package termenv
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	s := TrueColor.String("foo").Bold().Italic().Blink().Foreground(TrueColor.Color("#ff0000"))
+
+	tests := []struct {
+		name     string
+		environ  map[string]string
+		profile  Profile
+		expected []string
+	}{
+		{"xterm", map[string]string{"TERM": "xterm-256color"}, TrueColor, nil},
+		{"linux console", map[string]string{"TERM": "linux"}, ANSI, []string{ItalicSeq, BlinkSeq, "38;2;255;0;0"}},
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, TrueColor, []string{BlinkSeq}},
+		{"ascii", map[string]string{"TERM": "xterm-256color"}, Ascii, []string{BoldSeq, ItalicSeq, BlinkSeq, "38;2;255;0;0"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := NewOutput(io.Discard, WithEnvironment(mapEnv(test.environ)), WithProfile(test.profile))
+
+			var attrs []string
+			for _, w := range o.Lint(s) {
+				attrs = append(attrs, w.Attribute)
+			}
+			if !reflect.DeepEqual(attrs, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, attrs)
+			}
+		})
+	}
+}
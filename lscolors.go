@@ -0,0 +1,144 @@
+package termenv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidLSColors gets returned when an LS_COLORS entry can't be parsed.
+var ErrInvalidLSColors = errors.New("invalid LS_COLORS entry")
+
+// LSColors is a parsed LS_COLORS (dircolors) configuration.
+type LSColors struct {
+	// Types maps the two-letter file type indicators, e.g. "di" for
+	// directories or "ex" for executables, to their styles.
+	Types map[string]Style
+	// Patterns maps file name patterns, e.g. "*.tar", to their styles.
+	Patterns map[string]Style
+}
+
+// ParseLSColors parses the LS_COLORS syntax, e.g. "di=01;34:*.tar=01;31".
+func ParseLSColors(s string) (*LSColors, error) {
+	return output.ParseLSColors(s)
+}
+
+// ParseLSColors parses the LS_COLORS syntax, e.g. "di=01;34:*.tar=01;31".
+// The indicators for the left, right, and end codes (lc, rc, ec) are ignored,
+// as termenv renders sequences on its own.
+func (o *Output) ParseLSColors(s string) (*LSColors, error) {
+	c := &LSColors{
+		Types:    map[string]Style{},
+		Patterns: map[string]Style{},
+	}
+
+	for _, entry := range strings.Split(s, ":") {
+		if entry == "" {
+			continue
+		}
+		i := strings.IndexByte(entry, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("%s: %q", ErrInvalidLSColors, entry)
+		}
+		key, params := entry[:i], entry[i+1:]
+
+		switch key {
+		case "lc", "rc", "ec":
+			continue
+		}
+		if !isSGRParams(params) {
+			return nil, fmt.Errorf("%s: %q", ErrInvalidLSColors, entry)
+		}
+
+		style := o.sgrStyle(params)
+		if strings.HasPrefix(key, "*") {
+			c.Patterns[key] = style
+		} else {
+			c.Types[key] = style
+		}
+	}
+
+	return c, nil
+}
+
+// LSColors parses the LS_COLORS environment variable. It returns nil if the
+// variable is not set.
+func (o *Output) LSColors() (*LSColors, error) {
+	v := o.environ.Getenv("LS_COLORS")
+	if v == "" {
+		return nil, nil //nolint:nilnil
+	}
+	return o.ParseLSColors(v)
+}
+
+// Style returns the style for a file with the given name and mode, following
+// the precedence of GNU ls: special file types come first, then the longest
+// matching pattern, then the style for regular files.
+func (c *LSColors) Style(name string, mode os.FileMode) Style {
+	if s, ok := c.typeStyle(mode); ok {
+		return s
+	}
+
+	var (
+		match string
+		style Style
+	)
+	for pattern, s := range c.Patterns {
+		suffix := pattern[1:]
+		if len(suffix) > len(match) && strings.HasSuffix(name, suffix) {
+			match, style = suffix, s
+		}
+	}
+	if match != "" {
+		return style
+	}
+
+	if s, ok := c.Types["fi"]; ok {
+		return s
+	}
+	return c.Types["no"]
+}
+
+// typeStyle returns the style for special file types.
+//
+//nolint:mnd
+func (c *LSColors) typeStyle(mode os.FileMode) (Style, bool) {
+	var keys []string
+	switch {
+	case mode&os.ModeDir != 0:
+		switch {
+		case mode&os.ModeSticky != 0 && mode&0o002 != 0:
+			keys = append(keys, "tw")
+		case mode&0o002 != 0:
+			keys = append(keys, "ow")
+		case mode&os.ModeSticky != 0:
+			keys = append(keys, "st")
+		}
+		keys = append(keys, "di")
+	case mode&os.ModeSymlink != 0:
+		keys = append(keys, "ln")
+	case mode&os.ModeNamedPipe != 0:
+		keys = append(keys, "pi")
+	case mode&os.ModeSocket != 0:
+		keys = append(keys, "so")
+	case mode&os.ModeCharDevice != 0:
+		keys = append(keys, "cd")
+	case mode&os.ModeDevice != 0:
+		keys = append(keys, "bd")
+	case mode&os.ModeSetuid != 0:
+		keys = append(keys, "su")
+	case mode&os.ModeSetgid != 0:
+		keys = append(keys, "sg")
+	}
+	if mode.IsRegular() && mode&0o111 != 0 {
+		keys = append(keys, "ex")
+	}
+
+	for _, k := range keys {
+		if s, ok := c.Types[k]; ok {
+			return s, true
+		}
+	}
+	return Style{}, false
+}
@@ -0,0 +1,59 @@
+package termenv
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseLSColors(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{}), WithProfile(ANSI))
+	c, err := o.ParseLSColors("rs=0:di=01;34:ln=01;36:ex=01;32:ow=34;42:*.tar=01;31:*.tar.gz=35:lc=\\e[:*README=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		mode     os.FileMode
+		expected string
+	}{
+		{"dir", os.ModeDir | 0o755, "\x1b[01;34mdir\x1b[0m"},
+		{"tmp", os.ModeDir | 0o777, "\x1b[34;42mtmp\x1b[0m"},
+		{"link", os.ModeSymlink | 0o777, "\x1b[01;36mlink\x1b[0m"},
+		{"run.sh", 0o755, "\x1b[01;32mrun.sh\x1b[0m"},
+		{"a.tar", 0o644, "\x1b[01;31ma.tar\x1b[0m"},
+		{"a.tar.gz", 0o644, "\x1b[35ma.tar.gz\x1b[0m"},
+		{"README", 0o644, "\x1b[4mREADME\x1b[0m"},
+		{"plain.txt", 0o644, "plain.txt"},
+	}
+
+	for _, test := range tests {
+		if got := c.Style(test.name, test.mode).Styled(test.name); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestParseLSColorsInvalid(t *testing.T) {
+	for _, s := range []string{"di", "=01", "di=blue"} {
+		if _, err := ParseLSColors(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestLSColorsEnv(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{}))
+	if c, err := o.LSColors(); c != nil || err != nil {
+		t.Errorf("expected nil for unset LS_COLORS, got %v, %v", c, err)
+	}
+
+	o = NewOutput(nil, WithEnvironment(mapEnv{"LS_COLORS": "di=01;34"}))
+	c, err := o.LSColors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Types["di"]; !ok {
+		t.Error("expected directory style to be set")
+	}
+}
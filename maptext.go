@@ -0,0 +1,39 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// MapText applies f to the printable text of s, leaving escape sequences
+// untouched. f gets called for every run of text between two sequences, so
+// transforms like upper-casing can't corrupt the sequences of a styled
+// string.
+func MapText(s string, f func(string) string) string {
+	var b strings.Builder
+	walkText(s, func(text string) {
+		b.WriteString(f(text))
+	}, func(seq string) {
+		b.WriteString(seq)
+	})
+	return b.String()
+}
+
+// ReverseText returns s with its grapheme clusters in reverse order, so
+// combining characters, emoji sequences, and CRLF line breaks stay intact.
+// Combine it with MapText to reverse the text of styled strings.
+func ReverseText(s string) string {
+	var clusters []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		b.WriteString(clusters[i])
+	}
+	return b.String()
+}
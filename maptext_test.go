@@ -0,0 +1,43 @@
+package termenv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapText(t *testing.T) {
+	s := String().Bold().Styled("foo") + " bar"
+
+	exp := "\x1b[1mFOO\x1b[0m BAR"
+	if got := MapText(s, strings.ToUpper); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	exp = "\x1b[1moof\x1b[0mrab "
+	if got := MapText(s, ReverseText); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	// malformed sequences are text
+	if got := MapText("\x1b[1", strings.ToUpper); got != "\x1b[1" {
+		t.Errorf("expected %q, got %q", "\x1b[1", got)
+	}
+}
+
+func TestReverseText(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"abc", "cba"},
+		{"e\u0301a", "ae\u0301"},
+		{"a\U0001F468\u200d\U0001F469b", "b\U0001F468\u200d\U0001F469a"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := ReverseText(test.in); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
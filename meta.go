@@ -0,0 +1,30 @@
+package termenv
+
+// WithMeta returns a copy of the Style with the given metadata attached, e.g.
+// a semantic role like "error". Metadata doesn't affect rendering; it lets
+// code receiving a Style, like middleware or exporters, post-process output
+// by its meaning.
+func (t Style) WithMeta(key string, value interface{}) Style {
+	meta := make(map[string]interface{}, len(t.meta)+1)
+	for k, v := range t.meta {
+		meta[k] = v
+	}
+	meta[key] = value
+	t.meta = meta
+	return t
+}
+
+// Meta returns the metadata stored under key, and whether it is set.
+func (t Style) Meta(key string) (interface{}, bool) {
+	v, ok := t.meta[key]
+	return v, ok
+}
+
+// Metadata returns a copy of all metadata attached to the Style.
+func (t Style) Metadata() map[string]interface{} {
+	meta := make(map[string]interface{}, len(t.meta))
+	for k, v := range t.meta {
+		meta[k] = v
+	}
+	return meta
+}
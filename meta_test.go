@@ -0,0 +1,31 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestStyleMeta(t *testing.T) {
+	base := String().Bold().WithMeta("role", "error")
+	derived := base.WithMeta("role", "warning").Italic()
+
+	if v, ok := base.Meta("role"); !ok || v != "error" {
+		t.Errorf("expected role error, got %v", v)
+	}
+	if v, ok := derived.Meta("role"); !ok || v != "warning" {
+		t.Errorf("expected role warning, got %v", v)
+	}
+	if _, ok := base.Meta("missing"); ok {
+		t.Error("expected missing key to be unset")
+	}
+
+	m := base.Metadata()
+	m["role"] = "changed"
+	if v, _ := base.Meta("role"); v != "error" {
+		t.Errorf("expected Metadata to return a copy, got %v", v)
+	}
+
+	exp := "\x1b[1mfoo\x1b[0m"
+	if got := base.Styled("foo"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
@@ -0,0 +1,145 @@
+package termenv
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MouseButton is a mouse button.
+type MouseButton int
+
+// Mouse buttons.
+const (
+	MouseNone MouseButton = iota
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseWheelUp
+	MouseWheelDown
+	MouseWheelLeft
+	MouseWheelRight
+	MouseBackward
+	MouseForward
+	MouseButton10
+	MouseButton11
+)
+
+// MouseAction is the kind of mouse event.
+type MouseAction int
+
+// Mouse actions.
+const (
+	MousePress MouseAction = iota
+	MouseRelease
+	MouseMotion
+)
+
+// mouse report bits.
+const (
+	mouseShiftBit  = 4
+	mouseMetaBit   = 8
+	mouseCtrlBit   = 16
+	mouseMotionBit = 32
+	mouseWheelBit  = 64
+	mouseExtraBit  = 128
+
+	// X10 and urxvt encodings offset all values by 32.
+	mouseOffset = 32
+)
+
+// decodeMouseButton decodes the button byte shared by all mouse encodings.
+// release is true if the encoding reports releases as button 3 (X10 and
+// urxvt); SGR reports releases through its final byte instead.
+//
+//nolint:mnd
+func decodeMouseButton(cb int, release bool) (MouseButton, MouseAction, KeyMod) {
+	var mod KeyMod
+	if cb&mouseShiftBit != 0 {
+		mod |= ModShift
+	}
+	if cb&mouseMetaBit != 0 {
+		mod |= ModAlt
+	}
+	if cb&mouseCtrlBit != 0 {
+		mod |= ModCtrl
+	}
+
+	action := MousePress
+	if cb&mouseMotionBit != 0 {
+		action = MouseMotion
+	}
+
+	low := cb & 3
+	var btn MouseButton
+	switch {
+	case cb&mouseExtraBit != 0:
+		btn = MouseBackward + MouseButton(low)
+	case cb&mouseWheelBit != 0:
+		btn = MouseWheelUp + MouseButton(low)
+	case low == 3:
+		// X10 and urxvt don't tell which button has been released
+		btn = MouseNone
+		if release && action != MouseMotion {
+			action = MouseRelease
+		}
+	default:
+		btn = MouseLeft + MouseButton(low)
+	}
+
+	return btn, action, mod
+}
+
+// decodeMouse parses an X10, SGR (1006), or urxvt (1015) mouse report.
+func decodeMouse(tok string) Event {
+	ev := MouseEvent{Seq: tok}
+
+	body := tok[len(CSI):]
+	switch {
+	case body[0] == 'M' && len(body) == 4:
+		// X10: CSI M Cb Cx Cy
+		ev.Button, ev.Action, ev.Mod = decodeMouseButton(int(body[1])-mouseOffset, true)
+		ev.X = int(body[2]) - mouseOffset
+		ev.Y = int(body[3]) - mouseOffset
+		return ev
+
+	case body[0] == '<':
+		// SGR: CSI < Cb ; Cx ; Cy M/m
+		v, ok := mouseParams(body[1 : len(body)-1])
+		if !ok {
+			return UnknownEvent{Seq: tok}
+		}
+		ev.Button, ev.Action, ev.Mod = decodeMouseButton(v[0], false)
+		if body[len(body)-1] == 'm' {
+			ev.Action = MouseRelease
+		}
+		ev.X, ev.Y = v[1], v[2]
+		return ev
+
+	default:
+		// urxvt: CSI Cb ; Cx ; Cy M
+		v, ok := mouseParams(body[:len(body)-1])
+		if !ok {
+			return UnknownEvent{Seq: tok}
+		}
+		ev.Button, ev.Action, ev.Mod = decodeMouseButton(v[0]-mouseOffset, true)
+		ev.X, ev.Y = v[1], v[2]
+		return ev
+	}
+}
+
+// mouseParams parses the three numeric parameters of a mouse report.
+func mouseParams(s string) ([3]int, bool) {
+	var v [3]int
+	parts := strings.Split(s, ";")
+	if len(parts) != len(v) {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
@@ -0,0 +1,36 @@
+package termenv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMouseEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		event MouseEvent
+	}{
+		{"x10 left press", "\x1b[M 0!", MouseEvent{X: 16, Y: 1, Button: MouseLeft}},
+		{"x10 release", "\x1b[M#0!", MouseEvent{X: 16, Y: 1, Button: MouseNone, Action: MouseRelease}},
+		{"x10 ctrl wheel down", "\x1b[Mq!!", MouseEvent{X: 1, Y: 1, Button: MouseWheelDown, Mod: ModCtrl}},
+		{"sgr right press", "\x1b[<2;120;40M", MouseEvent{X: 120, Y: 40, Button: MouseRight}},
+		{"sgr right release", "\x1b[<2;120;40m", MouseEvent{X: 120, Y: 40, Button: MouseRight, Action: MouseRelease}},
+		{"sgr shift motion", "\x1b[<36;5;6M", MouseEvent{X: 5, Y: 6, Button: MouseLeft, Action: MouseMotion, Mod: ModShift}},
+		{"sgr no-button motion", "\x1b[<35;5;6M", MouseEvent{X: 5, Y: 6, Button: MouseNone, Action: MouseMotion}},
+		{"sgr wheel left", "\x1b[<66;1;1M", MouseEvent{X: 1, Y: 1, Button: MouseWheelLeft}},
+		{"sgr backward", "\x1b[<128;1;1M", MouseEvent{X: 1, Y: 1, Button: MouseBackward}},
+		{"urxvt middle press", "\x1b[33;300;2M", MouseEvent{X: 300, Y: 2, Button: MouseMiddle}},
+		{"urxvt release", "\x1b[35;300;2M", MouseEvent{X: 300, Y: 2, Button: MouseNone, Action: MouseRelease}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.event.Seq = test.input
+			events := readEvents(t, test.input)
+			if len(events) != 1 || !reflect.DeepEqual(events[0], test.event) {
+				t.Errorf("expected %#v, got %#v", test.event, events)
+			}
+		})
+	}
+}
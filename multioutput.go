@@ -0,0 +1,105 @@
+package termenv
+
+import "sync"
+
+// MultiOutput mirrors output to several Outputs, e.g. to every client
+// attached to a shared session. Clients may support different color
+// profiles, so rendering happens once per distinct profile rather than once
+// per Output.
+type MultiOutput struct {
+	mu   sync.RWMutex
+	outs []*Output
+}
+
+// NewMultiOutput returns a new MultiOutput writing to outs.
+func NewMultiOutput(outs ...*Output) *MultiOutput {
+	return &MultiOutput{
+		outs: outs,
+	}
+}
+
+// Add attaches an Output.
+func (m *MultiOutput) Add(o *Output) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.outs = append(m.outs, o)
+}
+
+// Remove detaches an Output.
+func (m *MultiOutput) Remove(o *Output) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, v := range m.outs {
+		if v == o {
+			m.outs = append(m.outs[:i], m.outs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Outputs returns the attached Outputs.
+func (m *MultiOutput) Outputs() []*Output {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]*Output{}, m.outs...)
+}
+
+// Profiles returns the distinct color profiles of the attached Outputs.
+func (m *MultiOutput) Profiles() []Profile {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var profiles []Profile
+	seen := map[Profile]bool{}
+	for _, o := range m.outs {
+		if p := o.profile(); !seen[p] {
+			seen[p] = true
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles
+}
+
+// Write writes p unmodified to all attached Outputs. Every Output gets
+// written to, even if an earlier one fails; the first error is returned.
+func (m *MultiOutput) Write(p []byte) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var err error
+	for _, o := range m.outs {
+		if _, werr := o.Write(p); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return len(p), err
+}
+
+// WriteString writes s unmodified to all attached Outputs.
+func (m *MultiOutput) WriteString(s string) (int, error) {
+	return m.Write([]byte(s))
+}
+
+// Render calls render once for every distinct profile of the attached
+// Outputs and writes the result to the Outputs using that profile. Every
+// Output gets written to, even if an earlier one fails; the first error is
+// returned.
+func (m *MultiOutput) Render(render func(p Profile) string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var err error
+	rendered := map[Profile][]byte{}
+	for _, o := range m.outs {
+		p := o.profile()
+		b, ok := rendered[p]
+		if !ok {
+			b = []byte(render(p))
+			rendered[p] = b
+		}
+		if _, werr := o.Write(b); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return err
+}
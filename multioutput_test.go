@@ -0,0 +1,44 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMultiOutput(t *testing.T) {
+	var bufs [3]bytes.Buffer
+	a := NewOutput(&bufs[0], WithProfile(TrueColor))
+	b := NewOutput(&bufs[1], WithProfile(Ascii))
+	c := NewOutput(&bufs[2], WithProfile(TrueColor))
+	m := NewMultiOutput(a, b, c)
+
+	if n := len(m.Profiles()); n != 2 {
+		t.Errorf("expected 2 distinct profiles, got %d", n)
+	}
+
+	calls := 0
+	err := m.Render(func(p Profile) string {
+		calls++
+		return p.String("foo").Foreground(p.Color("#ff0000")).String()
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 render calls, got %d", calls)
+	}
+
+	exp := "\x1b[38;2;255;0;0mfoo\x1b[0m"
+	if bufs[0].String() != exp || bufs[2].String() != exp {
+		t.Errorf("expected %q, got %q and %q", exp, bufs[0].String(), bufs[2].String())
+	}
+	if bufs[1].String() != "foo" {
+		t.Errorf("expected foo, got %q", bufs[1].String())
+	}
+
+	m.Remove(b)
+	_, _ = m.WriteString("!")
+	if bufs[1].String() != "foo" || bufs[0].String() != exp+"!" {
+		t.Errorf("unexpected output after removal: %q, %q", bufs[0].String(), bufs[1].String())
+	}
+}
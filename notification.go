@@ -1,11 +1,51 @@
 package termenv
 
-// Notify triggers a notification using OSC777.
+import (
+	"fmt"
+	"strings"
+)
+
+// Notification escape sequences.
+const (
+	// NotifySeq triggers a notification with a title and a body (OSC 777).
+	NotifySeq = "777;notify;%s;%s"
+	// NotifyMessageSeq triggers a notification with a single message
+	// (OSC 9), as understood by iTerm2 and ConEmu.
+	NotifyMessageSeq = "9;%s"
+)
+
+// Notify triggers a desktop notification, e.g. to signal that a
+// long-running command finished. It uses OSC 777, or OSC 9 in iTerm2 and
+// ConEmu, which don't support OSC 777.
 func Notify(title, body string) {
 	output.Notify(title, body)
 }
 
-// Notify triggers a notification using OSC777.
+// Notify triggers a desktop notification, e.g. to signal that a
+// long-running command finished. It uses OSC 777, or OSC 9 in iTerm2 and
+// ConEmu, which don't support OSC 777. Control characters are removed from
+// title and body, and semicolons in the title are replaced with commas.
 func (o *Output) Notify(title, body string) {
-	_, _ = o.WriteString(OSC + "777;notify;" + title + ";" + body + ST)
+	title, body = stripControls(title), stripControls(body)
+	if o.isITerm2() || o.isConEmu() {
+		// OSC 9 only carries a single message
+		msg := body
+		switch {
+		case title != "" && body != "":
+			msg = title + ": " + body
+		case title != "":
+			msg = title
+		}
+		_, _ = o.WritePassthrough(OSC + fmt.Sprintf(NotifyMessageSeq, msg) + ST)
+		return
+	}
+	// a semicolon in the title would end it early, the body is the last
+	// field and may contain them
+	title = strings.ReplaceAll(title, ";", ",")
+	_, _ = o.WritePassthrough(OSC + fmt.Sprintf(NotifySeq, title, body) + ST)
+}
+
+// isConEmu returns whether the terminal is ConEmu.
+func (o *Output) isConEmu() bool {
+	return o.environ.Getenv("ConEmuPID") != ""
 }
@@ -0,0 +1,34 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	tests := []struct {
+		name        string
+		environ     mapEnv
+		title, body string
+		expected    string
+	}{
+		{"osc 777", mapEnv{"TERM": "xterm-kitty"}, "Build", "done", "\x1b]777;notify;Build;done\x1b\\"},
+		{"controls", mapEnv{"TERM": "foot"}, "a\x1b\\", "b\a", "\x1b]777;notify;a\\;b\x1b\\"},
+		{"semicolons", mapEnv{"TERM": "foot"}, "a;b", "c;d", "\x1b]777;notify;a,b;c;d\x1b\\"},
+		{"iterm", mapEnv{"TERM_PROGRAM": "iTerm.app"}, "Build", "done", "\x1b]9;Build: done\x1b\\"},
+		{"iterm title only", mapEnv{"TERM_PROGRAM": "iTerm.app"}, "Build", "", "\x1b]9;Build\x1b\\"},
+		{"conemu", mapEnv{"ConEmuPID": "1234"}, "", "done", "\x1b]9;done\x1b\\"},
+		{"tmux", mapEnv{"TMUX": "/tmp/tmux", "TERM": "screen"}, "Build", "done", "\x1bPtmux;\x1b\x1b]777;notify;Build;done\x1b\x1b\\\x1b\\"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			o := NewOutput(&buf, WithEnvironment(test.environ), WithProfile(TrueColor))
+			o.Notify(test.title, test.body)
+			if got := buf.String(); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
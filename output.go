@@ -28,11 +28,26 @@ type Output struct {
 
 	assumeTTY bool
 	unsafe    bool
+	safe      bool
 	cache     bool
-	fgSync    *sync.Once
-	fgColor   Color
-	bgSync    *sync.Once
-	bgColor   Color
+
+	// mu guards the profile and the detected state, which Redetect and
+	// Refine replace
+	mu      *sync.Mutex
+	fgSync  *sync.Once
+	fgColor Color
+	bgSync  *sync.Once
+	bgColor Color
+	lazy    bool
+
+	detect    bool
+	listeners *listeners
+	semantics *semantics
+	palette   *Palette
+	stats     *statsWriter
+
+	hyperlinks *bool
+	altScreen  *altScreen
 }
 
 // Environ is an interface for getting environment variables.
@@ -67,10 +82,15 @@ func NewOutput(w io.Writer, opts ...OutputOption) *Output {
 		w:       w,
 		environ: &osEnviron{},
 		Profile: -1,
+		mu:      &sync.Mutex{},
 		fgSync:  &sync.Once{},
 		fgColor: NoColor{},
 		bgSync:  &sync.Once{},
 		bgColor: NoColor{},
+
+		listeners: &listeners{},
+		semantics: &semantics{},
+		altScreen: &altScreen{},
 	}
 
 	if o.w == nil {
@@ -80,12 +100,21 @@ func NewOutput(w io.Writer, opts ...OutputOption) *Output {
 		opt(o)
 	}
 	if o.Profile < 0 {
+		o.detect = true
 		o.Profile = o.EnvColorProfile()
 	}
 
 	return o
 }
 
+// profile returns the output's current profile, which Redetect may replace
+// concurrently.
+func (o *Output) profile() Profile {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.Profile
+}
+
 // WithEnvironment returns a new OutputOption for the given environment.
 func WithEnvironment(environ Environ) OutputOption {
 	return func(o *Output) {
@@ -135,55 +164,81 @@ func WithUnsafe() OutputOption {
 
 // ForegroundColor returns the terminal's default foreground color.
 func (o *Output) ForegroundColor() Color {
-	f := func() {
-		if !o.isTTY() {
-			return
-		}
-
-		o.fgColor = o.foregroundColor()
-	}
-
-	if o.cache {
-		o.fgSync.Do(f)
-	} else {
-		f()
-	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
 
+	o.detectColors(o.fgSync, o.bgSync)
 	return o.fgColor
 }
 
 // BackgroundColor returns the terminal's default background color.
 func (o *Output) BackgroundColor() Color {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.detectColors(o.bgSync, o.fgSync)
+	return o.bgColor
+}
+
+// detectColors detects both default colors, in a single round trip. If the
+// output caches its colors, once guards the detection of the requested
+// color, and other gets marked as done, as its color is known as well. The
+// caller must hold o.mu.
+func (o *Output) detectColors(once, other *sync.Once) {
 	f := func() {
 		if !o.isTTY() {
 			return
 		}
+		if o.lazy {
+			o.fgColor, o.bgColor = o.envColor(false), o.envColor(true)
+			return
+		}
 
-		o.bgColor = o.backgroundColor()
+		o.fgColor, o.bgColor = o.defaultColors()
 	}
 
-	if o.cache {
-		o.bgSync.Do(f)
-	} else {
+	if !o.cache {
 		f()
+		return
 	}
+	once.Do(func() {
+		f()
+		other.Do(func() {})
+	})
+}
 
-	return o.bgColor
+// colors returns both default colors, detected in a single round trip.
+func (o *Output) colors() (fg, bg Color) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.detectColors(o.bgSync, o.fgSync)
+	return o.fgColor, o.bgColor
 }
 
 // HasDarkBackground returns whether terminal uses a dark-ish background.
 func (o *Output) HasDarkBackground() bool {
-	c := ConvertToRGB(o.BackgroundColor())
-	_, _, l := c.Hsl()
+	return isDark(o.BackgroundColor())
+}
+
+// isDark returns whether c is a dark-ish background color.
+func isDark(c Color) bool {
+	_, _, l := ConvertToRGB(c).Hsl()
 	return l < 0.5 //nolint:mnd
 }
 
+// String returns a new Style using the output's profile. Adaptive colors
+// applied to it pick the variant matching the output's background.
+func (o *Output) String(s ...string) Style {
+	return o.profile().StringFor(o, s...)
+}
+
 // TTY returns the terminal's file descriptor. This may be nil if the output is
 // not a terminal.
 //
 // Deprecated: Use Writer() instead.
 func (o Output) TTY() File {
-	if f, ok := o.w.(File); ok {
+	if f, ok := o.Writer().(File); ok {
 		return f
 	}
 	return nil
@@ -192,7 +247,20 @@ func (o Output) TTY() File {
 // Writer returns the underlying writer. This may be of type io.Writer,
 // io.ReadWriter, or *os.File.
 func (o Output) Writer() io.Writer {
-	return o.w
+	w := o.w
+	for {
+		u, ok := w.(writerWrapper)
+		if !ok {
+			return w
+		}
+		w = u.unwrap()
+	}
+}
+
+// writerWrapper is implemented by writers Output options wrap the underlying
+// writer in.
+type writerWrapper interface {
+	unwrap() io.Writer
 }
 
 func (o Output) Write(p []byte) (int, error) {
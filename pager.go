@@ -0,0 +1,341 @@
+package termenv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// PagerOption sets an option on a Pager.
+type PagerOption = func(*Pager)
+
+// WithPagerInput returns a new PagerOption reading keys from r instead of
+// os.Stdin.
+func WithPagerInput(r io.Reader) PagerOption {
+	return func(p *Pager) {
+		p.in = r
+	}
+}
+
+// WithPagerSize returns a new PagerOption setting the size of the screen.
+// By default, the size of the terminal is used.
+func WithPagerSize(width, height int) PagerOption {
+	return func(p *Pager) {
+		p.width = width
+		p.height = height
+	}
+}
+
+// WithPagerMatchStyle returns a new PagerOption setting the style search
+// matches are highlighted with.
+func WithPagerMatchStyle(s Style) PagerOption {
+	return func(p *Pager) {
+		p.match = s
+	}
+}
+
+// Pager shows styled text one screen at a time, like less. It reads the
+// text only as far as it is displayed, so it can page through streams.
+//
+// Lines get wrapped to the width of the screen. The bottom line of the
+// screen is used as a status line; the lines above form a scrolling region,
+// so scrolling by a line only redraws that line.
+//
+// Keys: j, down or enter scroll down a line, k or up scroll up a line,
+// space, f or page down scroll down a screen, b or page up scroll up a
+// screen, g or home jump to the top, G or end to the bottom. / searches
+// forward, n and N jump to the next and previous match, and q, esc or
+// ctrl+c quit.
+type Pager struct {
+	o     *Output
+	in    io.Reader
+	src   *bufio.Reader
+	eof   bool
+	lines []string
+
+	width, height int
+	top           int
+	query         string
+	queryRe       *regexp.Regexp
+	match         Style
+}
+
+// NewPager returns a new Pager showing the text read from r on the default
+// output.
+func NewPager(r io.Reader, opts ...PagerOption) *Pager {
+	return output.NewPager(r, opts...)
+}
+
+// NewPager returns a new Pager showing the text read from r on o.
+func (o *Output) NewPager(r io.Reader, opts ...PagerOption) *Pager {
+	p := &Pager{
+		o:     o,
+		in:    os.Stdin,
+		src:   bufio.NewReader(r),
+		match: o.String().Reverse(),
+	}
+	if fd, err := o.fd(); err == nil {
+		p.width, p.height, _ = term.GetSize(fd)
+	}
+	if p.width <= 0 || p.height <= 0 {
+		p.width, p.height = 80, 24 //nolint:mnd
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Run shows the pager until the user quits it, or the input ends. It
+// switches to the alternate screen while running.
+func (p *Pager) Run() error {
+	in, restore, err := rawInput(p.in)
+	if err != nil {
+		return err
+	}
+	defer restore() //nolint:errcheck
+	ir := NewInputReader(in)
+
+	p.o.AltScreen()
+	p.o.HideCursor()
+	p.o.ChangeScrollingRegion(1, p.rows())
+	defer func() {
+		p.o.ChangeScrollingRegion(1, p.height)
+		p.o.ShowCursor()
+		p.o.ExitAltScreen()
+	}()
+
+	if err := p.redraw(); err != nil {
+		return err
+	}
+	for {
+		ev, err := ir.ReadEvent()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		k, ok := ev.(KeyEvent)
+		if !ok || k.Action == KeyRelease {
+			continue
+		}
+
+		switch {
+		case k.Type == KeyRune && k.Mod&ModCtrl != 0 && k.Rune == 'c',
+			k.Type == KeyEscape, k.Type == KeyRune && k.Rune == 'q':
+			return nil
+		case k.Type == KeyDown, k.Type == KeyEnter, k.Type == KeyRune && k.Rune == 'j':
+			err = p.scroll(1)
+		case k.Type == KeyUp, k.Type == KeyRune && k.Rune == 'k':
+			err = p.scroll(-1)
+		case k.Type == KeyPgDown, k.Type == KeyRune && (k.Rune == ' ' || k.Rune == 'f'):
+			err = p.jump(p.top + p.rows())
+		case k.Type == KeyPgUp, k.Type == KeyRune && k.Rune == 'b':
+			err = p.jump(p.top - p.rows())
+		case k.Type == KeyHome, k.Type == KeyRune && k.Rune == 'g':
+			err = p.jump(0)
+		case k.Type == KeyEnd, k.Type == KeyRune && k.Rune == 'G':
+			if err = p.load(-1); err == nil {
+				err = p.jump(len(p.lines))
+			}
+		case k.Type == KeyRune && k.Rune == '/':
+			if p.query, err = p.readQuery(ir); err == nil {
+				p.queryRe = regexp.MustCompile(regexp.QuoteMeta(p.query))
+				err = p.search(1)
+			}
+		case k.Type == KeyRune && k.Rune == 'n':
+			err = p.search(1)
+		case k.Type == KeyRune && k.Rune == 'N':
+			err = p.search(-1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Top returns the index of the first line on the screen. Lines are counted
+// after wrapping.
+func (p *Pager) Top() int {
+	return p.top
+}
+
+// rows returns the number of text lines on the screen.
+func (p *Pager) rows() int {
+	if p.height < 2 { //nolint:mnd
+		return 1
+	}
+	return p.height - 1
+}
+
+// load reads lines until there are at least n of them, or all lines if n is
+// negative.
+func (p *Pager) load(n int) error {
+	for !p.eof && (n < 0 || len(p.lines) < n) {
+		line, err := p.src.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			p.eof = true
+			if line == "" {
+				break
+			}
+		} else if err != nil {
+			return fmt.Errorf("pager: %w", err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		p.lines = append(p.lines, strings.Split(WrapText(line, p.width), "\n")...)
+	}
+	return nil
+}
+
+// maxTop returns the index of the first line when showing the end of the
+// text.
+func (p *Pager) maxTop() int {
+	if n := len(p.lines) - p.rows(); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// scroll moves the screen by n lines, scrolling the region and only
+// drawing the lines that appeared.
+func (p *Pager) scroll(n int) error {
+	if err := p.load(p.top + n + p.rows()); err != nil {
+		return err
+	}
+	top := p.top + n
+	if top < 0 || top > p.maxTop() {
+		return nil
+	}
+	p.top = top
+
+	if n > 0 {
+		p.o.ScrollUp(n)
+		for i := p.rows() - n; i < p.rows(); i++ {
+			p.drawLine(i)
+		}
+	} else {
+		p.o.ScrollDown(-n)
+		for i := 0; i < -n; i++ {
+			p.drawLine(i)
+		}
+	}
+	p.drawStatus()
+	return nil
+}
+
+// jump shows the screen starting at line top.
+func (p *Pager) jump(top int) error {
+	if err := p.load(top + p.rows()); err != nil {
+		return err
+	}
+	if top > p.maxTop() {
+		top = p.maxTop()
+	}
+	if top < 0 {
+		top = 0
+	}
+	p.top = top
+	return p.redraw()
+}
+
+// redraw draws the entire screen.
+func (p *Pager) redraw() error {
+	if err := p.load(p.top + p.rows()); err != nil {
+		return err
+	}
+	for i := 0; i < p.rows(); i++ {
+		p.drawLine(i)
+	}
+	p.drawStatus()
+	return nil
+}
+
+// drawLine draws the line at the given row of the screen.
+func (p *Pager) drawLine(row int) {
+	var line string
+	if i := p.top + row; i < len(p.lines) {
+		line = p.lines[i]
+		if p.query != "" {
+			line = HighlightMatches(line, p.queryRe, p.match)
+		}
+	}
+	p.o.MoveCursor(row+1, 1)
+	_, _ = p.o.WriteString(CSI + EraseEntireLineSeq + line)
+}
+
+// drawStatus draws the status line.
+func (p *Pager) drawStatus() {
+	status := ":"
+	if p.eof && p.top >= p.maxTop() {
+		status = "(END)"
+	}
+	p.drawPrompt(p.o.String(status).Reverse().String())
+}
+
+func (p *Pager) drawPrompt(s string) {
+	p.o.MoveCursor(p.height, 1)
+	_, _ = p.o.WriteString(CSI + EraseEntireLineSeq + s)
+}
+
+// readQuery reads a search query on the status line. An empty query keeps
+// the previous one.
+func (p *Pager) readQuery(ir *InputReader) (string, error) {
+	var q []rune
+	for {
+		p.drawPrompt("/" + string(q))
+
+		ev, err := ir.ReadEvent()
+		if err != nil {
+			return "", err
+		}
+		k, ok := ev.(KeyEvent)
+		if !ok || k.Action == KeyRelease {
+			continue
+		}
+
+		switch {
+		case k.Type == KeyEnter:
+			if len(q) == 0 {
+				return p.query, nil
+			}
+			return string(q), nil
+		case k.Type == KeyEscape, k.Type == KeyRune && k.Mod&ModCtrl != 0 && k.Rune == 'c':
+			return p.query, nil
+		case k.Type == KeyBackspace:
+			if len(q) > 0 {
+				q = q[:len(q)-1]
+			}
+		case k.Type == KeyRune && k.Mod&ModCtrl == 0:
+			q = append(q, k.Rune)
+		}
+	}
+}
+
+// search jumps to the next line matching the query in direction dir,
+// starting after the first line on the screen.
+func (p *Pager) search(dir int) error {
+	if p.query == "" {
+		return p.redraw()
+	}
+	for i := p.top + dir; i >= 0; i += dir {
+		if err := p.load(i + 1); err != nil {
+			return err
+		}
+		if i >= len(p.lines) {
+			break
+		}
+		if strings.Contains(Strip(p.lines[i]), p.query) {
+			return p.jump(i)
+		}
+	}
+	// no match; the highlighting of the new query may still change
+	return p.redraw()
+}
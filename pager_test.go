@@ -0,0 +1,93 @@
+package termenv
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func pagerText(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		top   int
+	}{
+		{"quit", "q", 0},
+		{"eof", "", 0},
+		{"down", "jj\x1b[Bq", 3},
+		{"down and up", "jjkq", 1},
+		{"up at top", "kq", 0},
+		{"page down", " q", 3},
+		{"bottom", "Gq", 7},
+		{"bottom and top", "Ggq", 0},
+		{"past the end", "    q", 7},
+		{"search", "/line 5\rq", 4},
+		{"search next", "/line\rnnq", 3},
+		{"search previous", "/line\rnnNq", 2},
+		{"search without match", "/nothing\rq", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+			p := o.NewPager(strings.NewReader(pagerText(10)),
+				WithPagerInput(strings.NewReader(test.input)),
+				WithPagerSize(20, 4))
+
+			if err := p.Run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Top() != test.top {
+				t.Errorf("expected top %d, got %d", test.top, p.Top())
+			}
+		})
+	}
+}
+
+func TestPagerScrollRegion(t *testing.T) {
+	buf := &bytes.Buffer{}
+	o := NewOutput(buf, WithProfile(Ascii))
+	p := o.NewPager(strings.NewReader(pagerText(10)),
+		WithPagerInput(strings.NewReader("jq")),
+		WithPagerSize(20, 4))
+	if err := p.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, exp := range []string{
+		"\x1b[?1049h",
+		"\x1b[1;3r",
+		// scrolling by a line only draws the new bottom line
+		"\x1b[1S\x1b[3;1H\x1b[2Kline 4",
+		"\x1b[1;4r",
+		"\x1b[?1049l",
+	} {
+		if !strings.Contains(buf.String(), exp) {
+			t.Errorf("expected output to contain %q, got %q", exp, buf.String())
+		}
+	}
+}
+
+func TestPagerWrap(t *testing.T) {
+	o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+	p := o.NewPager(strings.NewReader("a long line that needs wrapping\nshort\n"),
+		WithPagerInput(strings.NewReader("q")),
+		WithPagerSize(10, 10))
+	if err := p.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{"a long", "line that", "needs", "wrapping", "short"}
+	if strings.Join(p.lines, "|") != strings.Join(exp, "|") {
+		t.Errorf("expected %q, got %q", exp, p.lines)
+	}
+}
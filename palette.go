@@ -0,0 +1,73 @@
+package termenv
+
+import (
+	"image/color"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Palette is a 16-color terminal palette, e.g. a terminal's customized color
+// scheme. It is used to reduce colors for the ANSI profile.
+type Palette [16]RGBColor
+
+// DefaultPalette returns the default xterm palette.
+func DefaultPalette() Palette {
+	var p Palette
+	for i := range p {
+		p[i] = RGBColor(ansiHex[i])
+	}
+	return p
+}
+
+// WithPalette returns a new OutputOption reducing colors to the given palette
+// for the ANSI profile, instead of the default xterm palette. This improves
+// the fidelity on terminals with customized colors, e.g. Solarized.
+func WithPalette(p Palette) OutputOption {
+	return func(o *Output) {
+		o.palette = &p
+	}
+}
+
+// SetPalette sets the palette colors get reduced to for the ANSI profile.
+func (o *Output) SetPalette(p Palette) {
+	o.palette = &p
+}
+
+// Palette returns the palette colors get reduced to for the ANSI profile.
+func (o *Output) Palette() Palette {
+	if o.palette == nil {
+		return DefaultPalette()
+	}
+	return *o.palette
+}
+
+// Color creates a Color from a string, converted to the output's profile.
+// Valid inputs are hex colors, as well as ANSI color codes (0-15, 16-255).
+func (o *Output) Color(s string) Color {
+	return o.profile().color(s, o.palette)
+}
+
+// Convert transforms a given Color to a Color supported by the output's
+// profile. s is ignored; RGB colors are converted using their own value.
+//
+// Deprecated: please use ConvertColor instead.
+func (o *Output) Convert(c Color, s string) Color { //nolint:revive
+	return o.ConvertColor(c)
+}
+
+// ConvertColor transforms a given Color to a Color supported by the output's
+// profile. For an AdaptiveColor, the variant matching the output's
+// background is used.
+func (o *Output) ConvertColor(c Color) Color {
+	if ac, ok := c.(AdaptiveColor); ok {
+		c = ac.Resolve(o.HasDarkBackground())
+	}
+	return o.profile().convert(c, o.palette)
+}
+
+// FromColor creates a Color from a color.Color, converted to the output's
+// profile.
+func (o *Output) FromColor(c color.Color) Color {
+	col, _ := colorful.MakeColor(c)
+	return o.Color(col.Hex())
+}
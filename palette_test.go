@@ -0,0 +1,29 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestPalette(t *testing.T) {
+	pal := DefaultPalette()
+	if pal[1] != RGBColor("#800000") {
+		t.Errorf("expected default red to be #800000, got %s", pal[1])
+	}
+
+	// a palette with an orange instead of magenta
+	pal[5] = "#ff8700"
+	o := NewOutput(nil, WithEnvironment(mapEnv{}), WithProfile(ANSI), WithPalette(pal))
+
+	if c := o.Color("208"); c != ANSIColor(5) {
+		t.Errorf("expected %v, got %v", ANSIColor(5), c)
+	}
+	if c := o.Color("#ff8800"); c != ANSIColor(5) {
+		t.Errorf("expected %v, got %v", ANSIColor(5), c)
+	}
+	if c := ANSI.Color("208"); c == ANSIColor(5) {
+		t.Errorf("expected the default palette to ignore the custom one, got %v", c)
+	}
+	if o.Palette() != pal {
+		t.Errorf("expected palette %v, got %v", pal, o.Palette())
+	}
+}
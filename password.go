@@ -0,0 +1,32 @@
+package termenv
+
+// EchoMode controls how ReadPassword echoes typed characters.
+type EchoMode int
+
+// Echo modes.
+const (
+	// EchoNone doesn't echo anything.
+	EchoNone EchoMode = iota
+	// EchoAsterisk echoes an asterisk for every typed character.
+	EchoAsterisk
+)
+
+// ReadPassword reads a password without echoing it, displaying the given
+// prompt. The terminal is put into raw mode for the duration of the call and
+// restored even if the program receives a termination signal, in which case
+// a SignalError gets returned. Passwords are never recorded in a History.
+func ReadPassword(prompt Style, echo EchoMode, opts ...ReadLineOption) (string, error) {
+	return output.ReadPassword(prompt, echo, opts...)
+}
+
+// ReadPassword reads a password without echoing it, displaying the given
+// prompt. The terminal is put into raw mode for the duration of the call and
+// restored even if the program receives a termination signal, in which case
+// a SignalError gets returned. Passwords are never recorded in a History.
+func (o *Output) ReadPassword(prompt Style, echo EchoMode, opts ...ReadLineOption) (string, error) {
+	var mask rune
+	if echo == EchoAsterisk {
+		mask = '*'
+	}
+	return o.ReadLine(prompt, append(opts, WithMask(mask))...)
+}
@@ -0,0 +1,91 @@
+package termenv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadPassword(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   EchoMode
+		echoed string
+	}{
+		{"none", EchoNone, ""},
+		{"asterisk", EchoAsterisk, "\x1b[1@*\x1b[1@*\x1b[1@*\x1b[1D\x1b[1P"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			o := NewOutput(buf, WithProfile(Ascii))
+			h := NewHistory(0)
+			pw, err := o.ReadPassword(String("Password: "), test.mode,
+				WithInput(strings.NewReader("abc\x7fd\r")), WithHistory(h))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pw != "abd" {
+				t.Errorf("expected abd, got %q", pw)
+			}
+			if len(h.Entries()) != 0 {
+				t.Errorf("password was recorded in history: %v", h.Entries())
+			}
+
+			exp := "\x1b[1GPassword: \x1b[0K\x1b[11G" + test.echoed
+			if test.mode == EchoAsterisk {
+				exp += "\x1b[1@*"
+			}
+			exp += "\r\n"
+			if buf.String() != exp {
+				t.Errorf("expected %q, got %q", exp, buf.String())
+			}
+		})
+	}
+}
+
+func TestReadPasswordSignal(t *testing.T) {
+	in := newSignalReader(strings.NewReader("abc\r"))
+	in.interrupt(os.Interrupt)
+
+	o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+	_, err := o.ReadPassword(String("Password: "), EchoNone, WithInput(in))
+
+	var serr *SignalError
+	if !errors.As(err, &serr) || serr.Signal != os.Interrupt {
+		t.Fatalf("expected a SignalError for %v, got %v", os.Interrupt, err)
+	}
+	if !errors.Is(err, ErrInterrupted) {
+		t.Errorf("expected the error to match ErrInterrupted")
+	}
+}
+
+func TestReadPasswordSignalBlocked(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	in := newSignalReader(r)
+	time.AfterFunc(10*time.Millisecond, func() {
+		in.interrupt(os.Interrupt)
+	})
+
+	errs := make(chan error, 1)
+	go func() {
+		o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+		_, err := o.ReadPassword(String("Password: "), EchoNone, WithInput(in))
+		errs <- err
+	}()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ErrInterrupted) {
+			t.Errorf("expected ErrInterrupted, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked read wasn't interrupted")
+	}
+}
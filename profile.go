@@ -45,47 +45,75 @@ func (p Profile) String(s ...string) Style {
 	}
 }
 
+// StringFor returns a new Style for rendering to out. The Style uses the less
+// capable profile of p and out, so nothing but plain text gets rendered when
+// out is not a terminal, e.g. when it is piped to a file.
+func (p Profile) StringFor(out *Output, s ...string) Style {
+	if out == nil {
+		return p.String(s...)
+	}
+	if op := out.profile(); op > p {
+		p = op
+	}
+	t := p.String(s...)
+	t.out = out
+	return t
+}
+
 // Convert transforms a given Color to a Color supported within the Profile.
-func (p Profile) Convert(c Color, s string) Color {
+// s is ignored; RGB colors are converted using their own value.
+//
+// Deprecated: please use ConvertColor instead.
+func (p Profile) Convert(c Color, s string) Color { //nolint:revive
+	return p.convert(c, nil)
+}
+
+// ConvertColor transforms a given Color to a Color supported within the
+// Profile.
+func (p Profile) ConvertColor(c Color) Color {
+	return p.convert(c, nil)
+}
+
+// convert transforms c to a Color supported within the Profile, reducing
+// colors to the given 16-color palette for the ANSI profile. A CustomColor
+// gets converted like its RGB value.
+func (p Profile) convert(c Color, pal *Palette) Color {
 	if p == Ascii {
 		return NoColor{}
 	}
 
-	switch v := c.(type) {
+	switch v := resolveColor(c).(type) {
+	case AdaptiveColor:
+		rc := v.Resolve(true)
+		return p.convert(rc, pal)
+
 	case ANSIColor:
 		return v
 
 	case ANSI256Color:
 		if p == ANSI {
-			return ansi256ToANSIColor(v)
+			return ansi256ToPaletteColor(v, pal)
 		}
 		return v
 
 	case RGBColor:
-		var (
-			h   colorful.Color
-			err error
-		)
+		var h colorful.Color
 		cache := GetSRGBCache()
 		if sRGB, present := cache.Get(v); present {
-			h = sRGB.(colorful.Color)
-			// if sRGBColor, ok := sRGB.(colorful.Color); ok {
-			// 	h = sRGBColor
-			// } else {
-			// 	panic("srgbCache value type assertion failed")
-			// }
+			h = sRGB
 		} else {
-			h, err = colorful.Hex(s)
+			r, g, b, err := v.Values()
 			if err != nil {
 				return nil
 			}
+			h = colorful.Color{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255} //nolint:mnd
 			cache.Put(v, h)
 		}
 
 		if p != TrueColor {
 			ac := hexToANSI256Color(h)
 			if p == ANSI {
-				return ansi256ToANSIColor(ac)
+				return ansi256ToPaletteColor(ac, pal)
 			}
 			return ac
 		}
@@ -98,12 +126,16 @@ func (p Profile) Convert(c Color, s string) Color {
 // Color creates a Color from a string. Valid inputs are hex colors, as well as
 // ANSI color codes (0-15, 16-255).
 func (p Profile) Color(s string) Color {
+	return p.color(s, nil)
+}
+
+func (p Profile) color(s string, pal *Palette) Color {
 	if len(s) == 0 {
 		return nil
 	}
 
 	if strings.HasPrefix(s, "#") {
-		return p.Convert(RGBColor(s), s)
+		return p.convert(RGBColor(s), pal)
 	}
 
 	i, err := strconv.Atoi(s)
@@ -118,7 +150,7 @@ func (p Profile) Color(s string) Color {
 		c = ANSI256Color(i)
 	}
 
-	return p.Convert(c, "")
+	return p.convert(c, pal)
 }
 
 // FromColor creates a Color from a color.Color.
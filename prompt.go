@@ -0,0 +1,269 @@
+package termenv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNoOptions gets returned by Select when there are no options to pick
+// from.
+var ErrNoOptions = errors.New("no options")
+
+// PromptTheme defines the styles used by the interactive prompt helpers.
+type PromptTheme struct {
+	Question Style
+	Cursor   Style
+	Selected Style
+	Option   Style
+	Hint     Style
+}
+
+// PromptTheme returns the default prompt theme for the output's profile.
+func (o *Output) PromptTheme() PromptTheme {
+	return PromptTheme{
+		Question: o.String().Bold(),
+		Cursor:   o.String().Foreground(o.Color("6")),
+		Selected: o.String().Foreground(o.Color("6")).Bold(),
+		Option:   o.String(),
+		Hint:     o.String().Faint(),
+	}
+}
+
+// PromptOption sets an option on the interactive prompt helpers.
+type PromptOption = func(*prompter)
+
+// WithPromptInput returns a new PromptOption reading from r instead of
+// os.Stdin.
+func WithPromptInput(r io.Reader) PromptOption {
+	return func(p *prompter) {
+		p.in = r
+	}
+}
+
+// WithPromptTheme returns a new PromptOption using the given theme.
+func WithPromptTheme(t PromptTheme) PromptOption {
+	return func(p *prompter) {
+		p.theme = t
+	}
+}
+
+// WithDefault returns a new PromptOption setting the answer Confirm returns
+// when enter is pressed.
+func WithDefault(v bool) PromptOption {
+	return func(p *prompter) {
+		p.def = v
+	}
+}
+
+// prompter holds the state of a single prompt.
+type prompter struct {
+	o     *Output
+	in    io.Reader
+	theme PromptTheme
+	def   bool
+}
+
+func (o *Output) newPrompter(opts []PromptOption) *prompter {
+	p := &prompter{
+		o:     o,
+		in:    os.Stdin,
+		theme: o.PromptTheme(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// events puts the input into raw mode and returns a reader for it.
+func (p *prompter) events() (*InputReader, func() error, error) {
+	in, restore, err := rawInput(p.in)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewInputReader(in), restore, nil
+}
+
+// Confirm asks a yes/no question and returns the answer.
+func Confirm(question string, opts ...PromptOption) (bool, error) {
+	return output.Confirm(question, opts...)
+}
+
+// Confirm asks a yes/no question and returns the answer.
+func (o *Output) Confirm(question string, opts ...PromptOption) (bool, error) {
+	p := o.newPrompter(opts)
+
+	hint := "[y/N]"
+	if p.def {
+		hint = "[Y/n]"
+	}
+	_, _ = o.WriteString(p.theme.Question.Styled(question) + " " + p.theme.Hint.Styled(hint) + " ")
+
+	ir, restore, err := p.events()
+	if err != nil {
+		return false, err
+	}
+	defer restore() //nolint:errcheck
+
+	for {
+		ev, err := ir.ReadEvent()
+		if err != nil {
+			return false, err
+		}
+		k, ok := ev.(KeyEvent)
+		if !ok || k.Action == KeyRelease {
+			continue
+		}
+
+		var answer bool
+		switch {
+		case k.Type == KeyRune && k.Mod&ModCtrl != 0 && k.Rune == 'c':
+			_, _ = o.WriteString("\r\n")
+			return false, ErrInterrupted
+		case k.Type == KeyEnter:
+			answer = p.def
+		case k.Type == KeyRune && (k.Rune == 'y' || k.Rune == 'Y'):
+			answer = true
+		case k.Type == KeyRune && (k.Rune == 'n' || k.Rune == 'N'):
+			answer = false
+		default:
+			continue
+		}
+
+		reply := "no"
+		if answer {
+			reply = "yes"
+		}
+		_, _ = o.WriteString(p.theme.Selected.Styled(reply) + "\r\n")
+		return answer, nil
+	}
+}
+
+// Select lets the user pick one of the given options and returns its index.
+func Select(options []string, opts ...PromptOption) (int, error) {
+	return output.Select(options, opts...)
+}
+
+// Select lets the user pick one of the given options and returns its index.
+// It returns ErrNoOptions if options is empty.
+func (o *Output) Select(options []string, opts ...PromptOption) (int, error) {
+	if len(options) == 0 {
+		return -1, ErrNoOptions
+	}
+	p := o.newPrompter(opts)
+	sel, err := p.list(options, false)
+	if err != nil {
+		return -1, err
+	}
+	return sel[0], nil
+}
+
+// MultiSelect lets the user pick any number of the given options and
+// returns their indices in ascending order.
+func MultiSelect(options []string, opts ...PromptOption) ([]int, error) {
+	return output.MultiSelect(options, opts...)
+}
+
+// MultiSelect lets the user pick any number of the given options and
+// returns their indices in ascending order.
+func (o *Output) MultiSelect(options []string, opts ...PromptOption) ([]int, error) {
+	p := o.newPrompter(opts)
+	return p.list(options, true)
+}
+
+// list runs a (multi-)select prompt over options.
+func (p *prompter) list(options []string, multi bool) ([]int, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	ir, restore, err := p.events()
+	if err != nil {
+		return nil, err
+	}
+	defer restore() //nolint:errcheck
+
+	p.o.HideCursor()
+	defer p.o.ShowCursor()
+
+	cursor := 0
+	checked := make([]bool, len(options))
+	p.renderList(options, cursor, checked, multi, true)
+
+	for {
+		ev, err := ir.ReadEvent()
+		if err != nil {
+			return nil, err
+		}
+		k, ok := ev.(KeyEvent)
+		if !ok || k.Action == KeyRelease {
+			continue
+		}
+
+		switch {
+		case k.Type == KeyRune && k.Mod&ModCtrl != 0 && k.Rune == 'c':
+			return nil, ErrInterrupted
+		case k.Type == KeyUp, k.Type == KeyRune && k.Rune == 'k':
+			if cursor > 0 {
+				cursor--
+			}
+		case k.Type == KeyDown, k.Type == KeyRune && k.Rune == 'j':
+			if cursor < len(options)-1 {
+				cursor++
+			}
+		case k.Type == KeyRune && k.Rune == ' ' && multi:
+			checked[cursor] = !checked[cursor]
+		case k.Type == KeyEnter:
+			if !multi {
+				return []int{cursor}, nil
+			}
+			var sel []int
+			for i, c := range checked {
+				if c {
+					sel = append(sel, i)
+				}
+			}
+			return sel, nil
+		default:
+			continue
+		}
+
+		p.renderList(options, cursor, checked, multi, false)
+	}
+}
+
+// renderList draws the option list, redrawing it in place unless first is
+// set.
+func (p *prompter) renderList(options []string, cursor int, checked []bool, multi, first bool) {
+	var b strings.Builder
+	if !first {
+		fmt.Fprintf(&b, CSI+CursorPreviousLineSeq, len(options))
+	}
+
+	for i, opt := range options {
+		b.WriteString(CSI + EraseEntireLineSeq)
+		if i == cursor {
+			b.WriteString(p.theme.Cursor.Styled("> "))
+		} else {
+			b.WriteString("  ")
+		}
+		if multi {
+			if checked[i] {
+				b.WriteString("[x] ")
+			} else {
+				b.WriteString("[ ] ")
+			}
+		}
+		if i == cursor {
+			b.WriteString(p.theme.Selected.Styled(opt))
+		} else {
+			b.WriteString(p.theme.Option.Styled(opt))
+		}
+		b.WriteString("\r\n")
+	}
+
+	_, _ = p.o.WriteString(b.String())
+}
@@ -0,0 +1,73 @@
+package termenv
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input    string
+		def      bool
+		expected bool
+	}{
+		{"y", false, true},
+		{"N", true, false},
+		{"x\r", false, false},
+		{"\r", true, true},
+	}
+
+	for _, test := range tests {
+		o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+		v, err := o.Confirm("Continue?", WithPromptInput(strings.NewReader(test.input)), WithDefault(test.def))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v != test.expected {
+			t.Errorf("input %q: expected %t, got %t", test.input, test.expected, v)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+	options := []string{"red", "green", "blue"}
+
+	i, err := o.Select(options, WithPromptInput(strings.NewReader("\x1b[B\x1b[Bj\x1b[A\r")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if i != 1 {
+		t.Errorf("expected 1, got %d", i)
+	}
+
+	_, err = o.Select(options, WithPromptInput(strings.NewReader("\x03")))
+	if !errors.Is(err, ErrInterrupted) {
+		t.Errorf("expected ErrInterrupted, got %v", err)
+	}
+
+	i, err = o.Select(nil, WithPromptInput(strings.NewReader("\r")))
+	if !errors.Is(err, ErrNoOptions) || i != -1 {
+		t.Errorf("expected -1 and ErrNoOptions, got %d, %v", i, err)
+	}
+}
+
+func TestMultiSelect(t *testing.T) {
+	buf := &bytes.Buffer{}
+	o := NewOutput(buf, WithProfile(Ascii))
+	options := []string{"red", "green", "blue"}
+
+	sel, err := o.MultiSelect(options, WithPromptInput(strings.NewReader(" jj  j \r")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sel, []int{0, 2}) {
+		t.Errorf("expected [0 2], got %v", sel)
+	}
+	if !strings.Contains(buf.String(), "[x] red") {
+		t.Errorf("expected checked option to be rendered, got %q", buf.String())
+	}
+}
@@ -0,0 +1,118 @@
+package termenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sequence definitions.
+const (
+	// DECSCA
+	ProtectedSeq   = `1"q`
+	UnprotectedSeq = `0"q`
+
+	// DECSED and DECSEL
+	SelectiveEraseDisplaySeq = "?%dJ"
+	SelectiveEraseLineSeq    = "?%dK"
+)
+
+// protectionTerms lists the TERM values of terminals implementing DECSCA and
+// selective erase.
+var protectionTerms = map[string]bool{
+	"vt220":          true,
+	"vt320":          true,
+	"vt420":          true,
+	"vt520":          true,
+	"xterm":          true,
+	"xterm-16color":  true,
+	"xterm-256color": true,
+	"xterm-color":    true,
+	"xterm-direct":   true,
+	"xterm-ghostty":  true,
+}
+
+// SupportsProtection returns whether the terminal supports protecting cells
+// from selective erase.
+func SupportsProtection() bool {
+	return output.SupportsProtection()
+}
+
+// SupportsProtection returns whether the terminal supports protecting cells
+// from selective erase. Many terminals claim to be an xterm without
+// implementing it, so terminals announcing themselves otherwise, e.g. with
+// TERM_PROGRAM or VTE_VERSION, are ruled out.
+func (o *Output) SupportsProtection() bool {
+	term := o.environ.Getenv("TERM")
+	if i := strings.IndexByte(term, '-'); i >= 0 && strings.HasPrefix(term, "vt") {
+		// e.g. vt220-am
+		term = term[:i]
+	}
+	if !protectionTerms[term] {
+		return false
+	}
+
+	switch o.environ.Getenv("TERM_PROGRAM") {
+	case "", "ghostty":
+	default:
+		return false
+	}
+	return o.environ.Getenv("VTE_VERSION") == "" &&
+		o.environ.Getenv("KONSOLE_VERSION") == "" &&
+		o.environ.Getenv("WT_SESSION") == ""
+}
+
+// SetProtected protects the cells written from now on from selective erase,
+// e.g. to keep a status area intact. It does nothing if the terminal doesn't
+// support protection.
+func (o *Output) SetProtected() {
+	if o.SupportsProtection() {
+		fmt.Fprint(o.w, CSI+ProtectedSeq) //nolint:errcheck
+	}
+}
+
+// Unprotected stops protecting the cells written from now on.
+func (o *Output) Unprotected() {
+	if o.SupportsProtection() {
+		fmt.Fprint(o.w, CSI+UnprotectedSeq) //nolint:errcheck
+	}
+}
+
+// SelectiveEraseDisplay erases the unprotected cells of the display, using
+// the same modes as EraseDisplaySeq: 0 erases below the cursor, 1 above it,
+// and 2 the entire display. It does nothing if the terminal doesn't support
+// protection, as a regular erase would clear protected cells as well.
+func (o *Output) SelectiveEraseDisplay(n int) {
+	if o.SupportsProtection() {
+		fmt.Fprintf(o.w, CSI+SelectiveEraseDisplaySeq, n) //nolint:errcheck
+	}
+}
+
+// SelectiveEraseLine erases the unprotected cells of the current line, using
+// the same modes as EraseLineSeq: 0 erases right of the cursor, 1 left of it,
+// and 2 the entire line. It does nothing if the terminal doesn't support
+// protection.
+func (o *Output) SelectiveEraseLine(n int) {
+	if o.SupportsProtection() {
+		fmt.Fprintf(o.w, CSI+SelectiveEraseLineSeq, n) //nolint:errcheck
+	}
+}
+
+// SetProtected protects the cells written from now on from selective erase.
+func SetProtected() {
+	output.SetProtected()
+}
+
+// Unprotected stops protecting the cells written from now on.
+func Unprotected() {
+	output.Unprotected()
+}
+
+// SelectiveEraseDisplay erases the unprotected cells of the display.
+func SelectiveEraseDisplay(n int) {
+	output.SelectiveEraseDisplay(n)
+}
+
+// SelectiveEraseLine erases the unprotected cells of the current line.
+func SelectiveEraseLine(n int) {
+	output.SelectiveEraseLine(n)
+}
@@ -0,0 +1,49 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSupportsProtection(t *testing.T) {
+	for _, test := range []struct {
+		environ  mapEnv
+		expected bool
+	}{
+		{mapEnv{"TERM": "xterm-256color"}, true},
+		{mapEnv{"TERM": "vt220-am"}, true},
+		{mapEnv{"TERM": "vt100"}, false},
+		{mapEnv{"TERM": "xterm-kitty"}, false},
+		{mapEnv{"TERM": "xterm-256color", "VTE_VERSION": "6003"}, false},
+		{mapEnv{"TERM": "xterm-256color", "TERM_PROGRAM": "iTerm.app"}, false},
+		{mapEnv{"TERM": "xterm-ghostty", "TERM_PROGRAM": "ghostty"}, true},
+	} {
+		o := NewOutput(&bytes.Buffer{}, WithEnvironment(test.environ))
+		if got := o.SupportsProtection(); got != test.expected {
+			t.Errorf("%v: expected %t, got %t", test.environ, test.expected, got)
+		}
+	}
+}
+
+func TestSelectiveErase(t *testing.T) {
+	f := func(o *Output) {
+		o.SetProtected()
+		o.WriteString("status")
+		o.Unprotected()
+		o.SelectiveEraseDisplay(2)
+		o.SelectiveEraseLine(0)
+	}
+
+	var buf bytes.Buffer
+	f(NewOutput(&buf, WithEnvironment(mapEnv{"TERM": "xterm"})))
+	exp := "\x1b[1\"qstatus\x1b[0\"q\x1b[?2J\x1b[?0K"
+	if got := buf.String(); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	buf.Reset()
+	f(NewOutput(&buf, WithEnvironment(mapEnv{"TERM": "xterm-kitty"})))
+	if got := buf.String(); got != "status" {
+		t.Errorf("expected %q, got %q", "status", got)
+	}
+}
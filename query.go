@@ -0,0 +1,79 @@
+package termenv
+
+// OSC numbers of the default color queries.
+const (
+	oscForegroundColor = 10
+	oscBackgroundColor = 11
+	oscCursorColor     = 12
+)
+
+// DefaultColors are the default colors of a terminal.
+type DefaultColors struct {
+	Foreground Color
+	Background Color
+	Cursor     Color
+}
+
+// QueryDefaultColors queries the terminal's default fore- and background
+// colors and its cursor color. All queries get sent in a single write, so
+// they only cost a single round trip, which matters on high-latency links
+// like SSH. Colors the terminal doesn't report are NoColor. If the output
+// caches its colors, the results get cached.
+func (o *Output) QueryDefaultColors() (DefaultColors, error) {
+	c := DefaultColors{
+		Foreground: NoColor{},
+		Background: NoColor{},
+		Cursor:     NoColor{},
+	}
+	if !o.isTTY() {
+		return c, ErrStatusReport
+	}
+
+	res, err := o.termStatusReports(oscForegroundColor, oscBackgroundColor, oscCursorColor)
+	if err != nil {
+		return c, err
+	}
+
+	for seq, dst := range map[int]*Color{
+		oscForegroundColor: &c.Foreground,
+		oscBackgroundColor: &c.Background,
+		oscCursorColor:     &c.Cursor,
+	} {
+		if r, ok := res[seq]; ok {
+			if col, err := xTermColor(r); err == nil {
+				*dst = col
+			}
+		}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.cache {
+		o.fgSync.Do(func() {
+			o.fgColor = c.Foreground
+		})
+		o.bgSync.Do(func() {
+			o.bgColor = c.Background
+		})
+	}
+	return c, nil
+}
+
+// defaultColors queries the terminal's default fore- and background colors
+// in a single round trip. Colors the terminal doesn't report are taken from
+// the environment, see envColor.
+func (o *Output) defaultColors() (fg, bg Color) {
+	res, _ := o.termStatusReports(oscForegroundColor, oscBackgroundColor)
+	return o.reportedColor(res, oscForegroundColor, false), o.reportedColor(res, oscBackgroundColor, true)
+}
+
+// reportedColor returns the color reported in response to the OSC query
+// seq, or the fore- or background color advertised by the environment.
+func (o *Output) reportedColor(res map[int]string, seq int, bg bool) Color {
+	if r, ok := res[seq]; ok {
+		if c, err := xTermColor(r); err == nil {
+			return c
+		}
+	}
+	return o.envColor(bg)
+}
@@ -0,0 +1,76 @@
+package termenv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestQueryDefaultColorsNoTTY(t *testing.T) {
+	o := tempOutput(t)
+	c, err := o.QueryDefaultColors()
+	if !errors.Is(err, ErrStatusReport) {
+		t.Errorf("expected ErrStatusReport, got %v", err)
+	}
+	if c.Foreground != (NoColor{}) || c.Background != (NoColor{}) || c.Cursor != (NoColor{}) {
+		t.Errorf("expected no colors, got %+v", c)
+	}
+	verify(t, o, "")
+}
+
+// fakeTTY is a terminal answering queries with a fixed response.
+type fakeTTY struct {
+	bytes.Buffer
+	res io.Reader
+}
+
+func (f *fakeTTY) Read(p []byte) (int, error) {
+	return f.res.Read(p)
+}
+
+func (f *fakeTTY) Fd() uintptr {
+	return 0
+}
+
+func TestDefaultColorsSingleRoundTrip(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows", "js", "plan9", "aix":
+		t.Skip("terminal queries are not supported on", runtime.GOOS)
+	}
+
+	// the terminal doesn't answer the foreground query
+	tty := &fakeTTY{res: strings.NewReader("\x1b]11;rgb:1111/1111/1111\x1b\\\x1b[?62;22c")}
+	o := NewOutput(tty, WithEnvironment(mapEnv{"TERM": "xterm"}), WithUnsafe(), WithColorCache(true))
+
+	if c := o.BackgroundColor(); c != RGBColor("#111111") {
+		t.Errorf("expected background #111111, got %v", c)
+	}
+	if c := o.ForegroundColor(); c != ANSIColor(7) {
+		t.Errorf("expected the default foreground, got %v", c)
+	}
+	if got, exp := tty.String(), "\x1b]10;?\x1b\\\x1b]11;?\x1b\\\x1b[c"; got != exp {
+		t.Errorf("expected a single query %q, got %q", exp, got)
+	}
+}
+
+func TestSnapshotSingleRoundTrip(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows", "js", "plan9", "aix":
+		t.Skip("terminal queries are not supported on", runtime.GOOS)
+	}
+
+	// without caching, every query is sent to the terminal
+	tty := &fakeTTY{res: strings.NewReader("\x1b]10;rgb:eeee/eeee/eeee\x1b\\\x1b]11;rgb:1111/1111/1111\x1b\\\x1b[?62;22c")}
+	o := NewOutput(tty, WithEnvironment(mapEnv{"TERM": "xterm"}), WithUnsafe())
+
+	snap := o.Snapshot()
+	if snap.ForegroundColor != RGBColor("#eeeeee") || snap.BackgroundColor != RGBColor("#111111") || !snap.DarkBackground {
+		t.Errorf("unexpected snapshot: %+v", snap)
+	}
+	if got, exp := tty.String(), "\x1b]10;?\x1b\\\x1b]11;?\x1b\\\x1b[c"; got != exp {
+		t.Errorf("expected a single query %q, got %q", exp, got)
+	}
+}
@@ -0,0 +1,176 @@
+package termenv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+)
+
+// SignalError gets returned by the interactive functions, e.g. ReadLine,
+// when the program receives a termination signal while the terminal is in
+// raw mode. The terminal has been restored by then. The signal isn't raised
+// again, as the application may handle it itself, so it's up to the caller
+// to exit.
+type SignalError struct {
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInterrupted, e.Signal)
+}
+
+// Unwrap returns ErrInterrupted.
+func (e *SignalError) Unwrap() error {
+	return ErrInterrupted
+}
+
+// rawInput puts r into raw mode if it refers to a terminal, and returns the
+// reader to read input from and a function restoring its previous state.
+//
+// While the terminal is in raw mode, termination signals are intercepted: the
+// terminal gets restored, so a killed program never leaves the user with a
+// broken terminal, and the returned reader fails with a SignalError, also
+// unblocking a pending read.
+func rawInput(r io.Reader) (io.Reader, func() error, error) {
+	f, ok := r.(*os.File)
+	if !ok || !isatty.IsTerminal(f.Fd()) {
+		return r, func() error { return nil }, nil
+	}
+
+	fd := int(f.Fd()) //nolint:gosec
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", ErrRawMode, err)
+	}
+	restore := func() error {
+		return term.Restore(fd, state) //nolint:wrapcheck
+	}
+
+	sigs := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigs, interruptSignals...)
+
+	var (
+		once sync.Once
+		rerr error
+	)
+	cleanup := func() error {
+		once.Do(func() {
+			signal.Stop(sigs)
+			close(done)
+			rerr = restore()
+		})
+		return rerr
+	}
+
+	sr := newSignalReader(r)
+	go func() {
+		select {
+		case sig := <-sigs:
+			_ = cleanup()
+			sr.interrupt(sig)
+		case <-done:
+		}
+	}()
+
+	return sr, cleanup, nil
+}
+
+// signalReader reads from r until a termination signal got received. A read
+// blocked at that time returns immediately; its input, once it arrives, is
+// discarded.
+type signalReader struct {
+	r    io.Reader
+	intr chan struct{}
+	once sync.Once
+	err  error // set before intr gets closed
+}
+
+type readResult struct {
+	n   int
+	err error
+}
+
+func newSignalReader(r io.Reader) *signalReader {
+	return &signalReader{r: r, intr: make(chan struct{})}
+}
+
+func (s *signalReader) Read(p []byte) (int, error) {
+	select {
+	case <-s.intr:
+		return 0, s.err
+	default:
+	}
+
+	// read into a separate buffer, as an interrupted read may still
+	// complete after Read returned
+	buf := make([]byte, len(p))
+	res := make(chan readResult, 1)
+	go func() {
+		n, err := s.r.Read(buf)
+		res <- readResult{n, err}
+	}()
+
+	select {
+	case r := <-res:
+		return copy(p, buf[:r.n]), r.err
+	case <-s.intr:
+		return 0, s.err
+	}
+}
+
+func (s *signalReader) interrupt(sig os.Signal) {
+	s.once.Do(func() {
+		s.err = &SignalError{Signal: sig}
+		close(s.intr)
+	})
+}
+
+// fd returns the file descriptor of the output's writer.
+func (o *Output) fd() (int, error) {
+	f, ok := o.Writer().(interface{ Fd() uintptr })
+	if !ok {
+		return 0, ErrRawMode
+	}
+	return int(f.Fd()), nil //nolint:gosec
+}
+
+// MakeRaw puts the terminal connected to the output into raw mode and
+// returns its previous state.
+//
+// The state is a golang.org/x/term State, and termenv itself manages raw mode
+// through golang.org/x/term, so applications mixing both libraries can
+// restore the state with either Output.Restore or term.Restore.
+func (o *Output) MakeRaw() (*term.State, error) {
+	fd, err := o.fd()
+	if err != nil {
+		return nil, err
+	}
+	return term.MakeRaw(fd) //nolint:wrapcheck
+}
+
+// State returns the current state of the terminal connected to the output,
+// which can later be restored with Output.Restore or term.Restore.
+func (o *Output) State() (*term.State, error) {
+	fd, err := o.fd()
+	if err != nil {
+		return nil, err
+	}
+	return term.GetState(fd) //nolint:wrapcheck
+}
+
+// Restore restores the terminal connected to the output to a state
+// previously returned by Output.MakeRaw, Output.State, term.MakeRaw, or
+// term.GetState.
+func (o *Output) Restore(state *term.State) error {
+	fd, err := o.fd()
+	if err != nil {
+		return err
+	}
+	return term.Restore(fd, state) //nolint:wrapcheck
+}
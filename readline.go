@@ -0,0 +1,337 @@
+package termenv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// ErrInterrupted gets returned when the user aborts input with ctrl+c.
+var ErrInterrupted = errors.New("interrupted")
+
+// CompletionFunc returns the completion candidates for the given line.
+type CompletionFunc func(line string) []string
+
+// History is a list of previously entered lines, shared between ReadLine
+// calls.
+type History struct {
+	entries []string
+	max     int
+}
+
+// NewHistory returns a new History keeping at most max entries. A max of 0
+// keeps all entries.
+func NewHistory(max int) *History {
+	return &History{max: max}
+}
+
+// Add appends a line to the history. Empty lines and lines equal to the
+// previous entry are ignored.
+func (h *History) Add(line string) {
+	if line == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return
+	}
+	h.entries = append(h.entries, line)
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+}
+
+// Entries returns the history, oldest entry first.
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// ReadLineOption sets an option on ReadLine.
+type ReadLineOption = func(*lineEditor)
+
+// WithInput returns a new ReadLineOption reading from r instead of os.Stdin.
+func WithInput(r io.Reader) ReadLineOption {
+	return func(e *lineEditor) {
+		e.in = r
+	}
+}
+
+// WithHistory returns a new ReadLineOption browsing and recording lines in h.
+func WithHistory(h *History) ReadLineOption {
+	return func(e *lineEditor) {
+		e.history = h
+	}
+}
+
+// WithMask returns a new ReadLineOption echoing every character as r.
+func WithMask(r rune) ReadLineOption {
+	return func(e *lineEditor) {
+		e.masked = true
+		e.mask = r
+	}
+}
+
+// WithCompletion returns a new ReadLineOption calling f when tab is pressed.
+// A single candidate replaces the line, multiple candidates complete their
+// common prefix.
+func WithCompletion(f CompletionFunc) ReadLineOption {
+	return func(e *lineEditor) {
+		e.complete = f
+	}
+}
+
+// lineEditor holds the state of a single ReadLine call.
+type lineEditor struct {
+	o        *Output
+	in       io.Reader
+	prompt   Style
+	history  *History
+	masked   bool
+	mask     rune
+	complete CompletionFunc
+
+	buf     []rune
+	pos     int
+	histIdx int
+	saved   []rune
+}
+
+// ReadLine reads a line of input with basic line editing, displaying the
+// given prompt. The terminal is put into raw mode for the duration of the
+// call. It returns ErrInterrupted on ctrl+c and io.EOF on ctrl+d.
+func ReadLine(prompt Style, opts ...ReadLineOption) (string, error) {
+	return output.ReadLine(prompt, opts...)
+}
+
+// ReadLine reads a line of input with basic line editing, displaying the
+// given prompt. The terminal is put into raw mode for the duration of the
+// call. It returns ErrInterrupted on ctrl+c and io.EOF on ctrl+d.
+func (o *Output) ReadLine(prompt Style, opts ...ReadLineOption) (string, error) {
+	e := &lineEditor{
+		o:      o,
+		in:     os.Stdin,
+		prompt: prompt,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.history != nil {
+		e.histIdx = len(e.history.entries)
+	}
+
+	in, restore, err := rawInput(e.in)
+	if err != nil {
+		return "", err
+	}
+	e.in = in
+	defer restore() //nolint:errcheck
+
+	line, err := e.run()
+	_, _ = o.WriteString("\r\n")
+	if err != nil {
+		return "", err
+	}
+	if e.history != nil && !e.masked {
+		e.history.Add(line)
+	}
+	return line, nil
+}
+
+func (e *lineEditor) run() (string, error) {
+	e.redraw()
+
+	ir := NewInputReader(e.in)
+	for {
+		ev, err := ir.ReadEvent()
+		if err != nil {
+			return "", err
+		}
+
+		switch ev := ev.(type) {
+		case PasteEvent:
+			e.insert([]rune(strings.ReplaceAll(ev.Text, "\n", " ")))
+
+		case KeyEvent:
+			if ev.Action == KeyRelease {
+				continue
+			}
+			if ev.Type == KeyRune && ev.Mod&ModCtrl != 0 {
+				switch ev.Rune {
+				case 'c':
+					return "", ErrInterrupted
+				case 'd':
+					if len(e.buf) == 0 {
+						return "", io.EOF
+					}
+					e.deleteRight()
+				case 'a':
+					e.moveTo(0)
+				case 'e':
+					e.moveTo(len(e.buf))
+				case 'b':
+					e.moveTo(e.pos - 1)
+				case 'f':
+					e.moveTo(e.pos + 1)
+				case 'k':
+					e.buf = e.buf[:e.pos]
+					e.o.KillToEnd()
+				case 'u':
+					e.buf = append([]rune{}, e.buf[e.pos:]...)
+					e.pos = 0
+					e.redraw()
+				case 'w':
+					e.deleteWord()
+				case 'p':
+					e.browseHistory(-1)
+				case 'n':
+					e.browseHistory(1)
+				}
+				continue
+			}
+
+			switch ev.Type {
+			case KeyEnter:
+				return string(e.buf), nil
+			case KeyRune:
+				e.insert([]rune{ev.Rune})
+			case KeyBackspace:
+				e.deleteLeft()
+			case KeyDelete:
+				e.deleteRight()
+			case KeyLeft:
+				e.moveTo(e.pos - 1)
+			case KeyRight:
+				e.moveTo(e.pos + 1)
+			case KeyHome:
+				e.moveTo(0)
+			case KeyEnd:
+				e.moveTo(len(e.buf))
+			case KeyUp:
+				e.browseHistory(-1)
+			case KeyDown:
+				e.browseHistory(1)
+			case KeyTab:
+				e.completeLine()
+			}
+		}
+	}
+}
+
+// display returns the characters as they get echoed.
+func (e *lineEditor) display(r []rune) string {
+	if !e.masked {
+		return string(r)
+	}
+	if e.mask == 0 {
+		return ""
+	}
+	return strings.Repeat(string(e.mask), len(r))
+}
+
+// column returns the (1-based) screen column of the cursor.
+func (e *lineEditor) column() int {
+	return e.prompt.Width() + uniseg.StringWidth(e.display(e.buf[:e.pos])) + 1
+}
+
+// redraw renders the whole line and positions the cursor.
+func (e *lineEditor) redraw() {
+	e.o.RedrawFromColumn(1, e.prompt.String()+e.display(e.buf))
+	fmt.Fprintf(e.o.w, CSI+CursorHorizontalSeq, e.column()) //nolint:errcheck
+}
+
+func (e *lineEditor) insert(r []rune) {
+	e.buf = append(e.buf[:e.pos], append(r, e.buf[e.pos:]...)...)
+	e.pos += len(r)
+	e.o.InsertString(e.display(r))
+}
+
+func (e *lineEditor) deleteLeft() {
+	if e.pos == 0 {
+		return
+	}
+	w := uniseg.StringWidth(e.display(e.buf[e.pos-1 : e.pos]))
+	e.buf = append(e.buf[:e.pos-1], e.buf[e.pos:]...)
+	e.pos--
+	if w > 0 {
+		e.o.CursorBack(w)
+		e.o.DeleteChars(w)
+	}
+}
+
+func (e *lineEditor) deleteRight() {
+	if e.pos == len(e.buf) {
+		return
+	}
+	w := uniseg.StringWidth(e.display(e.buf[e.pos : e.pos+1]))
+	e.buf = append(e.buf[:e.pos], e.buf[e.pos+1:]...)
+	e.o.DeleteChars(w)
+}
+
+func (e *lineEditor) deleteWord() {
+	i := e.pos
+	for i > 0 && e.buf[i-1] == ' ' {
+		i--
+	}
+	for i > 0 && e.buf[i-1] != ' ' {
+		i--
+	}
+	e.buf = append(e.buf[:i], e.buf[e.pos:]...)
+	e.pos = i
+	e.redraw()
+}
+
+func (e *lineEditor) moveTo(pos int) {
+	if pos < 0 || pos > len(e.buf) || pos == e.pos {
+		return
+	}
+	e.pos = pos
+	fmt.Fprintf(e.o.w, CSI+CursorHorizontalSeq, e.column()) //nolint:errcheck
+}
+
+func (e *lineEditor) setLine(r []rune) {
+	e.buf = append([]rune{}, r...)
+	e.pos = len(e.buf)
+	e.redraw()
+}
+
+func (e *lineEditor) browseHistory(delta int) {
+	if e.history == nil || e.masked {
+		return
+	}
+	idx := e.histIdx + delta
+	if idx < 0 || idx > len(e.history.entries) {
+		return
+	}
+	if e.histIdx == len(e.history.entries) {
+		e.saved = append([]rune{}, e.buf...)
+	}
+	e.histIdx = idx
+
+	if idx == len(e.history.entries) {
+		e.setLine(e.saved)
+		return
+	}
+	e.setLine([]rune(e.history.entries[idx]))
+}
+
+func (e *lineEditor) completeLine() {
+	if e.complete == nil {
+		return
+	}
+	candidates := e.complete(string(e.buf))
+	if len(candidates) == 0 {
+		return
+	}
+
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(c, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	if len(prefix) > len(string(e.buf)) {
+		e.setLine([]rune(prefix))
+	}
+}
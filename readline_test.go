@@ -0,0 +1,105 @@
+package termenv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "hello\r", "hello"},
+		{"backspace", "helx\x7flo\r", "hello"},
+		{"cursor movement", "hllo\x1b[D\x1b[D\x1b[De\x01\x05!\r", "hello!"},
+		{"kill", "hello world\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x0b\r", "hello "},
+		{"delete word", "hello world\x17\r", "hello "},
+		{"paste", "\x1b[200~foo\nbar\x1b[201~\r", "foo bar"},
+		{"unicode", "世界\x7f\r", "世"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+			line, err := o.ReadLine(String("> "), WithInput(strings.NewReader(test.input)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if line != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, line)
+			}
+		})
+	}
+}
+
+func TestReadLineHistory(t *testing.T) {
+	h := NewHistory(2)
+	o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+	for _, in := range []string{"one\r", "two\r", "three\r"} {
+		if _, err := o.ReadLine(String("> "), WithInput(strings.NewReader(in)), WithHistory(h)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(h.Entries()) != 2 || h.Entries()[0] != "two" {
+		t.Fatalf("unexpected history: %v", h.Entries())
+	}
+
+	line, err := o.ReadLine(String("> "), WithInput(strings.NewReader("\x1b[A\x1b[A!\r")), WithHistory(h))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if line != "two!" {
+		t.Errorf("expected two!, got %q", line)
+	}
+}
+
+func TestReadLineCompletion(t *testing.T) {
+	complete := func(line string) []string {
+		var res []string
+		for _, c := range []string{"config", "configure", "connect"} {
+			if strings.HasPrefix(c, line) {
+				res = append(res, c)
+			}
+		}
+		return res
+	}
+
+	o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+	line, err := o.ReadLine(String("> "), WithInput(strings.NewReader("conf\t\r")), WithCompletion(complete))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if line != "config" {
+		t.Errorf("expected config, got %q", line)
+	}
+}
+
+func TestReadLineMask(t *testing.T) {
+	buf := &bytes.Buffer{}
+	o := NewOutput(buf, WithProfile(Ascii))
+	line, err := o.ReadLine(String(""), WithInput(strings.NewReader("secret\r")), WithMask('*'))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if line != "secret" {
+		t.Errorf("expected secret, got %q", line)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("masked input was echoed: %q", buf.String())
+	}
+}
+
+func TestReadLineAbort(t *testing.T) {
+	o := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+	if _, err := o.ReadLine(String(""), WithInput(strings.NewReader("foo\x03"))); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("expected ErrInterrupted, got %v", err)
+	}
+	if _, err := o.ReadLine(String(""), WithInput(strings.NewReader("\x04"))); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package termenv
+
+import (
+	"sync"
+)
+
+// Snapshot is the detected state of a terminal at a point in time.
+type Snapshot struct {
+	Profile         Profile
+	ForegroundColor Color
+	BackgroundColor Color
+	DarkBackground  bool
+	Capabilities    Capabilities
+}
+
+// listeners holds the functions called after an Output got re-detected, in
+// the order they got registered.
+type listeners struct {
+	mu    sync.Mutex
+	next  int
+	funcs []listener
+}
+
+// listener is a function registered with OnRedetect.
+type listener struct {
+	id int
+	f  func(Snapshot)
+}
+
+// Snapshot returns the currently detected state of the terminal. The default
+// colors are queried in a single round trip.
+func (o *Output) Snapshot() Snapshot {
+	fg, bg := o.colors()
+	return Snapshot{
+		Profile:         o.profile(),
+		ForegroundColor: fg,
+		BackgroundColor: bg,
+		DarkBackground:  isDark(bg),
+		Capabilities:    o.Capabilities(),
+	}
+}
+
+// OnRedetect registers f to be called with the new state of the terminal
+// every time Redetect runs. Listeners get called in the order they got
+// registered. It returns a function unregistering f.
+func (o *Output) OnRedetect(f func(Snapshot)) func() {
+	l := o.listeners
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id := l.next
+	l.next++
+	l.funcs = append(l.funcs, listener{id: id, f: f})
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, lf := range l.funcs {
+			if lf.id == id {
+				l.funcs = append(l.funcs[:i:i], l.funcs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Redetect re-runs the detection of the color profile and the terminal's
+// default colors, e.g. after the user switched the terminal's theme or the
+// terminal sent a notification about it. All functions registered with
+// OnRedetect get called with the new state, which is also returned.
+//
+// A profile set with WithProfile is kept.
+func (o *Output) Redetect() Snapshot {
+	o.mu.Lock()
+	if o.detect {
+		o.Profile = o.EnvColorProfile()
+	}
+	o.fgSync = &sync.Once{}
+	o.bgSync = &sync.Once{}
+	o.mu.Unlock()
+
+	snap := o.Snapshot()
+
+	o.listeners.mu.Lock()
+	funcs := o.listeners.funcs
+	o.listeners.mu.Unlock()
+
+	for _, l := range funcs {
+		l.f(snap)
+	}
+	return snap
+}
@@ -0,0 +1,110 @@
+package termenv
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRedetect(t *testing.T) {
+	env := mapEnv{"TERM": "xterm", "COLORTERM": "truecolor"}
+	o := NewOutput(nil, WithEnvironment(env), WithTTY(true))
+	if o.Profile != TrueColor {
+		t.Fatalf("expected %s, got %s", TrueColor.Name(), o.Profile.Name())
+	}
+
+	var got []Profile
+	remove := o.OnRedetect(func(s Snapshot) {
+		got = append(got, s.Profile)
+	})
+
+	env["COLORTERM"] = ""
+	env["TERM"] = "xterm-256color"
+	if s := o.Redetect(); s.Profile != ANSI256 || o.Profile != ANSI256 {
+		t.Errorf("expected %s, got %s", ANSI256.Name(), s.Profile.Name())
+	}
+
+	remove()
+	o.Redetect()
+	if len(got) != 1 || got[0] != ANSI256 {
+		t.Errorf("expected listener to be called once with %s, got %v", ANSI256.Name(), got)
+	}
+}
+
+func TestRedetectKeepsProfile(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{"TERM": "xterm"}), WithProfile(TrueColor))
+	if s := o.Redetect(); s.Profile != TrueColor {
+		t.Errorf("expected %s, got %s", TrueColor.Name(), s.Profile.Name())
+	}
+}
+
+func TestRedetectListenerOrder(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{"TERM": "xterm"}), WithTTY(true))
+
+	var got []int
+	for i := 0; i < 10; i++ {
+		i := i
+		remove := o.OnRedetect(func(Snapshot) {
+			got = append(got, i)
+		})
+		if i == 3 {
+			remove()
+		}
+	}
+
+	o.Redetect()
+	exp := []int{0, 1, 2, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected listeners to be called in order %v, got %v", exp, got)
+	}
+}
+
+func TestRedetectConcurrent(t *testing.T) {
+	env := mapEnv{"TERM": "xterm-256color", "COLORFGBG": "15;0"}
+	o := NewOutput(nil, WithEnvironment(env), WithTTY(true), WithLazyDetection(), WithColorCache(true))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = o.Color("#ff0000")
+				_ = o.BackgroundColor()
+				_ = o.Snapshot()
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		o.Redetect()
+	}
+	wg.Wait()
+}
+
+func TestRedetectConcurrentRendering(t *testing.T) {
+	env := mapEnv{"TERM": "xterm-256color", "COLORFGBG": "15;0"}
+	o := NewOutput(&bytes.Buffer{}, WithEnvironment(env), WithTTY(true), WithLazyDetection(), WithColorCache(true))
+	m := NewMultiOutput(o)
+	c := AdaptiveColor{Light: "#000000", Dark: "#ffffff"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = o.String("x").Foreground(c).Bold().String()
+				_ = StringFor(o, "x").Background(o.ConvertDithered(RGBColor("#808080"), j, j)).String()
+				_ = o.TemplateFuncs()
+				_ = o.ExportShell(Theme{}, ShellPOSIX)
+				_ = o.DetectionReport()
+				_ = m.Profiles()
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		o.Redetect()
+	}
+	wg.Wait()
+}
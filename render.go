@@ -0,0 +1,57 @@
+package termenv
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// renderBuffers holds the buffers Render assembles its output in.
+var renderBuffers = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256) //nolint:mnd
+		return &b
+	},
+}
+
+// Render writes s, rendered with all applied styles, to w in a single write.
+// Unlike Styled, it reuses its buffers and doesn't allocate a new string for
+// every call, which matters for renderers redrawing at a high frequency.
+func (t Style) Render(w io.Writer, s string) error {
+	b := renderBuffers.Get().(*[]byte)
+	*b = t.AppendTo((*b)[:0], s)
+	_, err := w.Write(*b)
+	renderBuffers.Put(b)
+	return err //nolint:wrapcheck
+}
+
+// AppendTo appends s, rendered with all applied styles, to dst and returns
+// the extended buffer. It only allocates if dst lacks the capacity, or if the
+// style transforms the text, e.g. to expand tabs or style lines separately,
+// or renders a hyperlink.
+func (t Style) AppendTo(dst []byte, s string) []byte {
+	if t.link != "" {
+		return append(dst, t.Styled(s)...)
+	}
+
+	s = t.applyTextPolicies(s)
+	if s == "" || t.profile == Ascii || len(t.styles) == 0 {
+		return append(dst, s...)
+	}
+
+	seq := t.sequence()
+	if seq == "" {
+		return append(dst, s...)
+	}
+	if t.lines != lineModeNone && strings.Contains(s, "\n") {
+		return append(dst, t.styledLines(seq, s)...)
+	}
+
+	dst = append(dst, CSI...)
+	dst = append(dst, seq...)
+	dst = append(dst, 'm')
+	dst = append(dst, s...)
+	dst = append(dst, CSI...)
+	dst = append(dst, t.reset()...)
+	return append(dst, 'm')
+}
@@ -0,0 +1,72 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	s := String().Bold()
+	tests := []struct {
+		style Style
+		in    string
+	}{
+		{s, ""},
+		{String(), "foo"},
+		{s, "foo"},
+		{s.Italic().Foreground(TrueColor.Color("#abcdef")), "foo"},
+		{s.Lines(), "foo\nbar"},
+		{s.TabWidth(4), "a\tb"},
+		{s.ScopedReset(), "foo"},
+		{Ascii.String().Bold(), "foo"},
+	}
+
+	for _, test := range tests {
+		exp := test.style.Styled(test.in)
+
+		var buf bytes.Buffer
+		if err := test.style.Render(&buf, test.in); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != exp {
+			t.Errorf("Render: expected %q, got %q", exp, got)
+		}
+
+		prefix := []byte("> ")
+		if got := string(test.style.AppendTo(prefix, test.in)); got != "> "+exp {
+			t.Errorf("AppendTo: expected %q, got %q", "> "+exp, got)
+		}
+	}
+}
+
+func TestAppendToAllocs(t *testing.T) {
+	s := String().Bold().Italic().Foreground(TrueColor.Color("#abcdef"))
+	buf := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = s.AppendTo(buf[:0], "token")
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	s := String().Bold().Italic().Foreground(TrueColor.Color("#abcdef"))
+	var buf bytes.Buffer
+
+	b.Run("Styled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			buf.WriteString(s.Styled("token"))
+		}
+	})
+	b.Run("Render", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			_ = s.Render(&buf, "token")
+		}
+	})
+}
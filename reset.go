@@ -0,0 +1,98 @@
+package termenv
+
+import (
+	"strings"
+)
+
+// Targeted reset sequences.
+const (
+	NormalIntensitySeq   = "22"
+	NoItalicSeq          = "23"
+	NoUnderlineSeq       = "24"
+	NoBlinkSeq           = "25"
+	NoReverseSeq         = "27"
+	NoCrossOutSeq        = "29"
+	DefaultForegroundSeq = "39"
+	DefaultBackgroundSeq = "49"
+	NoOverlineSeq        = "55"
+
+	DefaultUnderlineColorSeq = "59"
+)
+
+// ScopedReset makes the Style close only the attributes it opened, instead
+// of resetting all attributes with SGR 0. This keeps an outer style intact
+// when a styled fragment is nested inside of it, e.g. a bold word within a
+// colored line.
+func (t Style) ScopedReset() Style {
+	t.scoped = true
+	return t
+}
+
+// reset returns the SGR parameters closing the style.
+func (t Style) reset() string {
+	if !t.scoped {
+		return ResetSeq
+	}
+
+	var resets []string
+	seen := map[string]bool{}
+	for _, seq := range t.styles {
+		r := resetFor(seq)
+		if r == "" || seen[r] {
+			continue
+		}
+		seen[r] = true
+		resets = append(resets, r)
+	}
+
+	if len(resets) == 0 {
+		return ResetSeq
+	}
+	return strings.Join(resets, ";")
+}
+
+// resetFor returns the SGR parameter closing the attribute opened by seq, or
+// an empty string if seq is unknown.
+func resetFor(seq string) string {
+	p := seq
+	if i := strings.IndexAny(p, ";:"); i >= 0 {
+		p = p[:i]
+	}
+
+	switch p {
+	case BoldSeq, FaintSeq:
+		return NormalIntensitySeq
+	case ItalicSeq:
+		return NoItalicSeq
+	case UnderlineSeq:
+		return NoUnderlineSeq
+	case BlinkSeq:
+		return NoBlinkSeq
+	case ReverseSeq:
+		return NoReverseSeq
+	case CrossOutSeq:
+		return NoCrossOutSeq
+	case OverlineSeq:
+		return NoOverlineSeq
+	case "38":
+		return DefaultForegroundSeq
+	case "48":
+		return DefaultBackgroundSeq
+	case UnderlineColorSeq:
+		return DefaultUnderlineColorSeq
+	}
+
+	if len(p) == 2 && p[1] >= '0' && p[1] <= '7' { //nolint:mnd
+		switch p[0] {
+		case '3', '9':
+			return DefaultForegroundSeq
+		case '4':
+			return DefaultBackgroundSeq
+		}
+	}
+	if len(p) == 3 && p[:2] == "10" && p[2] >= '0' && p[2] <= '7' { //nolint:mnd
+		return DefaultBackgroundSeq
+	}
+
+	return ""
+}
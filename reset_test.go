@@ -0,0 +1,39 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestScopedReset(t *testing.T) {
+	tests := []struct {
+		style    Style
+		expected string
+	}{
+		{String().Bold().ScopedReset(), "\x1b[1mfoo\x1b[22m"},
+		{String().Bold().Faint().ScopedReset(), "\x1b[1;2mfoo\x1b[22m"},
+		{String().Italic().Underline().ScopedReset(), "\x1b[3;4mfoo\x1b[23;24m"},
+		{String().Foreground(ANSI.Color("1")).ScopedReset(), "\x1b[31mfoo\x1b[39m"},
+		{String().Foreground(ANSI.Color("9")).ScopedReset(), "\x1b[91mfoo\x1b[39m"},
+		{String().Background(ANSI.Color("9")).ScopedReset(), "\x1b[101mfoo\x1b[49m"},
+		{String().Background(TrueColor.Color("#abcdef")).ScopedReset(), "\x1b[48;2;171;205;239mfoo\x1b[49m"},
+		{String().Foreground(ANSI256.Color("200")).Overline().ScopedReset(), "\x1b[38;5;200;53mfoo\x1b[39;55m"},
+		{String().Bold().UnderlineStyle(UnderlineCurly).UnderlineColor(RGBColor("#ff0000")).ScopedReset(), "\x1b[1;4:3;58;2;255;0;0mfoo\x1b[22;24;59m"},
+		{String().Bold(), "\x1b[1mfoo\x1b[0m"},
+	}
+
+	for _, test := range tests {
+		if got := test.style.Styled("foo"); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestScopedResetNested(t *testing.T) {
+	outer := String().Foreground(ANSI.Color("1"))
+	inner := String().Bold().ScopedReset()
+
+	exp := "\x1b[31ma \x1b[1mb\x1b[22m c\x1b[0m"
+	if got := outer.Styled("a " + inner.Styled("b") + " c"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
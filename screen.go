@@ -23,8 +23,11 @@ const (
 	SaveCursorPositionSeq    = "s"
 	RestoreCursorPositionSeq = "u"
 	ChangeScrollingRegionSeq = "%d;%dr"
+	ResetScrollingRegionSeq  = "r"
 	InsertLineSeq            = "%dL"
 	DeleteLineSeq            = "%dM"
+	InsertCharSeq            = "%d@"
+	DeleteCharSeq            = "%dP"
 
 	// Explicit values for EraseLineSeq.
 	EraseLineRightSeq  = "0K"
@@ -46,6 +49,8 @@ const (
 	DisableMouseExtendedModeSeq = "?1006l"
 	EnableMousePixelsModeSeq    = "?1016h" // press, release, move, wheel, extended pixel coordinates
 	DisableMousePixelsModeSeq   = "?1016l"
+	EnableMouseURXVTModeSeq     = "?1015h" // press, release, move, wheel, extended coordinates (urxvt)
+	DisableMouseURXVTModeSeq    = "?1015l"
 
 	// Screen.
 	RestoreScreenSeq = "?47l"
@@ -57,16 +62,23 @@ const (
 	// https://en.wikipedia.org/wiki/Bracketed-paste
 	EnableBracketedPasteSeq  = "?2004h"
 	DisableBracketedPasteSeq = "?2004l"
-	StartBracketedPasteSeq   = "200~"
-	EndBracketedPasteSeq     = "201~"
+	// While bracketed paste is enabled, the terminal sends pasted text
+	// between these markers, each prefixed with CSI.
+	StartBracketedPasteSeq = "200~"
+	EndBracketedPasteSeq   = "201~"
 
 	// Session.
 	SetWindowTitleSeq     = "2;%s" + string(BEL)
+	SetIconNameSeq        = "1;%s" + string(BEL)
 	SetForegroundColorSeq = "10;%s" + string(BEL)
 	SetBackgroundColorSeq = "11;%s" + string(BEL)
 	SetCursorColorSeq     = "12;%s" + string(BEL)
 	ShowCursorSeq         = "?25h"
 	HideCursorSeq         = "?25l"
+	// XTWINOPS: push the window title and icon name on the terminal's
+	// title stack, and pop them again.
+	SaveWindowTitleSeq    = "22;0t"
+	RestoreWindowTitleSeq = "23;0t"
 )
 
 // Reset the terminal to its default style, removing any active styles.
@@ -101,14 +113,26 @@ func (o Output) SaveScreen() {
 
 // AltScreen switches to the alternate screen buffer. The former view can be
 // restored with ExitAltScreen().
+//
+// Calls can be nested: only the outermost AltScreen switches buffers, and
+// only its matching ExitAltScreen returns to the former view.
 func (o Output) AltScreen() {
-	fmt.Fprint(o.w, CSI+AltScreenSeq) //nolint:errcheck
+	if o.altScreen.enter() {
+		fmt.Fprint(o.w, CSI+AltScreenSeq) //nolint:errcheck
+	}
 }
 
 // ExitAltScreen exits the alternate screen buffer and returns to the former
 // terminal view.
 func (o Output) ExitAltScreen() {
-	fmt.Fprint(o.w, CSI+ExitAltScreenSeq) //nolint:errcheck
+	if o.altScreen.exit() {
+		fmt.Fprint(o.w, CSI+ExitAltScreenSeq) //nolint:errcheck
+	}
+}
+
+// InAltScreen returns whether the alternate screen buffer is active.
+func (o Output) InAltScreen() bool {
+	return o.altScreen.active()
 }
 
 // ClearScreen clears the visible portion of the terminal.
@@ -213,6 +237,18 @@ func (o Output) DeleteLines(n int) {
 	fmt.Fprintf(o.w, CSI+DeleteLineSeq, n) //nolint:errcheck
 }
 
+// ScrollUp scrolls the scrollable region up by the given number of lines,
+// adding blank lines at the bottom.
+func (o Output) ScrollUp(n int) {
+	fmt.Fprintf(o.w, CSI+ScrollUpSeq, n) //nolint:errcheck
+}
+
+// ScrollDown scrolls the scrollable region down by the given number of lines,
+// adding blank lines at the top.
+func (o Output) ScrollDown(n int) {
+	fmt.Fprintf(o.w, CSI+ScrollDownSeq, n) //nolint:errcheck
+}
+
 // EnableMousePress enables X10 mouse mode. Button press events are sent only.
 func (o Output) EnableMousePress() {
 	fmt.Fprint(o.w, CSI+EnableMousePressSeq) //nolint:errcheck
@@ -286,11 +322,40 @@ func (o Output) DisableMousePixelsMode() {
 	fmt.Fprint(o.w, CSI+DisableMousePixelsModeSeq) //nolint:errcheck
 }
 
+// EnableMouseURXVTMode enables urxvt extended mouse mode. Prefer
+// EnableMouseExtendedMode, which is more widely supported.
+func (o Output) EnableMouseURXVTMode() {
+	fmt.Fprint(o.w, CSI+EnableMouseURXVTModeSeq) //nolint:errcheck
+}
+
+// DisableMouseURXVTMode disables urxvt extended mouse mode.
+func (o Output) DisableMouseURXVTMode() {
+	fmt.Fprint(o.w, CSI+DisableMouseURXVTModeSeq) //nolint:errcheck
+}
+
 // SetWindowTitle sets the terminal window title.
 func (o Output) SetWindowTitle(title string) {
 	fmt.Fprintf(o.w, OSC+SetWindowTitleSeq, title) //nolint:errcheck
 }
 
+// SetIconName sets the terminal icon name, which many terminals show as the
+// tab title.
+func (o Output) SetIconName(name string) {
+	fmt.Fprintf(o.w, OSC+SetIconNameSeq, name) //nolint:errcheck
+}
+
+// SaveWindowTitle saves the window title and icon name, so they can be
+// restored with RestoreWindowTitle after changing them. Saves can be nested.
+func (o Output) SaveWindowTitle() {
+	fmt.Fprint(o.w, CSI+SaveWindowTitleSeq) //nolint:errcheck
+}
+
+// RestoreWindowTitle restores the window title and icon name saved by the
+// last SaveWindowTitle.
+func (o Output) RestoreWindowTitle() {
+	fmt.Fprint(o.w, CSI+RestoreWindowTitleSeq) //nolint:errcheck
+}
+
 // EnableBracketedPaste enables bracketed paste.
 func (o Output) EnableBracketedPaste() {
 	fmt.Fprintf(o.w, CSI+EnableBracketedPasteSeq) //nolint:errcheck
@@ -498,6 +563,20 @@ func DeleteLines(n int) {
 	output.DeleteLines(n)
 }
 
+// ScrollUp scrolls the scrollable region up by the given number of lines.
+//
+// Deprecated: please use termenv.Output instead.
+func ScrollUp(n int) {
+	output.ScrollUp(n)
+}
+
+// ScrollDown scrolls the scrollable region down by the given number of lines.
+//
+// Deprecated: please use termenv.Output instead.
+func ScrollDown(n int) {
+	output.ScrollDown(n)
+}
+
 // EnableMousePress enables X10 mouse mode. Button press events are sent only.
 //
 // Deprecated: please use termenv.Output instead.
@@ -575,6 +654,28 @@ func SetWindowTitle(title string) {
 	output.SetWindowTitle(title)
 }
 
+// SetIconName sets the terminal icon name, which many terminals show as the
+// tab title.
+//
+// Deprecated: please use termenv.Output instead.
+func SetIconName(name string) {
+	output.SetIconName(name)
+}
+
+// SaveWindowTitle saves the window title and icon name.
+//
+// Deprecated: please use termenv.Output instead.
+func SaveWindowTitle() {
+	output.SaveWindowTitle()
+}
+
+// RestoreWindowTitle restores the saved window title and icon name.
+//
+// Deprecated: please use termenv.Output instead.
+func RestoreWindowTitle() {
+	output.RestoreWindowTitle()
+}
+
 // EnableBracketedPaste enables bracketed paste.
 //
 // Deprecated: please use termenv.Output instead.
@@ -2,6 +2,7 @@ package termenv
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"os"
 	"strings"
@@ -21,6 +22,21 @@ func (te testEnv) Getenv(key string) string {
 	return ""
 }
 
+// mapEnv is an Environ backed by a map.
+type mapEnv map[string]string
+
+func (e mapEnv) Environ() []string {
+	var env []string
+	for k, v := range e {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+func (e mapEnv) Getenv(key string) string {
+	return e[key]
+}
+
 func tempOutput(t *testing.T) *Output {
 	t.Helper()
 
@@ -34,7 +50,7 @@ func tempOutput(t *testing.T) *Output {
 
 func verify(t *testing.T, o *Output, exp string) {
 	t.Helper()
-	tty := o.w.(*os.File)
+	tty := o.Writer().(*os.File)
 
 	if _, err := tty.Seek(0, 0); err != nil {
 		t.Fatal(err)
@@ -102,6 +118,24 @@ func TestExitAltScreen(t *testing.T) {
 	verify(t, o, "\x1b[?1049l")
 }
 
+func TestNestedAltScreen(t *testing.T) {
+	o := tempOutput(t)
+	o.AltScreen()
+	o.AltScreen()
+	if !o.InAltScreen() {
+		t.Errorf("expected alternate screen to be active")
+	}
+	o.ExitAltScreen()
+	if !o.InAltScreen() {
+		t.Errorf("expected alternate screen to be active")
+	}
+	o.ExitAltScreen()
+	if o.InAltScreen() {
+		t.Errorf("expected alternate screen to be inactive")
+	}
+	verify(t, o, "\x1b[?1049h\x1b[?1049l")
+}
+
 func TestClearScreen(t *testing.T) {
 	o := tempOutput(t)
 	o.ClearScreen()
@@ -216,6 +250,18 @@ func TestDeleteLines(t *testing.T) {
 	verify(t, o, "\x1b[8M")
 }
 
+func TestScrollUp(t *testing.T) {
+	o := tempOutput(t)
+	o.ScrollUp(3)
+	verify(t, o, "\x1b[3S")
+}
+
+func TestScrollDown(t *testing.T) {
+	o := tempOutput(t)
+	o.ScrollDown(3)
+	verify(t, o, "\x1b[3T")
+}
+
 func TestEnableMousePress(t *testing.T) {
 	o := tempOutput(t)
 	o.EnableMousePress()
@@ -300,12 +346,41 @@ func TestDisableMousePixelsMode(t *testing.T) {
 	verify(t, o, "\x1b[?1016l")
 }
 
+func TestEnableMouseURXVTMode(t *testing.T) {
+	o := tempOutput(t)
+	o.EnableMouseURXVTMode()
+	o.DisableMouseURXVTMode()
+	verify(t, o, "\x1b[?1015h\x1b[?1015l")
+}
+
 func TestSetWindowTitle(t *testing.T) {
 	o := tempOutput(t)
 	o.SetWindowTitle("test")
 	verify(t, o, "\x1b]2;test\a")
 }
 
+func TestSetIconName(t *testing.T) {
+	o := tempOutput(t)
+	o.SetIconName("test")
+	verify(t, o, "\x1b]1;test\a")
+}
+
+func TestSaveWindowTitle(t *testing.T) {
+	o := tempOutput(t)
+	o.SaveWindowTitle()
+	o.SetWindowTitle("test")
+	o.RestoreWindowTitle()
+	verify(t, o, "\x1b[22;0t\x1b]2;test\a\x1b[23;0t")
+}
+
+// Copy and CopyPrimary keep their original signatures.
+var (
+	_ func(string) = Copy
+	_ func(string) = CopyPrimary
+	_ func(string) = (&Output{}).Copy
+	_ func(string) = (&Output{}).CopyPrimary
+)
+
 func TestCopyClipboard(t *testing.T) {
 	o := tempOutput(t)
 	o.Copy("hello")
@@ -318,8 +393,46 @@ func TestCopyPrimary(t *testing.T) {
 	verify(t, o, "\x1b]52;p;aGVsbG8=\a")
 }
 
+func TestCopyLimit(t *testing.T) {
+	o := tempOutput(t)
+	if err := o.CopyWithOptions("hello", WithCopyLimit(4)); !errors.Is(err, ErrCopyLimit) {
+		t.Errorf("expected ErrCopyLimit, got %v", err)
+	}
+	verify(t, o, "")
+
+	o = tempOutput(t)
+	if err := o.CopyWithOptions("hello", WithCopyLimit(5)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	verify(t, o, "\x1b]52;c;aGVsbG8=\a")
+
+	// unlimited by default
+	var buf bytes.Buffer
+	o = NewOutput(&buf, WithEnvironment(testEnv{}))
+	if err := o.CopyWithOptions(strings.Repeat("a", XTermCopyLimit+1)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "\x1b]52;c;YWFh") {
+		t.Errorf("expected the text to be copied, got %d bytes", buf.Len())
+	}
+}
+
+func TestCopyChunks(t *testing.T) {
+	o := tempOutput(t)
+	_ = o.CopyWithOptions(strings.Repeat("a", 60), WithCopyChunks())
+	verify(t, o, "\x1bP\x1b]52;c;"+strings.Repeat("YWFh", 19)+"\x1b\\\x1bP"+
+		strings.Repeat("YWFh", 1)+"\a\x1b\\")
+}
+
 func TestHyperlink(t *testing.T) {
 	o := tempOutput(t)
+	WithHyperlinks(true)(o)
 	o.WriteString(o.Hyperlink("http://example.com", "example"))
 	verify(t, o, "\x1b]8;;http://example.com\x1b\\example\x1b]8;;\x1b\\")
 }
+
+func TestHyperlinkFallback(t *testing.T) {
+	o := tempOutput(t)
+	o.WriteString(o.Hyperlink("http://example.com", "example"))
+	verify(t, o, "example (http://example.com)")
+}
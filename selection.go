@@ -0,0 +1,113 @@
+package termenv
+
+import (
+	"math"
+	"strings"
+)
+
+// SelectionMode selects the shape of a Selection.
+type SelectionMode int
+
+const (
+	// SelectLinear selects the text flowing from the start to the end
+	// position, like a mouse selection in a terminal.
+	SelectLinear SelectionMode = iota
+	// SelectRectangular selects the same columns on every line between the
+	// start and the end position, like an alt-drag selection.
+	SelectRectangular
+)
+
+// Selection is a region of lines of styled text, e.g. the lines shown by a
+// Pager or a Tail. Positions are given as line index and rune index in the
+// visible text of the line; both ends are included, and they may be given in
+// any order, e.g. while the user drags the end of the selection around.
+type Selection struct {
+	Mode SelectionMode
+
+	StartLine, StartCol int
+	EndLine, EndCol     int
+}
+
+// bounds returns the ordered positions of the selection.
+func (sel Selection) bounds() (l0, c0, l1, c1 int) {
+	l0, c0, l1, c1 = sel.StartLine, sel.StartCol, sel.EndLine, sel.EndCol
+	if l1 < l0 || (l1 == l0 && c1 < c0) {
+		l0, c0, l1, c1 = l1, c1, l0, c0
+	}
+	if sel.Mode == SelectRectangular && c1 < c0 {
+		c0, c1 = c1, c0
+	}
+	return l0, c0, l1, c1
+}
+
+// span returns the rune range [start, end) selected on line i, and whether
+// the line is part of the selection at all.
+func (sel Selection) span(i int) (int, int, bool) {
+	l0, c0, l1, c1 := sel.bounds()
+	if i < l0 || i > l1 {
+		return 0, 0, false
+	}
+	if sel.Mode == SelectRectangular {
+		return c0, c1 + 1, true
+	}
+
+	start, end := 0, math.MaxInt
+	if i == l0 {
+		start = c0
+	}
+	if i == l1 {
+		end = c1 + 1
+	}
+	return start, end, true
+}
+
+// Render returns a copy of lines with the selection painted on top with
+// style, which usually is Reverse. The existing styles of the lines are
+// kept outside of, and restored after, the selection.
+func (sel Selection) Render(lines []string, style Style) []string {
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		rendered[i] = line
+		if start, end, ok := sel.span(i); ok {
+			rendered[i] = ApplyStyleRange(line, start, end, style)
+		}
+	}
+	return rendered
+}
+
+// Text returns the plain text of the selection in lines, with the lines
+// separated by newlines.
+func (sel Selection) Text(lines []string) string {
+	var text []string
+	for i, line := range lines {
+		start, end, ok := sel.span(i)
+		if !ok {
+			continue
+		}
+
+		runes := []rune(Strip(line))
+		if end > len(runes) {
+			end = len(runes)
+		}
+		if start < 0 {
+			start = 0
+		}
+		if start > end {
+			start = end
+		}
+		text = append(text, string(runes[start:end]))
+	}
+	return strings.Join(text, "\n")
+}
+
+// CopySelection copies the plain text of the selection in lines to the
+// clipboard, using OSC 52.
+func CopySelection(sel Selection, lines []string, opts ...CopyOption) error {
+	return output.CopySelection(sel, lines, opts...)
+}
+
+// CopySelection copies the plain text of the selection in lines to the
+// clipboard, using OSC 52.
+func (o Output) CopySelection(sel Selection, lines []string, opts ...CopyOption) error {
+	return o.CopyWithOptions(sel.Text(lines), opts...)
+}
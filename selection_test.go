@@ -0,0 +1,86 @@
+package termenv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+func TestSelection(t *testing.T) {
+	lines := []string{
+		"first line",
+		"\x1b[1msecond\x1b[0m line",
+		"third line",
+	}
+	tests := []struct {
+		name     string
+		sel      Selection
+		text     string
+		rendered []string
+	}{
+		{
+			"linear",
+			Selection{StartLine: 0, StartCol: 6, EndLine: 1, EndCol: 2},
+			"line\nsec",
+			[]string{
+				"first \x1b[7mline\x1b[0m",
+				"\x1b[1m\x1b[7msec\x1b[0m\x1b[1mond\x1b[0m line",
+				"third line",
+			},
+		},
+		{
+			"reversed",
+			Selection{StartLine: 2, StartCol: 0, EndLine: 1, EndCol: 7},
+			"line\nt",
+			[]string{
+				"first line",
+				"\x1b[1msecond\x1b[0m \x1b[7mline\x1b[0m",
+				"\x1b[7mt\x1b[0mhird line",
+			},
+		},
+		{
+			"rectangular",
+			Selection{Mode: SelectRectangular, StartLine: 0, StartCol: 3, EndLine: 2, EndCol: 1},
+			"irs\neco\nhir",
+			[]string{
+				"f\x1b[7mirs\x1b[0mt line",
+				"\x1b[1ms\x1b[7meco\x1b[0m\x1b[1mnd\x1b[0m line",
+				"t\x1b[7mhir\x1b[0md line",
+			},
+		},
+		{
+			"past the end",
+			Selection{Mode: SelectRectangular, StartLine: 0, StartCol: 8, EndLine: 1, EndCol: 12},
+			"ne\nine",
+			[]string{
+				"first li\x1b[7mne\x1b[0m",
+				"\x1b[1msecond\x1b[0m l\x1b[7mine\x1b[0m",
+				"third line",
+			},
+		},
+	}
+
+	style := String().Reverse()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.sel.Text(lines); got != test.text {
+				t.Errorf("expected text %q, got %q", test.text, got)
+			}
+			if got := test.sel.Render(lines, style); !reflect.DeepEqual(got, test.rendered) {
+				t.Errorf("expected rendering %q, got %q", test.rendered, got)
+			}
+		})
+	}
+}
+
+func TestCopySelection(t *testing.T) {
+	var buf bytes.Buffer
+	o := NewOutput(&buf, WithEnvironment(testEnv{}))
+	o.CopySelection(Selection{EndLine: 1, EndCol: 1}, []string{"ab", "\x1b[1mcd\x1b[0m"})
+
+	exp := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("ab\ncd")) + "\a"
+	if got := buf.String(); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
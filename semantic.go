@@ -0,0 +1,141 @@
+package termenv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSemanticStyle gets returned when a semantic style override can't
+// be parsed.
+var ErrInvalidSemanticStyle = errors.New("invalid semantic style")
+
+// Semantic roles with default styles.
+const (
+	RoleError    = "error"
+	RoleWarning  = "warning"
+	RoleSuccess  = "success"
+	RoleInfo     = "info"
+	RoleHint     = "hint"
+	RoleEmphasis = "emphasis"
+)
+
+// semantics holds the user-configured semantic styles of an Output.
+type semantics struct {
+	mu     sync.RWMutex
+	styles map[string]Style
+}
+
+// defaultSemantic returns the default style for role.
+func (o *Output) defaultSemantic(role string) Style {
+	s := o.String()
+	switch role {
+	case RoleError:
+		return s.Foreground(o.Color("1")).Bold()
+	case RoleWarning:
+		return s.Foreground(o.Color("3"))
+	case RoleSuccess:
+		return s.Foreground(o.Color("2"))
+	case RoleInfo:
+		return s.Foreground(o.Color("4"))
+	case RoleHint:
+		return s.Faint()
+	case RoleEmphasis:
+		return s.Bold()
+	}
+	return s
+}
+
+// Semantic returns the style for a semantic role, e.g. RoleError.
+func Semantic(role string) Style {
+	return output.Semantic(role)
+}
+
+// Semantic returns the style for a semantic role, e.g. RoleError. Styles set
+// with SetSemantic or loaded with LoadSemanticEnv take precedence over the
+// defaults. Unknown roles are rendered without any styling. The role is
+// attached to the Style as "role" metadata.
+func (o *Output) Semantic(role string) Style {
+	o.semantics.mu.RLock()
+	s, ok := o.semantics.styles[role]
+	o.semantics.mu.RUnlock()
+
+	if !ok {
+		s = o.defaultSemantic(role)
+	}
+	return s.WithMeta("role", role)
+}
+
+// SetSemantic sets the style for a semantic role.
+func SetSemantic(role string, s Style) {
+	output.SetSemantic(role, s)
+}
+
+// SetSemantic sets the style for a semantic role.
+func (o *Output) SetSemantic(role string, s Style) {
+	o.semantics.mu.Lock()
+	defer o.semantics.mu.Unlock()
+
+	if o.semantics.styles == nil {
+		o.semantics.styles = map[string]Style{}
+	}
+	o.semantics.styles[role] = s
+}
+
+// LoadSemanticEnv reads semantic style overrides from the environment
+// variable name, e.g. "MYAPP_COLORS". The variable holds colon-separated
+// role=SGR pairs, like "error=1;31:hint=2". An unset variable is no error.
+func LoadSemanticEnv(name string) error {
+	return output.LoadSemanticEnv(name)
+}
+
+// LoadSemanticEnv reads semantic style overrides from the environment
+// variable name, e.g. "MYAPP_COLORS". The variable holds colon-separated
+// role=SGR pairs, like "error=1;31:hint=2". An unset variable is no error.
+// Nothing gets applied if any of the pairs is invalid.
+func (o *Output) LoadSemanticEnv(name string) error {
+	v := o.environ.Getenv(name)
+	if v == "" {
+		return nil
+	}
+
+	styles := map[string]Style{}
+	for _, entry := range strings.Split(v, ":") {
+		if entry == "" {
+			continue
+		}
+		i := strings.IndexByte(entry, '=')
+		if i <= 0 || !isSGRParams(entry[i+1:]) {
+			return fmt.Errorf("%s: %q", ErrInvalidSemanticStyle, entry)
+		}
+		role, params := entry[:i], entry[i+1:]
+
+		styles[role] = o.sgrStyle(params)
+	}
+
+	for role, s := range styles {
+		o.SetSemantic(role, s)
+	}
+	return nil
+}
+
+// sgrStyle returns a Style applying the given raw SGR parameters.
+func (o *Output) sgrStyle(params string) Style {
+	s := o.String()
+	if params == "" {
+		return s
+	}
+	return s.add(params)
+}
+
+// isSGRParams returns whether s only consists of SGR parameters, i.e. digits
+// separated by semicolons or colons.
+func isSGRParams(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && s[i] != ';' && s[i] != ':' {
+			return false
+		}
+	}
+	return true
+}
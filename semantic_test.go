@@ -0,0 +1,60 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestSemantic(t *testing.T) {
+	o := NewOutput(nil, WithEnvironment(mapEnv{}), WithProfile(ANSI))
+
+	exp := "\x1b[31;1mfoo\x1b[0m"
+	if got := o.Semantic(RoleError).Styled("foo"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+	if got := o.Semantic("unknown").Styled("foo"); got != "foo" {
+		t.Errorf("expected %q, got %q", "foo", got)
+	}
+	if v, _ := o.Semantic(RoleHint).Meta("role"); v != RoleHint {
+		t.Errorf("expected role metadata %q, got %v", RoleHint, v)
+	}
+
+	o.SetSemantic(RoleHint, o.String().Italic())
+	exp = "\x1b[3mfoo\x1b[0m"
+	if got := o.Semantic(RoleHint).Styled("foo"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
+
+func TestLoadSemanticEnv(t *testing.T) {
+	env := mapEnv{"MYAPP_COLORS": "error=4;35:custom=1::plain="}
+	o := NewOutput(nil, WithEnvironment(env), WithProfile(ANSI))
+	if err := o.LoadSemanticEnv("MYAPP_COLORS"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		role     string
+		expected string
+	}{
+		{RoleError, "\x1b[4;35mfoo\x1b[0m"},
+		{"custom", "\x1b[1mfoo\x1b[0m"},
+		{"plain", "foo"},
+		{RoleWarning, "\x1b[33mfoo\x1b[0m"},
+	}
+	for _, test := range tests {
+		if got := o.Semantic(test.role).Styled("foo"); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.role, test.expected, got)
+		}
+	}
+
+	if err := o.LoadSemanticEnv("UNSET"); err != nil {
+		t.Errorf("expected no error for unset variable, got %v", err)
+	}
+
+	for _, v := range []string{"error", "=1", "error=red"} {
+		env["BAD"] = v
+		if err := o.LoadSemanticEnv("BAD"); err == nil {
+			t.Errorf("%q: expected an error", v)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package termenv
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidSequence gets returned when a string does not start with a
+// complete, well-formed escape sequence.
+var ErrInvalidSequence = errors.New("invalid escape sequence")
+
+// introducers of sequences carrying a string payload, except DCS.
+var stringSeqKinds = map[string]SeqKind{
+	OSC: SeqOSC,
+	APC: SeqAPC,
+	PM:  SeqPM,
+	SOS: SeqSOS,
+}
+
+// Sequence is a structured escape sequence. Sequences are comparable, so two
+// sequences can be checked for equality with ==.
+//
+// For CSI, SS2, SS3, and DCS sequences, Params holds the parameter bytes,
+// Intermediate the intermediate bytes, and Final the final byte. DCS, OSC,
+// APC, PM, and SOS sequences carry their string payload in Data. OSC and APC
+// strings are split at the first semicolon: the part before it goes to
+// Params, the rest to Data.
+type Sequence struct {
+	Kind         SeqKind
+	Params       string
+	Intermediate string
+	Final        byte
+	Data         string
+}
+
+// String returns the encoded sequence. String sequences are always
+// terminated by ST.
+func (s Sequence) String() string {
+	var final string
+	if s.Final != 0 {
+		final = string(s.Final)
+	}
+
+	switch s.Kind {
+	case SeqDCS:
+		return Emit(s.Kind, s.Params+s.Intermediate+final, s.Data)
+	case SeqOSC, SeqAPC, SeqPM, SeqSOS:
+		return Emit(s.Kind, s.Params, s.Data)
+	}
+	return Emit(s.Kind, s.Params, s.Intermediate+final)
+}
+
+// Append appends the encoded sequence to b and returns the extended buffer.
+func (s Sequence) Append(b []byte) []byte {
+	return append(b, s.String()...)
+}
+
+// ParseSequence parses the escape sequence at the start of s. It returns the
+// sequence and the number of bytes it occupies in s.
+func ParseSequence(s string) (Sequence, int, error) {
+	if len(s) < 2 || s[0] != ESC { //nolint:mnd
+		return Sequence{}, 0, ErrInvalidSequence
+	}
+
+	switch s[:2] {
+	case CSI:
+		return parseControlSeq(s, SeqCSI)
+	case DCS:
+		seq, n, err := parseControlSeq(s, SeqDCS)
+		if err != nil {
+			return Sequence{}, 0, err
+		}
+		data, m, ok := parseStringData(s[n:], false)
+		if !ok {
+			return Sequence{}, 0, ErrInvalidSequence
+		}
+		seq.Data = data
+		return seq, n + m, nil
+	case SS2, SS3:
+		kind := SeqSS2
+		if s[:2] == SS3 {
+			kind = SeqSS3
+		}
+		// some terminals send modifiers as digits, e.g. "ESC O 5 P"
+		i := 2
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == len(s) || s[i] < 0x40 || s[i] > 0x7e { //nolint:mnd
+			return Sequence{}, 0, ErrInvalidSequence
+		}
+		return Sequence{Kind: kind, Params: s[2:i], Final: s[i]}, i + 1, nil
+	case OSC, APC, PM, SOS:
+		kind := stringSeqKinds[s[:2]]
+		data, n, ok := parseStringData(s[2:], kind == SeqOSC)
+		if !ok {
+			return Sequence{}, 0, ErrInvalidSequence
+		}
+		seq := Sequence{Kind: kind, Data: data}
+		if kind == SeqOSC || kind == SeqAPC {
+			if i := strings.IndexByte(data, ';'); i >= 0 {
+				seq.Params, seq.Data = data[:i], data[i+1:]
+			} else {
+				seq.Params, seq.Data = data, ""
+			}
+		}
+		return seq, 2 + n, nil
+	}
+
+	return Sequence{}, 0, ErrInvalidSequence
+}
+
+// parseControlSeq parses the parameter, intermediate, and final bytes
+// following the two byte introducer at the start of s.
+//
+//nolint:mnd
+func parseControlSeq(s string, kind SeqKind) (Sequence, int, error) {
+	i := 2
+	for i < len(s) && s[i] >= 0x30 && s[i] <= 0x3f {
+		i++
+	}
+	j := i
+	for j < len(s) && s[j] >= 0x20 && s[j] <= 0x2f {
+		j++
+	}
+	if j == len(s) || s[j] < 0x40 || s[j] > 0x7e {
+		return Sequence{}, 0, ErrInvalidSequence
+	}
+
+	return Sequence{
+		Kind:         kind,
+		Params:       s[2:i],
+		Intermediate: s[i:j],
+		Final:        s[j],
+	}, j + 1, nil
+}
+
+// parseStringData returns the string payload at the start of s, up to the
+// terminating ST (or BEL, if allowed), and the number of bytes consumed
+// including the terminator.
+func parseStringData(s string, bel bool) (string, int, bool) {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == BEL && bel:
+			return s[:i], i + 1, true
+		case strings.HasPrefix(s[i:], ST):
+			return s[:i], i + len(ST), true
+		}
+	}
+	return "", 0, false
+}
+
+// walkText splits s into runs of text and escape sequences, calling text or
+// seq for each of them in order. Malformed sequences are treated as text.
+func walkText(s string, text, seq func(string)) {
+	start := 0
+	for i := 0; i < len(s); {
+		if s[i] != ESC {
+			i++
+			continue
+		}
+		_, n, err := ParseSequence(s[i:])
+		if err != nil {
+			i++
+			continue
+		}
+		if i > start {
+			text(s[start:i])
+		}
+		seq(s[i : i+n])
+		i += n
+		start = i
+	}
+	if start < len(s) {
+		text(s[start:])
+	}
+}
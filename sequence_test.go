@@ -0,0 +1,68 @@
+package termenv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSequenceString(t *testing.T) {
+	tests := []struct {
+		seq      Sequence
+		expected string
+	}{
+		{Sequence{Kind: SeqCSI, Params: "1;31", Final: 'm'}, "\x1b[1;31m"},
+		{Sequence{Kind: SeqCSI, Params: "2", Intermediate: " ", Final: 'q'}, "\x1b[2 q"},
+		{Sequence{Kind: SeqSS3, Final: 'P'}, "\x1bOP"},
+		{Sequence{Kind: SeqOSC, Params: "2", Data: "title"}, "\x1b]2;title\x1b\\"},
+		{Sequence{Kind: SeqDCS, Intermediate: "+", Final: 'q', Data: "544e"}, "\x1bP+q544e\x1b\\"},
+		{Sequence{Kind: SeqAPC, Params: "Ga=T", Data: "AAAA"}, "\x1b_Ga=T;AAAA\x1b\\"},
+	}
+
+	for _, test := range tests {
+		if s := test.seq.String(); s != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, s)
+		}
+		if b := test.seq.Append([]byte("x")); string(b) != "x"+test.expected {
+			t.Errorf("expected %q, got %q", "x"+test.expected, b)
+		}
+
+		seq, n, err := ParseSequence(test.expected + "trailing")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq != test.seq {
+			t.Errorf("expected %#v, got %#v", test.seq, seq)
+		}
+		if n != len(test.expected) {
+			t.Errorf("expected %d bytes, got %d", len(test.expected), n)
+		}
+	}
+}
+
+func TestParseSequenceBEL(t *testing.T) {
+	seq, n, err := ParseSequence("\x1b]11;?\a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := Sequence{Kind: SeqOSC, Params: "11", Data: "?"}
+	if seq != exp || n != 7 {
+		t.Errorf("expected %#v (7 bytes), got %#v (%d bytes)", exp, seq, n)
+	}
+}
+
+func TestParseSequenceInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"abc",
+		"\x1b",
+		"\x1b[1;2",
+		"\x1b]2;title",
+		"\x1bP+q\a",
+		"\x1bN\x00",
+		"\x1bO\xce",
+	} {
+		if _, _, err := ParseSequence(s); !errors.Is(err, ErrInvalidSequence) {
+			t.Errorf("expected ErrInvalidSequence for %q, got %v", s, err)
+		}
+	}
+}
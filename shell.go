@@ -0,0 +1,202 @@
+package termenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shell selects the syntax of the definitions ExportShell emits.
+type Shell int
+
+const (
+	// ShellPOSIX emits definitions for any POSIX shell, using printf.
+	ShellPOSIX Shell = iota
+	// ShellBash emits definitions using ANSI-C quoting, e.g. RED=$'\e[31m'.
+	ShellBash
+	// ShellZsh emits the same definitions as ShellBash.
+	ShellZsh
+	// ShellFish emits set commands for fish.
+	ShellFish
+	// ShellPowerShell emits variable assignments for PowerShell 6 and later.
+	ShellPowerShell
+)
+
+// ExportShell returns shell variable definitions for the theme, rendered for
+// the profile of the default output, so shell scripts accompanying a Go
+// program can share its colors.
+func ExportShell(theme Theme, shell Shell) string {
+	return output.ExportShell(theme, shell)
+}
+
+// ExportShell returns shell variable definitions for the theme, rendered for
+// the profile of o. Each color of the theme is defined as its foreground
+// sequence, named after the color in upper case, e.g. ACCENT. Styles are
+// defined as STYLE_<ROLE>, symbols as SYMBOL_<NAME>, and RESET resets all
+// styles. Without colors, e.g. when the output is not a terminal, the
+// sequences are empty, so scripts can use them unconditionally:
+//
+//	eval "$(mytool theme --shell bash)"
+//	echo "${STYLE_ERROR}failed${RESET}"
+func (o *Output) ExportShell(theme Theme, shell Shell) string {
+	p := o.profile()
+	sgr := func(seq string) string {
+		if p == Ascii || seq == "" {
+			return ""
+		}
+		return CSI + seq + "m"
+	}
+
+	var b strings.Builder
+	for _, name := range sortedKeys(theme.Colors) {
+		var seq string
+		if c := o.ThemeColor(theme, name); c != nil {
+			seq = c.Sequence(false)
+		}
+		writeShellVar(&b, shell, shellVarName("", name), sgr(seq))
+	}
+	for _, role := range sortedKeys(theme.Styles) {
+		s := o.RoleStyle(theme, role)
+		writeShellVar(&b, shell, shellVarName("STYLE_", role), sgr(s.sequence()))
+	}
+	for _, name := range sortedKeys(theme.Symbols) {
+		writeShellVar(&b, shell, shellVarName("SYMBOL_", name), theme.Symbols[name])
+	}
+	writeShellVar(&b, shell, "RESET", sgr(ResetSeq))
+	return b.String()
+}
+
+// shellVarName returns name in upper case, with characters not allowed in
+// variable names replaced by underscores.
+func shellVarName(prefix, name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+	if prefix == "" && (name == "" || name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return prefix + name
+}
+
+// writeShellVar writes the definition of the variable name with value v.
+func writeShellVar(b *strings.Builder, shell Shell, name, v string) {
+	switch shell {
+	case ShellBash, ShellZsh:
+		fmt.Fprintf(b, "%s=%s\n", name, quoteANSIC(v))
+	case ShellFish:
+		fmt.Fprintf(b, "set -g %s %s\n", name, quoteFish(v))
+	case ShellPowerShell:
+		fmt.Fprintf(b, "$%s = %s\n", name, quotePowerShell(v))
+	default:
+		fmt.Fprintf(b, "%s=%s\n", name, quotePOSIX(v))
+	}
+}
+
+// quoteANSIC quotes s as $'...', with escapes for control characters.
+func quoteANSIC(s string) string {
+	var b strings.Builder
+	b.WriteString("$'")
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == ESC:
+			b.WriteString(`\e`)
+		case c == '\\' || c == '\'':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c == 0x7f: //nolint:mnd
+			fmt.Fprintf(&b, `\x%02x`, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// quotePOSIX single-quotes s, using printf to produce control characters.
+func quotePOSIX(s string) string {
+	if stripControls(s) == s {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+
+	var b strings.Builder
+	b.WriteString(`"$(printf '`)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			b.WriteString(`'\''`)
+		case c == '\\':
+			b.WriteString(`\\`)
+		case c == '%':
+			b.WriteString("%%")
+		case c < ' ' || c == 0x7f: //nolint:mnd
+			fmt.Fprintf(&b, `\%03o`, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteString(`')"`)
+	return b.String()
+}
+
+// quoteFish single-quotes s, writing control characters as escapes outside
+// of the quotes.
+func quoteFish(s string) string {
+	if s == "" {
+		return "''"
+	}
+
+	var (
+		b      strings.Builder
+		quoted bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		control := c < ' ' || c == 0x7f //nolint:mnd
+		if control == quoted {
+			b.WriteByte('\'')
+			quoted = !quoted
+		}
+		switch {
+		case c == ESC:
+			b.WriteString(`\e`)
+		case control:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		case c == '\\' || c == '\'':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if quoted {
+		b.WriteByte('\'')
+	}
+	return b.String()
+}
+
+// quotePowerShell double-quotes s, with backtick escapes.
+func quotePowerShell(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == ESC:
+			b.WriteString("`e")
+		case c == '`' || c == '"' || c == '$':
+			b.WriteByte('`')
+			b.WriteByte(c)
+		case c < ' ' || c == 0x7f: //nolint:mnd
+			fmt.Fprintf(&b, "$([char]0x%02x)", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
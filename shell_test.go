@@ -0,0 +1,84 @@
+package termenv
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestExportShell(t *testing.T) {
+	theme := Theme{
+		Version: ThemeVersion,
+		Name:    "test",
+		Colors:  map[string]string{"red": "1", "my-accent": "#5f87ff"},
+		Styles:  map[string]ThemeStyle{RoleError: {Foreground: "red", Bold: true}},
+		Symbols: map[string]string{"success": "it's ✓"},
+	}
+	o := NewOutput(&bytes.Buffer{}, WithProfile(ANSI256))
+
+	tests := []struct {
+		shell    Shell
+		expected string
+	}{
+		{ShellBash, `MY_ACCENT=$'\e[38;5;69m'
+RED=$'\e[31m'
+STYLE_ERROR=$'\e[31;1m'
+SYMBOL_SUCCESS=$'it\'s ✓'
+RESET=$'\e[0m'
+`},
+		{ShellPOSIX, `MY_ACCENT="$(printf '\033[38;5;69m')"
+RED="$(printf '\033[31m')"
+STYLE_ERROR="$(printf '\033[31;1m')"
+SYMBOL_SUCCESS='it'\''s ✓'
+RESET="$(printf '\033[0m')"
+`},
+		{ShellFish, `set -g MY_ACCENT \e'[38;5;69m'
+set -g RED \e'[31m'
+set -g STYLE_ERROR \e'[31;1m'
+set -g SYMBOL_SUCCESS 'it\'s ✓'
+set -g RESET \e'[0m'
+`},
+		{ShellPowerShell, "$MY_ACCENT = \"`e[38;5;69m\"\n" +
+			"$RED = \"`e[31m\"\n" +
+			"$STYLE_ERROR = \"`e[31;1m\"\n" +
+			"$SYMBOL_SUCCESS = \"it's ✓\"\n" +
+			"$RESET = \"`e[0m\"\n"},
+	}
+	for _, test := range tests {
+		if got := o.ExportShell(theme, test.shell); got != test.expected {
+			t.Errorf("shell %d: expected\n%s\ngot\n%s", test.shell, test.expected, got)
+		}
+	}
+
+	ascii := NewOutput(&bytes.Buffer{}, WithProfile(Ascii))
+	if got := ascii.ExportShell(theme, ShellBash); !strings.Contains(got, "RED=$''\n") {
+		t.Errorf("expected empty sequences without colors, got\n%s", got)
+	}
+}
+
+func TestExportShellEval(t *testing.T) {
+	theme := Theme{Version: ThemeVersion, Name: "test", Colors: map[string]string{"red": "1"}}
+	o := NewOutput(&bytes.Buffer{}, WithProfile(ANSI))
+
+	for _, sh := range []struct {
+		name  string
+		shell Shell
+	}{
+		{"sh", ShellPOSIX},
+		{"bash", ShellBash},
+	} {
+		path, err := exec.LookPath(sh.name)
+		if err != nil {
+			continue
+		}
+		script := o.ExportShell(theme, sh.shell) + `printf '%s' "$RED"`
+		out, err := exec.Command(path, "-c", script).Output()
+		if err != nil {
+			t.Fatalf("%s: %v", sh.name, err)
+		}
+		if string(out) != "\x1b[31m" {
+			t.Errorf("%s: expected %q, got %q", sh.name, "\x1b[31m", out)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package termenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColorType is the kind of color a ColorSpec describes.
+type ColorType int
+
+// Color types.
+const (
+	// ColorNone is the zero ColorType, describing the default color.
+	ColorNone ColorType = iota
+	ColorANSI
+	ColorANSI256
+	ColorRGB
+)
+
+// ColorSpec is a plain, serializable description of a Color, e.g. for
+// embedding colors in configuration files or RPC messages.
+type ColorSpec struct {
+	Type ColorType `json:"type"`
+	// Index is the palette index of ANSI and ANSI256 colors.
+	Index int `json:"index,omitempty"`
+	// Hex is the "#rrggbb" value of RGB colors.
+	Hex string `json:"hex,omitempty"`
+}
+
+// NewColorSpec returns the ColorSpec describing c. nil and NoColor are
+// described as ColorNone, a CustomColor by its RGB value.
+func NewColorSpec(c Color) ColorSpec {
+	switch v := resolveColor(c).(type) {
+	case ANSIColor:
+		return ColorSpec{Type: ColorANSI, Index: int(v)}
+	case ANSI256Color:
+		return ColorSpec{Type: ColorANSI256, Index: int(v)}
+	case RGBColor:
+		return ColorSpec{Type: ColorRGB, Hex: string(v)}
+	}
+	return ColorSpec{}
+}
+
+// Color returns the Color described by c, or nil for the default color.
+func (c ColorSpec) Color() Color {
+	switch c.Type {
+	case ColorANSI:
+		return ANSIColor(c.Index)
+	case ColorANSI256:
+		return ANSI256Color(c.Index)
+	case ColorRGB:
+		return RGBColor(c.Hex)
+	}
+	return nil
+}
+
+// StyleSpec is a plain, serializable description of the colors and
+// attributes of a Style. Unlike a Style, it is independent of a color
+// profile. Nil colors are the terminal's defaults.
+type StyleSpec struct {
+	Foreground *ColorSpec `json:"foreground,omitempty"`
+	Background *ColorSpec `json:"background,omitempty"`
+
+	Bold      bool `json:"bold,omitempty"`
+	Faint     bool `json:"faint,omitempty"`
+	Italic    bool `json:"italic,omitempty"`
+	Underline bool `json:"underline,omitempty"`
+	Blink     bool `json:"blink,omitempty"`
+	Reverse   bool `json:"reverse,omitempty"`
+	CrossOut  bool `json:"crossout,omitempty"`
+	Overline  bool `json:"overline,omitempty"`
+}
+
+// equal returns whether s and o describe the same style.
+func (s StyleSpec) equal(o StyleSpec) bool {
+	eq := func(a, b *ColorSpec) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return *a == *b
+	}
+	fs, bs := s.Foreground, s.Background
+	s.Foreground, s.Background = nil, nil
+	fo, bo := o.Foreground, o.Background
+	o.Foreground, o.Background = nil, nil
+	return s == o && eq(fs, fo) && eq(bs, bo)
+}
+
+// NewStyleSpec returns the StyleSpec describing the colors and attributes
+// of t. Its text and text policies are not part of the StyleSpec.
+func NewStyleSpec(t Style) StyleSpec {
+	var s StyleSpec
+	for _, seq := range t.styles {
+		if seq != "" {
+			s.applySGR(seq)
+		}
+	}
+	return s
+}
+
+// StyleFromSequence parses SGR parameters into a Style for the default
+// output. See Output.StyleFromSequence.
+func StyleFromSequence(params string) (Style, error) {
+	return output.StyleFromSequence(params)
+}
+
+// StyleFromSequence parses SGR parameters, as returned by Style.Sequence,
+// into a Style for o. Parsing the sequence of a Style results in the
+// normalized Style: the same colors and attributes, with colors applied
+// before attributes, each applied once, and overridden or reset parameters
+// dropped. Unknown parameters are ignored.
+func (o *Output) StyleFromSequence(params string) (Style, error) {
+	if !isSGRParams(params) {
+		return Style{}, fmt.Errorf("%w: SGR parameters %q", ErrInvalidSequence, params)
+	}
+
+	var s StyleSpec
+	if params != "" {
+		s.applySGR(params)
+	}
+	return s.Style(o.profile()), nil
+}
+
+// Style returns a Style for profile p rendering the colors and attributes
+// of s. Colors get converted to the profile.
+func (s StyleSpec) Style(p Profile) Style {
+	st := p.String()
+	if s.Foreground != nil {
+		if c := s.Foreground.Color(); c != nil {
+			st = st.Foreground(p.ConvertColor(c))
+		}
+	}
+	if s.Background != nil {
+		if c := s.Background.Color(); c != nil {
+			st = st.Background(p.ConvertColor(c))
+		}
+	}
+
+	for _, attr := range []struct {
+		set bool
+		seq string
+	}{
+		{s.Bold, BoldSeq},
+		{s.Faint, FaintSeq},
+		{s.Italic, ItalicSeq},
+		{s.Underline, UnderlineSeq},
+		{s.Blink, BlinkSeq},
+		{s.Reverse, ReverseSeq},
+		{s.CrossOut, CrossOutSeq},
+		{s.Overline, OverlineSeq},
+	} {
+		if attr.set {
+			st = st.add(attr.seq)
+		}
+	}
+	return st
+}
+
+// applySGR updates s with the parameters of an SGR sequence. Parameters it
+// doesn't know are ignored.
+//
+//nolint:mnd
+func (s *StyleSpec) applySGR(params string) {
+	if params == "" {
+		*s = StyleSpec{}
+		return
+	}
+
+	ps := strings.Split(params, ";")
+	for i := 0; i < len(ps); i++ {
+		// sub-parameters, as in 4:3 or 38:2::255:0:0
+		sub := strings.Split(ps[i], ":")
+		n, err := strconv.Atoi(sub[0])
+		if err != nil && sub[0] != "" {
+			continue
+		}
+
+		switch {
+		case n == 0:
+			*s = StyleSpec{}
+		case n == 1:
+			s.Bold = true
+		case n == 2:
+			s.Faint = true
+		case n == 3:
+			s.Italic = true
+		case n == 4:
+			s.Underline = len(sub) < 2 || sub[1] != "0"
+		case n == 5 || n == 6:
+			s.Blink = true
+		case n == 7:
+			s.Reverse = true
+		case n == 9:
+			s.CrossOut = true
+		case n == 21:
+			s.Underline = true
+		case n == 22:
+			s.Bold, s.Faint = false, false
+		case n == 23:
+			s.Italic = false
+		case n == 24:
+			s.Underline = false
+		case n == 25:
+			s.Blink = false
+		case n == 27:
+			s.Reverse = false
+		case n == 29:
+			s.CrossOut = false
+		case n == 53:
+			s.Overline = true
+		case n == 55:
+			s.Overline = false
+		case n >= 30 && n <= 37:
+			s.Foreground = &ColorSpec{Type: ColorANSI, Index: n - 30}
+		case n >= 90 && n <= 97:
+			s.Foreground = &ColorSpec{Type: ColorANSI, Index: n - 90 + 8}
+		case n >= 40 && n <= 47:
+			s.Background = &ColorSpec{Type: ColorANSI, Index: n - 40}
+		case n >= 100 && n <= 107:
+			s.Background = &ColorSpec{Type: ColorANSI, Index: n - 100 + 8}
+		case n == 39:
+			s.Foreground = nil
+		case n == 49:
+			s.Background = nil
+		case n == 58:
+			// underline colors aren't part of a StyleSpec, but their
+			// parameters must be skipped
+			if len(sub) == 1 {
+				i += extendedColorLen(ps[i+1:])
+			}
+		case n == 38 || n == 48:
+			var c *ColorSpec
+			if len(sub) > 1 {
+				c = extendedColorSpec(sub[1:], true)
+			} else {
+				c = extendedColorSpec(ps[i+1:], false)
+				i += extendedColorLen(ps[i+1:])
+			}
+			if c == nil {
+				continue
+			}
+			if n == 38 {
+				s.Foreground = c
+			} else {
+				s.Background = c
+			}
+		}
+	}
+}
+
+// extendedColorSpec parses the parameters following 38 or 48: 5;n for an
+// ANSI256 color or 2;r;g;b for an RGB color. In the colon form, the RGB
+// values may be preceded by a color space id.
+//
+//nolint:mnd
+func extendedColorSpec(ps []string, colon bool) *ColorSpec {
+	if len(ps) == 0 {
+		return nil
+	}
+	switch ps[0] {
+	case "5":
+		if len(ps) < 2 {
+			return nil
+		}
+		idx, err := strconv.Atoi(ps[1])
+		if err != nil || idx < 0 || idx > 255 {
+			return nil
+		}
+		return &ColorSpec{Type: ColorANSI256, Index: idx}
+	case "2":
+		rgb := ps[1:]
+		if colon && len(rgb) >= 4 {
+			rgb = rgb[1:]
+		}
+		if len(rgb) < 3 {
+			return nil
+		}
+		var v [3]int
+		for j := range v {
+			n, err := strconv.Atoi(rgb[j])
+			if err != nil || n < 0 || n > 255 {
+				return nil
+			}
+			v[j] = n
+		}
+		return &ColorSpec{Type: ColorRGB, Hex: fmt.Sprintf("#%02x%02x%02x", v[0], v[1], v[2])}
+	}
+	return nil
+}
+
+// extendedColorLen returns the number of semicolon-separated parameters
+// following 38 or 48 that belong to the color.
+//
+//nolint:mnd
+func extendedColorLen(ps []string) int {
+	if len(ps) == 0 {
+		return 0
+	}
+	n := 1
+	switch ps[0] {
+	case "5":
+		n = 2
+	case "2":
+		n = 4
+	}
+	if n > len(ps) {
+		n = len(ps)
+	}
+	return n
+}
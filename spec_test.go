@@ -0,0 +1,187 @@
+package termenv
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestColorSpec(t *testing.T) {
+	tests := []struct {
+		color Color
+		spec  ColorSpec
+	}{
+		{nil, ColorSpec{}},
+		{ANSIColor(9), ColorSpec{Type: ColorANSI, Index: 9}},
+		{ANSI256Color(200), ColorSpec{Type: ColorANSI256, Index: 200}},
+		{RGBColor("#abcdef"), ColorSpec{Type: ColorRGB, Hex: "#abcdef"}},
+	}
+
+	for _, test := range tests {
+		if got := NewColorSpec(test.color); got != test.spec {
+			t.Errorf("expected spec %+v for %v, got %+v", test.spec, test.color, got)
+		}
+		if got := test.spec.Color(); got != test.color {
+			t.Errorf("expected color %v for %+v, got %v", test.color, test.spec, got)
+		}
+	}
+	if got := NewColorSpec(NoColor{}); got != (ColorSpec{}) {
+		t.Errorf("expected NoColor to be ColorNone, got %+v", got)
+	}
+}
+
+func TestStyleSpec(t *testing.T) {
+	style := TrueColor.String().
+		Foreground(RGBColor("#ff0000")).
+		Background(ANSIColor(4)).
+		Bold().
+		Underline()
+
+	spec := NewStyleSpec(style)
+	exp := StyleSpec{
+		Foreground: &ColorSpec{Type: ColorRGB, Hex: "#ff0000"},
+		Background: &ColorSpec{Type: ColorANSI, Index: 4},
+		Bold:       true,
+		Underline:  true,
+	}
+	if !reflect.DeepEqual(spec, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, spec)
+	}
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); s != `{"foreground":{"type":3,"hex":"#ff0000"},"background":{"type":1,"index":4},"bold":true,"underline":true}` {
+		t.Errorf("unexpected JSON %s", s)
+	}
+	var decoded StyleSpec
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, spec) {
+		t.Errorf("expected %+v, got %+v", spec, decoded)
+	}
+
+	if got, want := spec.Style(TrueColor).Styled("x"), style.Styled("x"); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := spec.Style(ANSI).Styled("x"), "\x1b[91;44;1;4mx\x1b[0m"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := spec.Style(Ascii).Styled("x"); got != "x" {
+		t.Errorf("expected %q, got %q", "x", got)
+	}
+}
+
+func TestStyleFromSequence(t *testing.T) {
+	o := NewOutput(nil, WithProfile(TrueColor))
+
+	tests := []struct {
+		params   string
+		expected string
+	}{
+		{"", ""},
+		{"1;31", "31;1"},
+		{"1;1;22;3", "3"},
+		{"38;5;200;48:2::0:0:255;4:3", "38;5;200;48;2;0;0;255;4"},
+		{"31;32;0;44;99", "44"},
+	}
+	for _, test := range tests {
+		s, err := o.StyleFromSequence(test.params)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.params, err)
+			continue
+		}
+		if got := s.Sequence(); got != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.params, test.expected, got)
+		}
+	}
+
+	for _, params := range []string{"1;a", "\x1b[1m", "1m"} {
+		if _, err := o.StyleFromSequence(params); !errors.Is(err, ErrInvalidSequence) {
+			t.Errorf("%q: expected ErrInvalidSequence, got %v", params, err)
+		}
+	}
+}
+
+// randomStyle builds a Style for p by applying the operations encoded in ops.
+func randomStyle(p Profile, ops []byte) Style {
+	s := p.String()
+	for i := 0; i < len(ops); i++ {
+		op := ops[i]
+		var arg byte
+		if i+1 < len(ops) {
+			arg = ops[i+1]
+		}
+		switch op % 13 {
+		case 0:
+			s = s.Bold()
+		case 1:
+			s = s.Faint()
+		case 2:
+			s = s.Italic()
+		case 3:
+			s = s.Underline()
+		case 4:
+			s = s.Blink()
+		case 5:
+			s = s.Reverse()
+		case 6:
+			s = s.CrossOut()
+		case 7:
+			s = s.Overline()
+		case 8:
+			s = s.Foreground(ANSIColor(arg % 16))
+			i++
+		case 9:
+			s = s.Background(ANSI256Color(arg))
+			i++
+		case 10:
+			s = s.Foreground(p.ConvertColor(NewRGBColor(arg, arg*7, arg*13)))
+			i++
+		case 11:
+			s = s.Background(p.ConvertColor(NewRGBColor(arg*3, arg, arg*5)))
+			i++
+		case 12:
+			s = s.Foreground(NoColor{})
+		}
+	}
+	return s
+}
+
+func TestStyleFromSequenceRoundTrip(t *testing.T) {
+	for _, p := range []Profile{TrueColor, ANSI256, ANSI} {
+		o := NewOutput(nil, WithProfile(p))
+		normalize := func(s Style) string {
+			return NewStyleSpec(s).Style(p).Sequence()
+		}
+
+		// parse(render(x)) == normalize(x)
+		roundTrip := func(ops []byte) bool {
+			x := randomStyle(p, ops)
+			parsed, err := o.StyleFromSequence(x.Sequence())
+			return err == nil && parsed.Sequence() == normalize(x)
+		}
+		// parsing a normalized sequence is the identity
+		fixedPoint := func(ops []byte) bool {
+			n := normalize(randomStyle(p, ops))
+			parsed, err := o.StyleFromSequence(n)
+			return err == nil && parsed.Sequence() == n
+		}
+		// the rendered text carries the same style
+		styled := func(ops []byte) bool {
+			x := randomStyle(p, ops)
+			text := ParseStyledText(x.Styled("foo"))
+			return len(text) == 1 && text[0].Style.equal(NewStyleSpec(x))
+		}
+
+		for _, prop := range []interface{}{roundTrip, fixedPoint, styled} {
+			if err := quick.Check(prop, nil); err != nil {
+				t.Errorf("%s: %v", p.Name(), err)
+			}
+		}
+	}
+}
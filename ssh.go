@@ -0,0 +1,43 @@
+package termenv
+
+import (
+	"io"
+	"sort"
+)
+
+// clientEnviron is the environment an SSH client sent.
+type clientEnviron map[string]string
+
+func (e clientEnviron) Environ() []string {
+	env := make([]string, 0, len(e))
+	for k, v := range e {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+func (e clientEnviron) Getenv(key string) string {
+	return e[key]
+}
+
+// OutputFromSSH returns a new Output for a remote SSH client. env holds the
+// environment variables the client sent, ptyTerm the terminal type of the
+// allocated pty (empty if no pty was requested), and w the session to write
+// to.
+//
+// Detection runs against the client's environment instead of the server's:
+// ptyTerm takes precedence over a TERM variable in env, and the session is
+// treated as a terminal if and only if a pty was allocated.
+func OutputFromSSH(env map[string]string, ptyTerm string, w io.Writer, opts ...OutputOption) *Output {
+	e := make(clientEnviron, len(env)+1)
+	for k, v := range env {
+		e[k] = v
+	}
+	if ptyTerm != "" {
+		e["TERM"] = ptyTerm
+	}
+
+	opts = append([]OutputOption{WithEnvironment(e), WithTTY(ptyTerm != "")}, opts...)
+	return NewOutput(w, opts...)
+}
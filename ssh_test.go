@@ -0,0 +1,29 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOutputFromSSH(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		ptyTerm  string
+		expected Profile
+	}{
+		{"no pty", map[string]string{"COLORTERM": "truecolor"}, "", Ascii},
+		{"truecolor", map[string]string{"COLORTERM": "truecolor"}, "xterm-256color", TrueColor},
+		{"pty term wins", map[string]string{"TERM": "dumb"}, "xterm-256color", ANSI256},
+		{"no color", map[string]string{"NO_COLOR": "1"}, "xterm-kitty", Ascii},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := OutputFromSSH(test.env, test.ptyTerm, &bytes.Buffer{})
+			if o.Profile != test.expected {
+				t.Errorf("expected %s, got %s", test.expected.Name(), o.Profile.Name())
+			}
+		})
+	}
+}
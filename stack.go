@@ -0,0 +1,77 @@
+package termenv
+
+import (
+	"strings"
+)
+
+// Wrap renders inner with all applied styles, like Styled. Unlike Styled, it
+// re-applies the style after every full reset contained in inner, so the
+// style is restored once a nested styled fragment ends.
+func (t Style) Wrap(inner string) string {
+	inner = t.applyTextPolicies(inner)
+	if t.profile != Ascii && len(t.styles) > 0 {
+		if seq := t.sequence(); seq != "" {
+			open := CSI + seq + "m"
+			inner = strings.NewReplacer(
+				CSI+ResetSeq+"m", CSI+ResetSeq+"m"+open,
+				CSI+"m", CSI+"m"+open,
+			).Replace(inner)
+		}
+	}
+
+	if t.link != "" && inner != "" {
+		return t.linked(t.styled(inner))
+	}
+	return t.styled(inner)
+}
+
+// StyleStack tracks nested styles. Every Push returns the sequence opening a
+// style, every Pop the sequence closing it and restoring the styles below.
+// The zero value is an empty stack ready to use.
+type StyleStack struct {
+	styles []Style
+}
+
+// Push opens s on top of the current styles and returns the sequence to emit.
+func (st *StyleStack) Push(s Style) string {
+	st.styles = append(st.styles, s)
+	return st.open(s)
+}
+
+// Pop closes the innermost style and returns the sequence to emit, which
+// resets all attributes and re-applies the remaining styles. Popping an empty
+// stack returns an empty string.
+func (st *StyleStack) Pop() string {
+	if len(st.styles) == 0 {
+		return ""
+	}
+	top := st.styles[len(st.styles)-1]
+	st.styles = st.styles[:len(st.styles)-1]
+	if st.open(top) == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(CSI + ResetSeq + "m")
+	for _, s := range st.styles {
+		b.WriteString(st.open(s))
+	}
+	return b.String()
+}
+
+// Len returns the number of styles on the stack.
+func (st *StyleStack) Len() int {
+	return len(st.styles)
+}
+
+// open returns the sequence applying s.
+func (st *StyleStack) open(s Style) string {
+	if s.profile == Ascii || len(s.styles) == 0 {
+		return ""
+	}
+	seq := s.sequence()
+	if seq == "" {
+		return ""
+	}
+	return CSI + seq + "m"
+}
@@ -0,0 +1,39 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestStyleWrap(t *testing.T) {
+	outer := String().Foreground(ANSI.Color("1"))
+	inner := String().Bold()
+
+	exp := "\x1b[31ma \x1b[1mb\x1b[0m\x1b[31m c\x1b[0m"
+	if got := outer.Wrap("a " + inner.Styled("b") + " c"); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	if got := Ascii.String().Bold().Wrap("foo"); got != "foo" {
+		t.Errorf("expected %q, got %q", "foo", got)
+	}
+}
+
+func TestStyleStack(t *testing.T) {
+	var st StyleStack
+
+	s := st.Push(String().Foreground(ANSI.Color("1"))) + "a"
+	s += st.Push(String().Bold()) + "b"
+	s += st.Pop() + "c"
+	s += st.Pop()
+
+	exp := "\x1b[31ma\x1b[1mb\x1b[0m\x1b[31mc\x1b[0m"
+	if s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+	if st.Len() != 0 {
+		t.Errorf("expected empty stack, got %d styles", st.Len())
+	}
+	if st.Pop() != "" {
+		t.Error("expected popping an empty stack to return nothing")
+	}
+}
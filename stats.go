@@ -0,0 +1,116 @@
+package termenv
+
+import (
+	"io"
+	"sync"
+)
+
+// Stats are counters of everything written to an Output.
+type Stats struct {
+	// Bytes is the number of bytes written.
+	Bytes int64
+	// Writes is the number of write calls.
+	Writes int64
+	// SGRSequences is the number of SGR (style) sequences written.
+	SGRSequences int64
+	// Resets is the number of SGR sequences resetting all attributes.
+	Resets int64
+}
+
+// WithStats returns a new OutputOption counting the bytes, writes, and
+// sequences written to the output. The counters can be read with Stats, e.g.
+// to measure the effect of rendering optimizations.
+func WithStats() OutputOption {
+	return func(o *Output) {
+		o.stats = &statsWriter{w: o.w}
+		o.w = o.stats
+	}
+}
+
+// Stats returns the output's counters. All counters are zero unless the
+// output was created with WithStats.
+func (o *Output) Stats() Stats {
+	if o.stats == nil {
+		return Stats{}
+	}
+	o.stats.mu.Lock()
+	defer o.stats.mu.Unlock()
+	return o.stats.stats
+}
+
+// ResetStats sets all of the output's counters to zero.
+func (o *Output) ResetStats() {
+	if o.stats == nil {
+		return
+	}
+	o.stats.mu.Lock()
+	defer o.stats.mu.Unlock()
+	o.stats.stats = Stats{}
+}
+
+// statsWriter counts everything written to w.
+type statsWriter struct {
+	w io.Writer
+
+	mu    sync.Mutex
+	stats Stats
+
+	// state of the sequence scanner, kept across writes
+	state  int
+	params []byte
+}
+
+// sequence scanner states.
+const (
+	scanText = iota
+	scanEsc
+	scanCSI
+)
+
+func (sw *statsWriter) unwrap() io.Writer {
+	return sw.w
+}
+
+func (sw *statsWriter) Write(p []byte) (int, error) {
+	n, err := sw.w.Write(p)
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.stats.Writes++
+	sw.stats.Bytes += int64(n)
+	sw.scan(p[:n])
+
+	return n, err //nolint:wrapcheck
+}
+
+// scan counts the SGR sequences in p.
+func (sw *statsWriter) scan(p []byte) {
+	for _, b := range p {
+		switch sw.state {
+		case scanText:
+			if b == ESC {
+				sw.state = scanEsc
+			}
+		case scanEsc:
+			sw.state = scanText
+			if b == '[' {
+				sw.state = scanCSI
+				sw.params = sw.params[:0]
+			}
+		case scanCSI:
+			if b >= 0x40 && b <= 0x7e { //nolint:mnd
+				sw.state = scanText
+				if b == 'm' {
+					sw.stats.SGRSequences++
+					if p := string(sw.params); p == "" || p == ResetSeq {
+						sw.stats.Resets++
+					}
+				}
+				continue
+			}
+			if len(sw.params) < maxCSILen {
+				sw.params = append(sw.params, b)
+			}
+		}
+	}
+}
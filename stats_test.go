@@ -0,0 +1,40 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	o := tempOutput(t)
+	WithStats()(o)
+
+	_, _ = o.WriteString(o.String("foo").Bold().String())
+	_, _ = o.WriteString("\x1b[31")
+	_, _ = o.WriteString("mbar\x1b[m")
+	o.ClearScreen()
+
+	exp := Stats{
+		Bytes:        int64(len("\x1b[1mfoo\x1b[0m\x1b[31mbar\x1b[m\x1b[2J\x1b[1;1H")),
+		Writes:       5,
+		SGRSequences: 4,
+		Resets:       2,
+	}
+	if s := o.Stats(); s != exp {
+		t.Errorf("expected %+v, got %+v", exp, s)
+	}
+
+	o.ResetStats()
+	if s := o.Stats(); s != (Stats{}) {
+		t.Errorf("expected zero stats, got %+v", s)
+	}
+	verify(t, o, "\x1b[1mfoo\x1b[0m\x1b[31mbar\x1b[m\x1b[2J\x1b[1;1H")
+}
+
+func TestStatsDisabled(t *testing.T) {
+	o := tempOutput(t)
+	o.Reset()
+	if s := o.Stats(); s != (Stats{}) {
+		t.Errorf("expected zero stats, got %+v", s)
+	}
+	verify(t, o, "\x1b[0m")
+}
@@ -0,0 +1,174 @@
+package termenv
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// StripWriter removes escape sequences from everything written to it, e.g.
+// to store the output of a program as plain text. Like the other streaming
+// writers of this package, it uses bounded memory: only an escape sequence
+// split across multiple writes is held back until it is complete.
+type StripWriter struct {
+	w       io.Writer
+	pending string
+}
+
+// NewStripWriter returns a new StripWriter writing to w.
+func NewStripWriter(w io.Writer) *StripWriter {
+	return &StripWriter{w: w}
+}
+
+// Write writes p without its escape sequences to the underlying writer.
+// Malformed sequences are written as text.
+func (s *StripWriter) Write(p []byte) (int, error) {
+	var b strings.Builder
+	str := s.pending + string(p)
+	s.pending = ""
+
+	start := 0
+	for i := 0; i < len(str); {
+		if str[i] != ESC {
+			i++
+			continue
+		}
+
+		_, n, err := ParseSequence(str[i:])
+		if err != nil {
+			if isIncompleteSequence(str[i:]) {
+				b.WriteString(str[start:i])
+				s.pending = str[i:]
+				start = len(str)
+				break
+			}
+			i++
+			continue
+		}
+
+		b.WriteString(str[start:i])
+		i += n
+		start = i
+	}
+	b.WriteString(str[start:])
+
+	if _, err := io.WriteString(s.w, b.String()); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	return len(p), nil
+}
+
+// Flush writes a held back, incomplete escape sequence to the underlying
+// writer. It is malformed at the end of the input, so it's written as text,
+// as by Write.
+func (s *StripWriter) Flush() error {
+	if s.pending == "" {
+		return nil
+	}
+	_, err := io.WriteString(s.w, Strip(s.pending))
+	s.pending = ""
+	return err //nolint:wrapcheck
+}
+
+// Flush writes a held back, incomplete escape sequence to the underlying
+// writer, e.g. at the end of the input.
+func (e *EmulationWriter) Flush() error {
+	if e.pending == "" {
+		return nil
+	}
+	_, err := io.WriteString(e.w, e.pending)
+	e.pending = ""
+	return err //nolint:wrapcheck
+}
+
+// StripStream copies r to w without escape sequences, holding at most one
+// escape sequence in memory. It returns the number of bytes read from r.
+func StripStream(w io.Writer, r io.Reader) (int64, error) {
+	sw := NewStripWriter(w)
+	n, err := io.Copy(sw, r)
+	if err != nil {
+		return n, err //nolint:wrapcheck
+	}
+	return n, sw.Flush()
+}
+
+// ConvertStream copies r to w, re-encoding all colors for profile p. It
+// holds at most one escape sequence in memory, and returns the number of
+// bytes read from r.
+func (p Profile) ConvertStream(w io.Writer, r io.Reader) (int64, error) {
+	ew := NewEmulationWriter(w, p, false)
+	n, err := io.Copy(ew, r)
+	if err != nil {
+		return n, err //nolint:wrapcheck
+	}
+	return n, ew.Flush()
+}
+
+// StreamWidth returns the number of cells the widest line read from r
+// occupies, ignoring escape sequences. Lines don't need to fit in memory.
+func StreamWidth(r io.Reader) (int, error) {
+	ww := &widthWriter{}
+	if _, err := StripStream(ww, r); err != nil {
+		return 0, err
+	}
+	ww.flush()
+	return ww.max, nil
+}
+
+// widthWriter measures the lines of plain text written to it. The last
+// grapheme cluster of each write is held back, as the next write may
+// continue it.
+type widthWriter struct {
+	pending string
+	width   int
+	max     int
+}
+
+func (w *widthWriter) Write(p []byte) (int, error) {
+	s := w.pending + string(p)
+	w.pending = w.measure(s, false)
+	return len(p), nil
+}
+
+func (w *widthWriter) flush() {
+	w.measure(w.pending, true)
+	w.pending = ""
+}
+
+// measure adds the widths of the grapheme clusters of s to the current line
+// and returns the rest of s that may be continued by further text, unless
+// final is set.
+func (w *widthWriter) measure(s string, final bool) string {
+	// a rune split across writes
+	var tail string
+	if !final {
+		for i := len(s) - 1; i >= 0 && i >= len(s)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(s[i]) {
+				if !utf8.FullRuneInString(s[i:]) {
+					s, tail = s[:i], s[i:]
+				}
+				break
+			}
+		}
+	}
+
+	state := -1
+	for len(s) > 0 {
+		cluster, rest, width, newState := uniseg.FirstGraphemeClusterInString(s, state)
+		if !final && rest == "" {
+			return s + tail
+		}
+		if cluster == "\n" || cluster == "\r\n" {
+			w.width = 0
+		} else {
+			w.width += width
+		}
+		if w.width > w.max {
+			w.max = w.width
+		}
+		s, state = rest, newState
+	}
+	return tail
+}
@@ -0,0 +1,74 @@
+package termenv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStripStream(t *testing.T) {
+	for _, s := range fuzzCorpus(t, "FuzzStrip") {
+		var buf bytes.Buffer
+		n, err := StripStream(&buf, iotest.OneByteReader(strings.NewReader(s)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(s)) {
+			t.Errorf("%q: expected to read %d bytes, got %d", s, len(s), n)
+		}
+		if got := buf.String(); got != Strip(s) {
+			t.Errorf("%q: expected %q, got %q", s, Strip(s), got)
+		}
+	}
+}
+
+func TestConvertStream(t *testing.T) {
+	in := "\x1b[38;2;255;0;0mred\x1b[0m \x1b[48;5;21mblue\x1b[0m\x1b[1"
+	var buf bytes.Buffer
+	if _, err := ANSI.ConvertStream(&buf, iotest.HalfReader(strings.NewReader(in))); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := buf.String(), "\x1b[91mred\x1b[0m \x1b[104mblue\x1b[0m\x1b[1"; got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
+
+func TestStreamWidth(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected int
+	}{
+		{"", 0},
+		{"foo", 3},
+		{"\x1b[1mfoo\x1b[0m\nfoobar\r\nfo", 6},
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"日本語\n\U0001f44d\U0001f3fd é", 6},
+	}
+	for _, test := range tests {
+		got, err := StreamWidth(iotest.OneByteReader(strings.NewReader(test.s)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.expected {
+			t.Errorf("%q: expected width %d, got %d", test.s, test.expected, got)
+		}
+	}
+
+	for _, s := range fuzzCorpus(t, "FuzzStrip") {
+		if strings.ContainsAny(s, "\r\n") {
+			continue
+		}
+		got, err := StreamWidth(iotest.OneByteReader(strings.NewReader(s)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := StringWidth(s); got != exp {
+			t.Errorf("%q: expected width %d, got %d", s, exp, got)
+		}
+	}
+
+	if _, err := StreamWidth(iotest.ErrReader(iotest.ErrTimeout)); err != iotest.ErrTimeout {
+		t.Errorf("expected the read error, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Strip removes all escape sequences from s, e.g. CSI sequences like SGR
+// styles and cursor movements, OSC sequences like hyperlinks, and DCS
+// sequences. Malformed sequences are kept as text. For large inputs, see
+// StripStream.
+func Strip(s string) string {
+	if !strings.ContainsRune(s, ESC) {
+		return s
+	}
+	plain, _, _ := splitWrapText(s)
+	return plain
+}
+
+// StringWidth returns the number of cells s occupies in a terminal,
+// ignoring escape sequences, so already styled text can be measured. Unlike
+// Style.Width, which measures the raw string, it is meant for pre-rendered
+// content. Newlines don't occupy any cells, so text spanning multiple lines
+// should be measured line by line.
+func StringWidth(s string) int {
+	if !strings.ContainsRune(s, ESC) {
+		return uniseg.StringWidth(s)
+	}
+
+	var b strings.Builder
+	walkText(s, func(text string) {
+		b.WriteString(text)
+	}, func(string) {})
+	return uniseg.StringWidth(b.String())
+}
@@ -0,0 +1,29 @@
+package termenv
+
+import "testing"
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+		width    int
+	}{
+		{"", "", 0},
+		{"foo", "foo", 3},
+		{TrueColor.String("foo").Bold().Foreground(RGBColor("#ff0000")).String(), "foo", 3},
+		{"\x1b[2J\x1b[1;1Hfoo\x1b[K", "foo", 3},
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link", 4},
+		{"\x1b]0;title\afoo", "foo", 3},
+		{"\x1bP+q544e\x1b\\foo", "foo", 3},
+		{"\x1b[1m日本語\x1b[0m", "日本語", 6},
+		{"foo\x1b[1", "foo\x1b[1", 5},
+	}
+	for _, test := range tests {
+		if got := Strip(test.in); got != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.in, test.expected, got)
+		}
+		if got := StringWidth(test.in); got != test.width {
+			t.Errorf("%q: expected width %d, got %d", test.in, test.width, got)
+		}
+	}
+}
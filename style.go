@@ -1,7 +1,9 @@
 package termenv
 
 import (
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/rivo/uniseg"
 )
@@ -24,6 +26,27 @@ type Style struct {
 	profile Profile
 	string
 	styles []string
+	seq    *styleSeq
+	scoped bool
+	dedup  bool
+	lines  lineMode
+	tabs   int
+
+	bidi      BidiPolicy
+	combining CombiningPolicy
+
+	meta map[string]interface{}
+	link string
+
+	// out resolves adaptive colors, if the Style was created for an output
+	out *Output
+}
+
+// styleSeq caches the joined SGR parameters of a Style. Every modification
+// of a Style replaces it, so copies of a Style can safely share it.
+type styleSeq struct {
+	once sync.Once
+	s    string
 }
 
 // String returns a new Style.
@@ -34,139 +57,248 @@ func String(s ...string) Style {
 	}
 }
 
+// StringFor returns a new Style for rendering to out. Unlike String, which
+// always renders ANSI sequences, it only renders what out supports, so
+// styled text written to a pipe or file stays plain.
+func StringFor(out *Output, s ...string) Style {
+	return ANSI.StringFor(out, s...)
+}
+
 func (t Style) String() string {
 	return t.Styled(t.string)
 }
 
 // Styled renders s with all applied styles.
 func (t Style) Styled(s string) string {
-	if t.profile == Ascii {
-		return s
+	s = t.applyTextPolicies(s)
+	if t.link != "" && s != "" {
+		return t.linked(t.styled(s))
 	}
-	if len(t.styles) == 0 {
+	return t.styled(s)
+}
+
+// styled wraps s in the style's sequences.
+func (t Style) styled(s string) string {
+	if s == "" || t.profile == Ascii || len(t.styles) == 0 {
 		return s
 	}
 
-	var (
-		stylesLen = len(t.styles)
-		n         int
-	)
-	switch stylesLen {
-	case 0:
+	seq := t.sequence()
+	if seq == "" {
 		return s
-	case 1:
-		if t.styles[0] == "" {
-			return s
+	}
+	if t.lines != lineModeNone && strings.Contains(s, "\n") {
+		return t.styledLines(seq, s)
+	}
+	return CSI + seq + "m" + s + CSI + t.reset() + "m"
+}
+
+// Sequence returns the SGR parameters t renders before its text, e.g. "1;31",
+// or an empty string for a plain style. StyleFromSequence parses them back
+// into a Style.
+func (t Style) Sequence() string {
+	return t.sequence()
+}
+
+// sequence returns the SGR parameters of all applied styles, joined by
+// semicolons. The result is computed once and reused by all copies of t.
+func (t Style) sequence() string {
+	// a single style needs no joining
+	if len(t.styles) == 1 {
+		return t.styles[0]
+	}
+	if t.seq == nil {
+		return strings.Join(t.styles, ";")
+	}
+
+	t.seq.once.Do(func() {
+		t.seq.s = strings.Join(t.styles, ";")
+	})
+	return t.seq.s
+}
+
+// add returns a copy of t with seq appended to its styles. The styles are
+// copied, so Styles derived from the same base don't overwrite each other.
+//
+// If t deduplicates its styles, styles already applied aren't added again,
+// and a color replaces the previous color of the same layer.
+func (t Style) add(seq string) Style {
+	if !t.dedup {
+		styles := make([]string, len(t.styles), len(t.styles)+1)
+		copy(styles, t.styles)
+		t.styles = append(styles, seq)
+		t.seq = &styleSeq{}
+		return t
+	}
+
+	layer := styleLayer(seq)
+	styles := make([]string, 0, len(t.styles)+1)
+	for _, s := range t.styles {
+		if s == seq {
+			return t
 		}
-		n = len(t.styles[0])
-	default:
-		n = (stylesLen - 1) // calcs bytes of the ascii seperator we'll use (semicolon, 1 byte)
-		for i := 0; i < stylesLen; i++ {
-			n += len(t.styles[i])
+		if layer != layerNone && styleLayer(s) == layer {
+			continue
 		}
+		styles = append(styles, s)
 	}
+	t.styles = append(styles, seq)
+	t.seq = &styleSeq{}
+	return t
+}
 
-	buf := make([]byte, 0, len(CSI)*2+n+len(s)+len(ResetSeq)+2)
-	buf = append(buf, CSI...)
+// Deduplicate makes the Style skip attributes it already applies, and lets
+// a color replace the previous color of the same layer, so e.g.
+// Bold().Bold() renders a single bold parameter and a later Foreground
+// overrides an earlier one instead of being appended to it. The styles
+// applied so far get deduplicated as well.
+func (t Style) Deduplicate() Style {
+	styles := t.styles
+	t.styles = nil
+	t.dedup = true
+	for _, s := range styles {
+		t = t.add(s)
+	}
+	t.seq = &styleSeq{}
+	return t
+}
 
-	buf = append(buf, t.styles[0]...)
-	for i := 1; i < len(t.styles); i++ {
-		buf = append(buf, ";"...)
-		buf = append(buf, t.styles[i]...)
+// Layers of SGR sequences. A sequence replaces earlier ones of its layer.
+const (
+	layerNone = iota
+	layerForeground
+	layerBackground
+	layerUnderline
+	layerUnderlineColor
+)
+
+// styleLayer returns whether seq consists of a single foreground,
+// background or underline color, or a single underline. Sequences setting
+// other attributes as well, like "1;31", are layerNone.
+//
+//nolint:mnd
+func styleLayer(seq string) int {
+	ps := strings.Split(seq, ";")
+	sub := strings.Split(ps[0], ":")
+	n, err := strconv.Atoi(sub[0])
+	if err != nil {
+		return layerNone
 	}
 
-	buf = append(buf, "m"...)
-	buf = append(buf, s...)
-	buf = append(buf, CSI...)
-	buf = append(buf, ResetSeq...)
-	buf = append(buf, "m"...)
-	return string(buf)
+	switch {
+	case n == 38 || n == 48 || n == 58:
+		if len(sub) == 1 && 1+extendedColorLen(ps[1:]) != len(ps) {
+			return layerNone
+		}
+		if len(sub) > 1 && len(ps) > 1 {
+			return layerNone
+		}
+		switch n {
+		case 38:
+			return layerForeground
+		case 48:
+			return layerBackground
+		}
+		return layerUnderlineColor
+	case len(ps) > 1:
+		return layerNone
+	case n == 4:
+		return layerUnderline
+	case len(sub) > 1:
+		return layerNone
+	case n >= 30 && n <= 37, n >= 90 && n <= 97:
+		return layerForeground
+	case n >= 40 && n <= 47, n >= 100 && n <= 107:
+		return layerBackground
+	}
+	return layerNone
 }
 
-// Foreground sets a foreground color.
+// Foreground sets a foreground color. nil and NoColor leave t unchanged.
 func (t Style) Foreground(c Color) Style {
 	if c == nil {
 		return t
 	}
+	c = t.adapt(c)
 
 	var (
 		rgb RGBColor
 		yes bool
 	)
 	if rgb, yes = c.(RGBColor); !yes {
-		t.styles = append(t.styles, c.Sequence(false))
-		return t
+		return t.addColor(c.Sequence(false))
 	}
 
 	cache := GetANSICache()
 	if s, present := cache.Get(rgb); present {
-		t.styles = append(t.styles, s.(string))
-	} else {
-		seq := rgb.Sequence(false)
-		t.styles = append(t.styles, seq)
-		cache.Put(rgb, seq)
+		return t.addColor(s)
 	}
 
-	return t
+	seq := rgb.Sequence(false)
+	cache.Put(rgb, seq)
+	return t.addColor(seq)
 }
 
-// Background sets a background color.
+// Background sets a background color. nil and NoColor leave t unchanged.
 func (t Style) Background(c Color) Style {
-	if c != nil {
-		t.styles = append(t.styles, c.Sequence(true))
+	if c == nil {
+		return t
 	}
-	return t
+	return t.addColor(t.adapt(c).Sequence(true))
+}
+
+// addColor adds the sequence of a color. Colors without a sequence, like
+// NoColor, are skipped: an empty parameter would reset all styles.
+func (t Style) addColor(seq string) Style {
+	if seq == "" {
+		return t
+	}
+	return t.add(seq)
 }
 
 // Bold enables bold rendering.
 func (t Style) Bold() Style {
-	t.styles = append(t.styles, BoldSeq)
-	return t
+	return t.add(BoldSeq)
 }
 
 // Faint enables faint rendering.
 func (t Style) Faint() Style {
-	t.styles = append(t.styles, FaintSeq)
-	return t
+	return t.add(FaintSeq)
 }
 
 // Italic enables italic rendering.
 func (t Style) Italic() Style {
-	t.styles = append(t.styles, ItalicSeq)
-	return t
+	return t.add(ItalicSeq)
 }
 
 // Underline enables underline rendering.
 func (t Style) Underline() Style {
-	t.styles = append(t.styles, UnderlineSeq)
-	return t
+	return t.add(UnderlineSeq)
 }
 
 // Overline enables overline rendering.
 func (t Style) Overline() Style {
-	t.styles = append(t.styles, OverlineSeq)
-	return t
+	return t.add(OverlineSeq)
 }
 
 // Blink enables blink mode.
 func (t Style) Blink() Style {
-	t.styles = append(t.styles, BlinkSeq)
-	return t
+	return t.add(BlinkSeq)
 }
 
 // Reverse enables reverse color mode.
 func (t Style) Reverse() Style {
-	t.styles = append(t.styles, ReverseSeq)
-	return t
+	return t.add(ReverseSeq)
 }
 
 // CrossOut enables crossed-out rendering.
 func (t Style) CrossOut() Style {
-	t.styles = append(t.styles, CrossOutSeq)
-	return t
+	return t.add(CrossOutSeq)
 }
 
-// Width returns the width required to print all runes in Style.
+// Width returns the width required to print all runes in Style. Escape
+// sequences in the string are measured as text; see StringWidth for
+// measuring already styled text.
 func (t Style) Width() int {
-	return uniseg.StringWidth(t.string)
+	return uniseg.StringWidth(t.applyTextPolicies(t.string))
 }
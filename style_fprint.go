@@ -0,0 +1,39 @@
+package termenv
+
+import (
+	"fmt"
+	"io"
+)
+
+// Fprint formats using the default formats for its operands and writes the
+// styled result to w, rendered like Styled in a single write. It returns the
+// number of bytes written and any write error encountered.
+func (t Style) Fprint(w io.Writer, a ...interface{}) (int, error) {
+	return t.fprint(w, fmt.Sprint(a...), "")
+}
+
+// Fprintf formats according to a format specifier and writes the styled
+// result to w. It returns the number of bytes written and any write error
+// encountered.
+func (t Style) Fprintf(w io.Writer, format string, a ...interface{}) (int, error) {
+	return t.fprint(w, fmt.Sprintf(format, a...), "")
+}
+
+// Fprintln formats using the default formats for its operands and writes the
+// styled result to w, followed by a newline. The newline is written after the
+// reset sequence. It returns the number of bytes written and any write error
+// encountered.
+func (t Style) Fprintln(w io.Writer, a ...interface{}) (int, error) {
+	s := fmt.Sprintln(a...)
+	return t.fprint(w, s[:len(s)-1], "\n")
+}
+
+// fprint renders s followed by the unstyled suffix into a pooled buffer and
+// writes it to w.
+func (t Style) fprint(w io.Writer, s, suffix string) (int, error) {
+	b := renderBuffers.Get().(*[]byte)
+	*b = append(t.AppendTo((*b)[:0], s), suffix...)
+	n, err := w.Write(*b)
+	renderBuffers.Put(b)
+	return n, err //nolint:wrapcheck
+}
@@ -0,0 +1,84 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStyleFprint(t *testing.T) {
+	s := String().Bold()
+
+	var buf bytes.Buffer
+	n, err := s.Fprint(&buf, "foo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := s.Styled("foo1"); buf.String() != exp || n != len(exp) {
+		t.Errorf("expected %q (%d bytes), got %q (%d bytes)", exp, len(exp), buf.String(), n)
+	}
+
+	buf.Reset()
+	_, _ = s.Fprintf(&buf, "%s=%d", "foo", 1)
+	if exp := s.Styled("foo=1"); buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+
+	buf.Reset()
+	_, _ = s.Fprintln(&buf, "foo", 1)
+	if exp := s.Styled("foo 1") + "\n"; buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+
+	buf.Reset()
+	_, _ = Ascii.String().Bold().Fprint(&buf, "foo")
+	if buf.String() != "foo" {
+		t.Errorf("expected %q, got %q", "foo", buf.String())
+	}
+}
+
+func TestStyleFprintOutput(t *testing.T) {
+	o := tempOutput(t)
+	_, _ = o.String().Italic().Fprint(o, "foo")
+	verify(t, o, "\x1b[3mfoo\x1b[0m")
+}
+
+func TestStyleFprintPolicies(t *testing.T) {
+	s := String().Bold()
+	tests := []struct {
+		name  string
+		style Style
+		in    string
+	}{
+		{"empty", s, ""},
+		{"tabs", s.TabWidth(4), "a\tb"},
+		{"lines", s.Lines(), "foo\nbarbaz"},
+		{"block", s.Block(), "foo\nbarbaz"},
+		{"bidi", s.Bidi(BidiStrip), "a\u202eb"},
+		{"combining", s.Combining(CombiningStrip), "e\u0301"},
+		{"hyperlink", s.Hyperlink("https://example.com"), "foo"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exp := test.style.Styled(test.in)
+
+			var buf bytes.Buffer
+			if n, err := test.style.Fprint(&buf, test.in); err != nil || n != len(exp) {
+				t.Errorf("unexpected write result: %d, %v", n, err)
+			}
+			if buf.String() != exp {
+				t.Errorf("Fprint: expected %q, got %q", exp, buf.String())
+			}
+
+			buf.Reset()
+			_, _ = test.style.Fprintln(&buf, test.in)
+			if buf.String() != exp+"\n" {
+				t.Errorf("Fprintln: expected %q, got %q", exp+"\n", buf.String())
+			}
+
+			if got := test.style.Wrap(test.in); got != exp {
+				t.Errorf("Wrap: expected %q, got %q", exp, got)
+			}
+		})
+	}
+}
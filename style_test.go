@@ -26,3 +26,132 @@ func TestStyleWidth(t *testing.T) {
 		t.Errorf("Expected width of 11, got %d", s.Width())
 	}
 }
+
+func TestStyled(t *testing.T) {
+	s := String().Bold()
+	tests := []struct {
+		style    Style
+		in       string
+		expected string
+	}{
+		{s, "", ""},
+		{String(), "foo", "foo"},
+		{s, "foo", "\x1b[1mfoo\x1b[0m"},
+		{s.Italic(), "foo", "\x1b[1;3mfoo\x1b[0m"},
+		{Ascii.String().Bold(), "foo", "foo"},
+	}
+
+	for _, test := range tests {
+		if got := test.style.Styled(test.in); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func BenchmarkStyled(b *testing.B) {
+	single := String().Bold()
+	multi := single.Italic().Foreground(TrueColor.Color("#abcdef"))
+
+	b.Run("single", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = single.Styled("token")
+		}
+	})
+	b.Run("multi", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = multi.Styled("token")
+		}
+	})
+}
+
+func TestStyleCopyOnWrite(t *testing.T) {
+	base := String().Bold().Italic()
+	_ = base.Styled("foo")
+
+	a := base.Underline()
+	b := base.CrossOut()
+
+	for _, test := range []struct {
+		style    Style
+		expected string
+	}{
+		{base, "\x1b[1;3mfoo\x1b[0m"},
+		{a, "\x1b[1;3;4mfoo\x1b[0m"},
+		{b, "\x1b[1;3;9mfoo\x1b[0m"},
+	} {
+		// render twice to hit the cached sequence
+		for i := 0; i < 2; i++ {
+			if got := test.style.Styled("foo"); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		}
+	}
+}
+
+func TestStringFor(t *testing.T) {
+	piped := NewOutput(nil, WithProfile(Ascii))
+	if s := StringFor(piped, "foo").Bold().String(); s != "foo" {
+		t.Errorf("expected %q, got %q", "foo", s)
+	}
+
+	term := NewOutput(nil, WithProfile(TrueColor))
+	exp := "\x1b[1mfoo\x1b[0m"
+	if s := StringFor(term, "foo").Bold().String(); s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+
+	if s := TrueColor.StringFor(NewOutput(nil, WithProfile(ANSI256))); s.profile != ANSI256 {
+		t.Errorf("expected profile %s, got %s", ANSI256.Name(), s.profile.Name())
+	}
+}
+
+func TestStyleDedup(t *testing.T) {
+	s := TrueColor.String().Deduplicate()
+	tests := []struct {
+		style    Style
+		expected string
+	}{
+		{s.Bold().Bold(), "1"},
+		{s.Bold().Italic().Bold(), "1;3"},
+		{s.Foreground(ANSIColor(1)).Foreground(ANSIColor(2)), "32"},
+		{s.Foreground(ANSIColor(1)).Bold().Foreground(RGBColor("#0000ff")), "1;38;2;0;0;255"},
+		{s.Foreground(ANSI256Color(100)).Background(ANSIColor(9)).Foreground(ANSIColor(3)), "101;33"},
+		{s.Background(RGBColor("#ff0000")).Background(ANSI256Color(17)), "48;5;17"},
+		{s.Foreground(ANSIColor(1)).Foreground(ANSIColor(2)).Foreground(ANSIColor(1)), "31"},
+		{s.add("1;31").Foreground(ANSIColor(2)), "1;31;32"},
+		// the styles applied so far get deduplicated
+		{TrueColor.String().Bold().Foreground(ANSIColor(1)).Bold().Foreground(ANSIColor(2)).Deduplicate(), "1;32"},
+		// without Deduplicate, all styles are rendered in order
+		{TrueColor.String().Bold().Bold(), "1;1"},
+		{TrueColor.String().Foreground(ANSIColor(1)).Foreground(ANSIColor(2)), "31;32"},
+	}
+	for i, test := range tests {
+		if got := test.style.Sequence(); got != test.expected {
+			t.Errorf("#%d: expected %q, got %q", i, test.expected, got)
+		}
+	}
+
+	for seq, layer := range map[string]int{
+		"31":          layerForeground,
+		"97":          layerForeground,
+		"38;5;1":      layerForeground,
+		"38:2::1:2:3": layerForeground,
+		"48;2;1;2;3":  layerBackground,
+		"104":         layerBackground,
+		"1":           layerNone,
+		"1;31":        layerNone,
+		"31;1":        layerNone,
+		"38;5;1;1":    layerNone,
+		"38:5:1;1":    layerNone,
+		"4":           layerUnderline,
+		"4:3":         layerUnderline,
+		"58;5;1":      layerUnderlineColor,
+		"58:2::1:2:3": layerUnderlineColor,
+		"58;5;1;4":    layerNone,
+		"":            layerNone,
+	} {
+		if got := styleLayer(seq); got != layer {
+			t.Errorf("%q: expected layer %d, got %d", seq, layer, got)
+		}
+	}
+}
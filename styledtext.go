@@ -0,0 +1,245 @@
+package termenv
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrInvalidStyledText is returned when decoding malformed StyledText.
+var ErrInvalidStyledText = errors.New("invalid styled text")
+
+// styledTextVersion is the version of the binary encoding of StyledText.
+const styledTextVersion = 1
+
+// Segment is a run of text sharing a style and hyperlink.
+type Segment struct {
+	Text  string    `json:"text"`
+	Style StyleSpec `json:"style"`
+	Link  string    `json:"link,omitempty"`
+}
+
+// StyledText is a portable representation of styled text, independent of
+// any color profile. It can be encoded as JSON or, more compactly, with
+// MarshalBinary, e.g. to send styled content from a rendering server to
+// clients, which render it for their own profile.
+type StyledText []Segment
+
+// ParseStyledText splits the rendered string s into segments. SGR
+// sequences and OSC 8 hyperlinks are captured; all other escape sequences
+// are dropped.
+func ParseStyledText(s string) StyledText {
+	var (
+		text  StyledText
+		style StyleSpec
+		link  string
+	)
+	walkText(s, func(t string) {
+		if n := len(text); n > 0 && text[n-1].Link == link && text[n-1].Style.equal(style) {
+			text[n-1].Text += t
+			return
+		}
+		text = append(text, Segment{Text: t, Style: style, Link: link})
+	}, func(seq string) {
+		sq, _, err := ParseSequence(seq)
+		if err != nil {
+			return
+		}
+		switch {
+		case sq.Kind == SeqCSI && sq.Final == 'm' && sq.Intermediate == "" && isSGRParams(sq.Params):
+			style.applySGR(sq.Params)
+		case sq.Kind == SeqOSC && sq.Params == "8":
+			link = sq.Data
+			if i := strings.IndexByte(link, ';'); i >= 0 {
+				link = link[i+1:]
+			}
+		}
+	})
+	return text
+}
+
+// Render renders the text for profile p, converting colors as needed.
+func (t StyledText) Render(p Profile) string {
+	var b strings.Builder
+	for _, seg := range t {
+		st := seg.Style.Style(p)
+		if seg.Link != "" {
+			st = st.Hyperlink(seg.Link)
+		}
+		b.WriteString(st.Styled(seg.Text))
+	}
+	return b.String()
+}
+
+// String returns the plain text.
+func (t StyledText) String() string {
+	var b strings.Builder
+	for _, seg := range t {
+		b.WriteString(seg.Text)
+	}
+	return b.String()
+}
+
+// Style attribute flags of the binary encoding.
+const (
+	specBold = 1 << iota
+	specFaint
+	specItalic
+	specUnderline
+	specBlink
+	specReverse
+	specCrossOut
+	specOverline
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding starts
+// with a version byte, followed by the number of segments and, for each
+// segment, its text, link, attribute flags and colors.
+func (t StyledText) MarshalBinary() ([]byte, error) {
+	buf := []byte{styledTextVersion}
+	buf = appendUvarint(buf, uint64(len(t)))
+	for _, seg := range t {
+		buf = appendUvarint(buf, uint64(len(seg.Text)))
+		buf = append(buf, seg.Text...)
+		buf = appendUvarint(buf, uint64(len(seg.Link)))
+		buf = append(buf, seg.Link...)
+
+		var flags byte
+		for i, set := range []bool{
+			seg.Style.Bold, seg.Style.Faint, seg.Style.Italic, seg.Style.Underline,
+			seg.Style.Blink, seg.Style.Reverse, seg.Style.CrossOut, seg.Style.Overline,
+		} {
+			if set {
+				flags |= 1 << i
+			}
+		}
+		buf = append(buf, flags)
+		buf = appendColorSpec(buf, seg.Style.Foreground)
+		buf = appendColorSpec(buf, seg.Style.Background)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *StyledText) UnmarshalBinary(data []byte) error {
+	d := styledTextDecoder{data: data}
+	if d.byte() != styledTextVersion {
+		return ErrInvalidStyledText
+	}
+
+	n := d.uvarint()
+	if d.err != nil || n > uint64(len(d.data)) {
+		return ErrInvalidStyledText
+	}
+	text := make(StyledText, 0, n)
+	for i := uint64(0); i < n; i++ {
+		var seg Segment
+		seg.Text = d.string()
+		seg.Link = d.string()
+
+		flags := d.byte()
+		seg.Style = StyleSpec{
+			Bold:      flags&specBold != 0,
+			Faint:     flags&specFaint != 0,
+			Italic:    flags&specItalic != 0,
+			Underline: flags&specUnderline != 0,
+			Blink:     flags&specBlink != 0,
+			Reverse:   flags&specReverse != 0,
+			CrossOut:  flags&specCrossOut != 0,
+			Overline:  flags&specOverline != 0,
+		}
+		seg.Style.Foreground = d.colorSpec()
+		seg.Style.Background = d.colorSpec()
+		if d.err != nil {
+			return d.err
+		}
+		text = append(text, seg)
+	}
+	if len(d.data) > 0 {
+		return ErrInvalidStyledText
+	}
+
+	*t = text
+	return nil
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	return append(buf, b[:n]...)
+}
+
+// appendColorSpec appends the type of c, followed by its palette index or
+// its RGB values.
+func appendColorSpec(buf []byte, c *ColorSpec) []byte {
+	if c == nil {
+		return append(buf, byte(ColorNone))
+	}
+	buf = append(buf, byte(c.Type))
+	switch c.Type {
+	case ColorANSI, ColorANSI256:
+		buf = append(buf, byte(c.Index))
+	case ColorRGB:
+		var r, g, b uint8
+		if h, err := colorful.Hex(c.Hex); err == nil {
+			r, g, b = h.RGB255()
+		}
+		buf = append(buf, r, g, b)
+	}
+	return buf
+}
+
+// styledTextDecoder reads the binary encoding of StyledText, remembering
+// the first error.
+type styledTextDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *styledTextDecoder) byte() byte {
+	if len(d.data) == 0 {
+		d.err = ErrInvalidStyledText
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *styledTextDecoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = ErrInvalidStyledText
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *styledTextDecoder) string() string {
+	n := d.uvarint()
+	if d.err != nil || n > uint64(len(d.data)) {
+		d.err = ErrInvalidStyledText
+		return ""
+	}
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+	return s
+}
+
+func (d *styledTextDecoder) colorSpec() *ColorSpec {
+	switch t := ColorType(d.byte()); t {
+	case ColorNone:
+		return nil
+	case ColorANSI, ColorANSI256:
+		return &ColorSpec{Type: t, Index: int(d.byte())}
+	case ColorRGB:
+		r, g, b := d.byte(), d.byte(), d.byte()
+		return &ColorSpec{Type: t, Hex: fmt.Sprintf("#%02x%02x%02x", r, g, b)}
+	}
+	d.err = ErrInvalidStyledText
+	return nil
+}
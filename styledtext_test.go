@@ -0,0 +1,110 @@
+package termenv
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseStyledText(t *testing.T) {
+	red := &ColorSpec{Type: ColorANSI, Index: 1}
+	tests := []struct {
+		name     string
+		in       string
+		expected StyledText
+	}{
+		{"plain", "foo", StyledText{{Text: "foo"}}},
+		{"styled", "a\x1b[1;31mb\x1b[0mc", StyledText{
+			{Text: "a"},
+			{Text: "b", Style: StyleSpec{Bold: true, Foreground: red}},
+			{Text: "c"},
+		}},
+		{"accumulated", "\x1b[3m\x1b[48;5;200ma\x1b[23mb\x1b[m", StyledText{
+			{Text: "a", Style: StyleSpec{Italic: true, Background: &ColorSpec{Type: ColorANSI256, Index: 200}}},
+			{Text: "b", Style: StyleSpec{Background: &ColorSpec{Type: ColorANSI256, Index: 200}}},
+		}},
+		{"rgb", "\x1b[38;2;255;0;128ma\x1b[38:2::0:255:0mb", StyledText{
+			{Text: "a", Style: StyleSpec{Foreground: &ColorSpec{Type: ColorRGB, Hex: "#ff0080"}}},
+			{Text: "b", Style: StyleSpec{Foreground: &ColorSpec{Type: ColorRGB, Hex: "#00ff00"}}},
+		}},
+		{"merged", "\x1b[1ma\x1b[1mb", StyledText{{Text: "ab", Style: StyleSpec{Bold: true}}}},
+		{"link", "\x1b]8;;https://example.com\x1b\\a\x1b]8;;\x1b\\b", StyledText{
+			{Text: "a", Link: "https://example.com"},
+			{Text: "b"},
+		}},
+		{"other sequences", "a\x1b[2Kb", StyledText{{Text: "ab"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseStyledText(test.in)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %+v, got %+v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestStyledTextRender(t *testing.T) {
+	in := "a\x1b[1;38;2;255;0;0mb\x1b[0mc"
+	text := ParseStyledText(in)
+
+	tests := []struct {
+		profile  Profile
+		expected string
+	}{
+		{TrueColor, "a\x1b[38;2;255;0;0;1mb\x1b[0mc"},
+		{ANSI256, "a\x1b[38;5;196;1mb\x1b[0mc"},
+		{ANSI, "a\x1b[91;1mb\x1b[0mc"},
+		{Ascii, "abc"},
+	}
+	for _, test := range tests {
+		t.Run(test.profile.Name(), func(t *testing.T) {
+			got := text.Render(test.profile)
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+			if plain := ParseStyledText(got).String(); plain != "abc" {
+				t.Errorf("expected the text to be kept, got %q", got)
+			}
+		})
+	}
+}
+
+func TestStyledTextEncoding(t *testing.T) {
+	text := ParseStyledText("a\x1b[1;4;38;2;1;2;3;48;5;100mb\x1b[0;7;31;\x1b]8;;http://x\x1b\\c\x1b]8;;\x1b\\\x1b[mdé")
+
+	b, err := text.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got StyledText
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, text) {
+		t.Errorf("binary round trip: expected %+v, got %+v", text, got)
+	}
+
+	j, err := json.Marshal(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	if err := json.Unmarshal(j, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, text) {
+		t.Errorf("JSON round trip: expected %+v, got %+v", text, got)
+	}
+
+	for i := 0; i < len(b); i++ {
+		if err := got.UnmarshalBinary(b[:i]); !errors.Is(err, ErrInvalidStyledText) {
+			t.Errorf("expected an error decoding %d bytes, got %v", i, err)
+		}
+	}
+	if err := got.UnmarshalBinary(append(b, 0)); !errors.Is(err, ErrInvalidStyledText) {
+		t.Errorf("expected an error for trailing data, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package termenv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidStyleSpec is returned when a textual style can't be parsed.
+var ErrInvalidStyleSpec = errors.New("invalid style spec")
+
+// styleSpecColors maps the color names accepted by ParseStyleSpec to their
+// ANSI color codes.
+var styleSpecColors = map[string]string{
+	"black":          "0",
+	"red":            "1",
+	"green":          "2",
+	"yellow":         "3",
+	"blue":           "4",
+	"magenta":        "5",
+	"cyan":           "6",
+	"white":          "7",
+	"bright-black":   "8",
+	"bright-red":     "9",
+	"bright-green":   "10",
+	"bright-yellow":  "11",
+	"bright-blue":    "12",
+	"bright-magenta": "13",
+	"bright-cyan":    "14",
+	"bright-white":   "15",
+}
+
+// ParseStyleSpec parses a textual style for the default output. See
+// Output.ParseStyleSpec.
+func ParseStyleSpec(s string) (Style, error) {
+	return output.ParseStyleSpec(s)
+}
+
+// ParseStyleSpec parses a textual style, like "bold #ff0000 on blue", into a
+// Style for o. The style is a whitespace-separated list of attributes (bold,
+// faint, italic, underline, blink, reverse, crossout, overline) and colors.
+// Colors are hex colors, ANSI color codes (0-255) or ANSI color names, like
+// red or bright-red; a color preceded by "on" sets the background, otherwise
+// the foreground. Case is ignored, and an empty string is a plain style.
+func (o *Output) ParseStyleSpec(s string) (Style, error) {
+	st := o.String()
+	fields := strings.Fields(strings.ToLower(s))
+	for i := 0; i < len(fields); i++ {
+		tok := fields[i]
+		if seq, ok := styleSpecAttr(tok); ok {
+			st = st.add(seq)
+			continue
+		}
+
+		bg := tok == "on"
+		if bg {
+			if i++; i == len(fields) {
+				return Style{}, fmt.Errorf("%w: missing color after \"on\" in %q", ErrInvalidStyleSpec, s)
+			}
+			tok = fields[i]
+		}
+		if v, ok := styleSpecColors[tok]; ok {
+			tok = v
+		}
+		if validateColor(tok) != "" {
+			return Style{}, fmt.Errorf("%w: unknown attribute or color %q in %q", ErrInvalidStyleSpec, fields[i], s)
+		}
+
+		if bg {
+			st = st.Background(o.Color(tok))
+		} else {
+			st = st.Foreground(o.Color(tok))
+		}
+	}
+	return st, nil
+}
+
+// styleSpecAttr returns the SGR sequence of the attribute name.
+func styleSpecAttr(name string) (string, bool) {
+	switch name {
+	case "bold":
+		return BoldSeq, true
+	case "faint", "dim":
+		return FaintSeq, true
+	case "italic":
+		return ItalicSeq, true
+	case "underline":
+		return UnderlineSeq, true
+	case "blink":
+		return BlinkSeq, true
+	case "reverse":
+		return ReverseSeq, true
+	case "crossout", "strikethrough":
+		return CrossOutSeq, true
+	case "overline":
+		return OverlineSeq, true
+	}
+	return "", false
+}
+
+// LoadSemanticEnvPrefix reads semantic style overrides from the environment
+// variables starting with prefix. See Output.LoadSemanticEnvPrefix.
+func LoadSemanticEnvPrefix(prefix string) error {
+	return output.LoadSemanticEnvPrefix(prefix)
+}
+
+// LoadSemanticEnvPrefix reads semantic style overrides from the environment
+// variables starting with prefix, e.g. "MYAPP_STYLE_". The rest of a
+// variable's name is the role in lower case, and its value a style as
+// accepted by ParseStyleSpec:
+//
+//	MYAPP_STYLE_ERROR="bold #ff0000"
+//	MYAPP_STYLE_HINT="faint"
+//
+// Empty variables are skipped. Nothing gets applied if any of the variables
+// is invalid.
+func (o *Output) LoadSemanticEnvPrefix(prefix string) error {
+	var names []string
+	for _, kv := range o.environ.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if len(name) > len(prefix) && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	styles := map[string]Style{}
+	for _, name := range names {
+		v := o.environ.Getenv(name)
+		if v == "" {
+			continue
+		}
+		s, err := o.ParseStyleSpec(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		styles[strings.ToLower(name[len(prefix):])] = s
+	}
+
+	for role, s := range styles {
+		o.SetSemantic(role, s)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package termenv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStyleSpec(t *testing.T) {
+	o := NewOutput(nil, WithProfile(TrueColor))
+
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"", "foo"},
+		{"bold #ff0000", "\x1b[1;38;2;255;0;0mfoo\x1b[0m"},
+		{"Italic Red on #00f", "\x1b[3;31;48;2;0;0;255mfoo\x1b[0m"},
+		{"  dim   underline  ", "\x1b[2;4mfoo\x1b[0m"},
+		{"bright-cyan on 236 strikethrough", "\x1b[96;48;5;236;9mfoo\x1b[0m"},
+	}
+	for _, test := range tests {
+		s, err := o.ParseStyleSpec(test.spec)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.spec, err)
+			continue
+		}
+		if got := s.Styled("foo"); got != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.spec, test.expected, got)
+		}
+	}
+
+	for _, spec := range []string{"bold on", "#ff00", "256", "purple", "on on red"} {
+		if _, err := o.ParseStyleSpec(spec); !errors.Is(err, ErrInvalidStyleSpec) {
+			t.Errorf("%q: expected ErrInvalidStyleSpec, got %v", spec, err)
+		}
+	}
+}
+
+func TestLoadSemanticEnvPrefix(t *testing.T) {
+	env := mapEnv{
+		"MYAPP_STYLE_ERROR":  "bold #ff0000",
+		"MYAPP_STYLE_CUSTOM": "on blue",
+		"MYAPP_STYLE_HINT":   "",
+		"MYAPP_STYLE_":       "bold",
+		"OTHER_STYLE_INFO":   "red",
+	}
+	o := NewOutput(nil, WithEnvironment(env), WithProfile(ANSI))
+	if err := o.LoadSemanticEnvPrefix("MYAPP_STYLE_"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		role     string
+		expected string
+	}{
+		{RoleError, "\x1b[1;91mfoo\x1b[0m"},
+		{"custom", "\x1b[44mfoo\x1b[0m"},
+		{RoleHint, "\x1b[2mfoo\x1b[0m"},
+		{RoleInfo, "\x1b[34mfoo\x1b[0m"},
+	}
+	for _, test := range tests {
+		if got := o.Semantic(test.role).Styled("foo"); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.role, test.expected, got)
+		}
+	}
+
+	env["MYAPP_STYLE_WARNING"] = "bold purple"
+	env["MYAPP_STYLE_SUCCESS"] = "italic"
+	if err := o.LoadSemanticEnvPrefix("MYAPP_STYLE_"); !errors.Is(err, ErrInvalidStyleSpec) {
+		t.Errorf("expected ErrInvalidStyleSpec, got %v", err)
+	}
+	if got, exp := o.Semantic(RoleSuccess).Styled("foo"), "\x1b[32mfoo\x1b[0m"; got != exp {
+		t.Errorf("expected nothing to be applied, got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// TabWidth makes the Style expand tabs to spaces before rendering, with tab
+// stops every n columns. Raw tabs break alignment and background blocks,
+// since the terminal skips over the cells instead of printing them.
+func (t Style) TabWidth(n int) Style {
+	t.tabs = n
+	return t
+}
+
+// ExpandTabs replaces the tabs in s with spaces, up to the next tab stop. Tab
+// stops are placed every width columns. Escape sequences don't advance the
+// column, and newlines and carriage returns reset it.
+func ExpandTabs(s string, width int) string {
+	if width <= 0 || !strings.Contains(s, "\t") {
+		return s
+	}
+
+	var (
+		b     strings.Builder
+		col   int
+		start int
+	)
+	flush := func(end int) {
+		col += uniseg.StringWidth(s[start:end])
+		b.WriteString(s[start:end])
+	}
+
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\t':
+			flush(i)
+			n := width - col%width
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			i++
+			start = i
+		case '\n', '\r':
+			flush(i)
+			b.WriteByte(s[i])
+			col = 0
+			i++
+			start = i
+		case ESC:
+			if _, n, err := ParseSequence(s[i:]); err == nil {
+				flush(i)
+				b.WriteString(s[i : i+n])
+				i += n
+				start = i
+				continue
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	flush(len(s))
+
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestExpandTabs(t *testing.T) {
+	tests := []struct {
+		in       string
+		width    int
+		expected string
+	}{
+		{"a\tb", 4, "a   b"},
+		{"abcd\tb", 4, "abcd    b"},
+		{"\t\tb", 2, "    b"},
+		{"ab\n\tc", 4, "ab\n    c"},
+		{"日本\tx", 8, "日本    x"},
+		{"\x1b[1mab\x1b[0m\tc", 4, "\x1b[1mab\x1b[0m  c"},
+		{"a\tb", 0, "a\tb"},
+	}
+
+	for _, test := range tests {
+		if got := ExpandTabs(test.in, test.width); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestStyleTabWidth(t *testing.T) {
+	s := String("a\tb").Bold().TabWidth(4)
+
+	exp := "\x1b[1ma   b\x1b[0m"
+	if s.String() != exp {
+		t.Errorf("expected %q, got %q", exp, s.String())
+	}
+	if s.Width() != 5 {
+		t.Errorf("expected width of 5, got %d", s.Width())
+	}
+}
@@ -0,0 +1,182 @@
+package termenv
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Tail keeps the last lines of a stream rendered in a fixed region of the
+// screen, e.g. the output of a build step below a progress display. Lines
+// are kept in a scrollback ring buffer. While the view follows the newest
+// lines, rendering scrolls the region with a scrolling region and only draws
+// the new lines; otherwise it redraws the rows that changed since the last
+// render.
+type Tail struct {
+	o      *Output
+	top    int
+	height int
+
+	mu      sync.Mutex
+	ring    []string
+	start   int
+	n       int
+	offset  int
+	partial string
+	drawn   []string
+	added   int // lines added since the last render
+}
+
+// NewTail returns a Tail drawing into the given number of rows, starting at
+// row top (1-based). A height smaller than 1 is raised to 1. It keeps up to
+// scrollback lines; a scrollback smaller than height is raised to height.
+func (o *Output) NewTail(top, height, scrollback int) *Tail {
+	if height < 1 {
+		height = 1
+	}
+	if scrollback < height {
+		scrollback = height
+	}
+	return &Tail{
+		o:      o,
+		top:    top,
+		height: height,
+		ring:   make([]string, scrollback),
+		drawn:  make([]string, height),
+	}
+}
+
+// Add appends a (styled) line to the buffer. It does not render.
+func (t *Tail) Add(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(line)
+}
+
+func (t *Tail) add(line string) {
+	t.added++
+	if t.n < len(t.ring) {
+		t.ring[(t.start+t.n)%len(t.ring)] = line
+		t.n++
+	} else {
+		t.ring[t.start] = line
+		t.start = (t.start + 1) % len(t.ring)
+	}
+
+	if t.offset > 0 {
+		// keep a scrolled back view in place
+		t.offset++
+		if limit := t.n - t.height; t.offset > limit {
+			t.offset = limit
+		}
+	}
+}
+
+// Write implements io.Writer, splitting p into lines. A trailing incomplete
+// line is buffered until its newline arrives. It does not render.
+func (t *Tail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lines := strings.Split(t.partial+string(p), "\n")
+	for _, l := range lines[:len(lines)-1] {
+		t.add(strings.TrimSuffix(l, "\r"))
+	}
+	t.partial = lines[len(lines)-1]
+	return len(p), nil
+}
+
+// Lines returns all lines in the scrollback buffer, oldest first.
+func (t *Tail) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lines := make([]string, t.n)
+	for i := range lines {
+		lines[i] = t.ring[(t.start+i)%len(t.ring)]
+	}
+	return lines
+}
+
+// Scroll moves the view n lines back into the scrollback buffer (or forward
+// for negative n). A view scrolled back stays put when new lines arrive.
+func (t *Tail) Scroll(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.offset += n
+	if limit := t.n - t.height; t.offset > limit {
+		t.offset = limit
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+}
+
+// scrolled returns whether visible is what has been drawn, moved up by the
+// lines added since, so the region can be scrolled instead of redrawn.
+func (t *Tail) scrolled(visible []string) bool {
+	k := t.added
+	if t.offset != 0 || k < 1 || k >= t.height {
+		return false
+	}
+	for i, l := range visible[:t.height-k] {
+		if t.drawn[i+k] != l {
+			return false
+		}
+	}
+	return true
+}
+
+// visible returns the lines currently in view, padded to the region height.
+func (t *Tail) visible() []string {
+	lines := make([]string, t.height)
+	end := t.n - t.offset
+	begin := end - t.height
+	for i := range lines {
+		if idx := begin + i; idx >= 0 && idx < end {
+			lines[i] = t.ring[(t.start+idx)%len(t.ring)]
+		}
+	}
+	return lines
+}
+
+// Render draws the rows whose content changed since the last render. The
+// cursor position is preserved.
+func (t *Tail) Render() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b strings.Builder
+	visible := t.visible()
+	if t.scrolled(visible) {
+		// scroll the drawn rows up, then draw the new lines at the bottom
+		k := t.added
+		fmt.Fprintf(&b, CSI+ChangeScrollingRegionSeq, t.top, t.top+t.height-1)
+		fmt.Fprintf(&b, CSI+ScrollUpSeq, k)
+		copy(t.drawn, t.drawn[k:])
+		for i := t.height - k; i < t.height; i++ {
+			t.drawn[i] = visible[i]
+			fmt.Fprintf(&b, CSI+CursorPositionSeq, t.top+i, 1)
+			b.WriteString(visible[i])
+			b.WriteString(CSI + EraseLineRightSeq)
+		}
+		b.WriteString(CSI + ResetScrollingRegionSeq)
+	}
+	t.added = 0
+
+	for i, l := range visible {
+		if l == t.drawn[i] {
+			continue
+		}
+		t.drawn[i] = l
+		fmt.Fprintf(&b, CSI+CursorPositionSeq, t.top+i, 1)
+		b.WriteString(l)
+		b.WriteString(CSI + EraseLineRightSeq)
+	}
+	if b.Len() == 0 {
+		return
+	}
+
+	_, _ = t.o.WriteString(CSI + SaveCursorPositionSeq + b.String() + CSI + RestoreCursorPositionSeq)
+}
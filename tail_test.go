@@ -0,0 +1,102 @@
+package termenv
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTail(t *testing.T) {
+	buf := &bytes.Buffer{}
+	o := NewOutput(buf, WithProfile(Ascii))
+	tail := o.NewTail(5, 2, 3)
+
+	_, _ = tail.Write([]byte("one\ntwo\nthr"))
+	tail.Render()
+	exp := "\x1b[s\x1b[5;1Hone\x1b[0K\x1b[6;1Htwo\x1b[0K\x1b[u"
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+
+	// nothing changed, nothing to render
+	buf.Reset()
+	tail.Render()
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+
+	_, _ = tail.Write([]byte("ee\nfour\n"))
+	if !reflect.DeepEqual(tail.Lines(), []string{"two", "three", "four"}) {
+		t.Errorf("unexpected scrollback: %v", tail.Lines())
+	}
+
+	tail.Scroll(1)
+	tail.Render()
+	exp = "\x1b[s\x1b[5;1Htwo\x1b[0K\x1b[6;1Hthree\x1b[0K\x1b[u"
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+
+	buf.Reset()
+	tail.Scroll(-5)
+	tail.Render()
+	exp = "\x1b[s\x1b[5;1Hthree\x1b[0K\x1b[6;1Hfour\x1b[0K\x1b[u"
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+}
+
+func TestTailInvalidHeight(t *testing.T) {
+	for _, height := range []int{0, -1} {
+		buf := &bytes.Buffer{}
+		o := NewOutput(buf, WithProfile(Ascii))
+		tail := o.NewTail(1, height, 0)
+
+		tail.Add("one")
+		tail.Add("two")
+		tail.Render()
+		exp := "\x1b[s\x1b[1;1Htwo\x1b[0K\x1b[u"
+		if buf.String() != exp {
+			t.Errorf("height %d: expected %q, got %q", height, exp, buf.String())
+		}
+	}
+}
+
+func TestTailFollow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	o := NewOutput(buf, WithProfile(Ascii))
+	tail := o.NewTail(5, 3, 10)
+
+	tail.Add("one")
+	tail.Add("two")
+	tail.Add("three")
+	tail.Render()
+
+	// new lines scroll the region instead of redrawing it
+	buf.Reset()
+	tail.Add("four")
+	tail.Render()
+	exp := "\x1b[s\x1b[5;7r\x1b[1S\x1b[7;1Hfour\x1b[0K\x1b[r\x1b[u"
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+
+	// a view scrolled back stays put
+	tail.Scroll(1)
+	tail.Render()
+	buf.Reset()
+	tail.Add("five")
+	tail.Add("six")
+	tail.Render()
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+
+	buf.Reset()
+	tail.Scroll(-1)
+	tail.Render()
+	exp = "\x1b[s\x1b[5;1Htwo\x1b[0K\x1b[6;1Hthree\x1b[0K\x1b[7;1Hfour\x1b[0K\x1b[u"
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+}
@@ -3,6 +3,7 @@ package termenv
 import (
 	"errors"
 	"os"
+	"strings"
 
 	"github.com/mattn/go-isatty"
 )
@@ -10,6 +11,9 @@ import (
 var (
 	// ErrStatusReport gets returned when the terminal can't be queried.
 	ErrStatusReport = errors.New("unable to retrieve status report")
+
+	// ErrRawMode gets returned when the terminal can't be put into raw mode.
+	ErrRawMode = errors.New("unable to enable raw mode")
 )
 
 const (
@@ -21,6 +25,18 @@ const (
 	CSI = string(ESC) + "["
 	// Operating System Command.
 	OSC = string(ESC) + "]"
+	// Device Control String.
+	DCS = string(ESC) + "P"
+	// Application Program Command.
+	APC = string(ESC) + "_"
+	// Privacy Message.
+	PM = string(ESC) + "^"
+	// Start of String.
+	SOS = string(ESC) + "X"
+	// Single Shift Two.
+	SS2 = string(ESC) + "N"
+	// Single Shift Three.
+	SS3 = string(ESC) + "O"
 	// String Terminator.
 	ST = string(ESC) + `\`
 )
@@ -65,7 +81,11 @@ func HasDarkBackground() bool {
 // or CLICOLOR/CLICOLOR_FORCE (https://bixense.com/clicolors/)
 // If NO_COLOR is set, this will return true, ignoring CLICOLOR/CLICOLOR_FORCE
 // If CLICOLOR=="0", it will be true only if CLICOLOR_FORCE is also "0" or is unset.
+// FORCE_COLOR takes precedence over all of them, see EnvColorProfile.
 func (o *Output) EnvNoColor() bool {
+	if p, ok := o.forcedColor(); ok {
+		return p == Ascii
+	}
 	return o.environ.Getenv("NO_COLOR") != "" || (o.environ.Getenv("CLICOLOR") == "0" && !o.cliColorForced())
 }
 
@@ -74,6 +94,7 @@ func (o *Output) EnvNoColor() bool {
 // or CLICOLOR/CLICOLOR_FORCE (https://bixense.com/clicolors/)
 // If NO_COLOR is set, this will return true, ignoring CLICOLOR/CLICOLOR_FORCE
 // If CLICOLOR=="0", it will be true only if CLICOLOR_FORCE is also "0" or is unset.
+// FORCE_COLOR takes precedence over all of them, see EnvColorProfile.
 func EnvNoColor() bool {
 	return output.EnvNoColor()
 }
@@ -85,6 +106,10 @@ func EnvNoColor() bool {
 // It will return the Ascii color profile if EnvNoColor() returns true
 // If the terminal does not support any colors, but CLICOLOR_FORCE is set and not "0"
 // then the ANSI color profile will be returned.
+//
+// FORCE_COLOR follows the Node.js convention and takes precedence over all
+// other variables: "0" (or "false") selects Ascii, "1" (or "true") ANSI, "2"
+// ANSI256 and "3" TrueColor, whether or not the output is a terminal.
 func EnvColorProfile() Profile {
 	return output.EnvColorProfile()
 }
@@ -96,7 +121,14 @@ func EnvColorProfile() Profile {
 // It will return the Ascii color profile if EnvNoColor() returns true
 // If the terminal does not support any colors, but CLICOLOR_FORCE is set and not "0"
 // then the ANSI color profile will be returned.
+//
+// FORCE_COLOR follows the Node.js convention and takes precedence over all
+// other variables: "0" (or "false") selects Ascii, "1" (or "true") ANSI, "2"
+// ANSI256 and "3" TrueColor, whether or not the output is a terminal.
 func (o *Output) EnvColorProfile() Profile {
+	if p, ok := o.forcedColor(); ok {
+		return p
+	}
 	if o.EnvNoColor() {
 		return Ascii
 	}
@@ -104,9 +136,60 @@ func (o *Output) EnvColorProfile() Profile {
 	if o.cliColorForced() && p == Ascii {
 		return ANSI
 	}
+	if o.SafeMode() && p < ANSI {
+		return ANSI
+	}
 	return p
 }
 
+// ForceSafe enables safe mode, meant for serial consoles and IPMI
+// serial-over-LAN sessions: the color profile gets clamped to ANSI, and the
+// terminal is never queried, as such consoles don't answer queries and would
+// only stall the program until the query times out.
+//
+// Safe mode is enabled automatically for the classic DEC terminal types
+// (TERM=vt100, vt102, vt220, ...) these consoles usually advertise.
+func (o *Output) ForceSafe() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.safe = true
+	if o.Profile < ANSI {
+		o.Profile = ANSI
+	}
+}
+
+// SafeMode returns whether safe mode is enabled, see ForceSafe.
+func (o *Output) SafeMode() bool {
+	return o.safe || isSerialTerm(o.environ.Getenv("TERM"))
+}
+
+// isSerialTerm returns whether term is one of the DEC terminal types
+// typically set for serial consoles.
+func isSerialTerm(term string) bool {
+	switch term {
+	case "vt52", "vt100", "vt102", "vt220", "vt320", "vt420":
+		return true
+	}
+	return strings.HasPrefix(term, "vt100-") || strings.HasPrefix(term, "vt220-")
+}
+
+// forcedColor returns the profile selected by FORCE_COLOR, and whether it
+// is set to a known level.
+func (o *Output) forcedColor() (Profile, bool) {
+	switch strings.ToLower(o.environ.Getenv("FORCE_COLOR")) {
+	case "0", "false":
+		return Ascii, true
+	case "1", "true":
+		return ANSI, true
+	case "2":
+		return ANSI256, true
+	case "3":
+		return TrueColor, true
+	}
+	return Ascii, false
+}
+
 func (o *Output) cliColorForced() bool {
 	if forced := o.environ.Getenv("CLICOLOR_FORCE"); forced != "" {
 		return forced != "0"
@@ -3,7 +3,13 @@
 
 package termenv
 
-import "io"
+import (
+	"io"
+	"os"
+)
+
+// signals that restore the terminal when received in raw mode.
+var interruptSignals = []os.Signal{os.Interrupt}
 
 // ColorProfile returns the supported color profile:
 // ANSI256
@@ -11,14 +17,14 @@ func (o Output) ColorProfile() Profile {
 	return ANSI256
 }
 
-func (o Output) foregroundColor() Color {
-	// default gray
-	return ANSIColor(7)
+func (o Output) termStatusReports(...int) (map[int]string, error) {
+	return nil, ErrStatusReport
 }
 
-func (o Output) backgroundColor() Color {
-	// default black
-	return ANSIColor(0)
+// defaultUnicode reports whether the terminal renders Unicode when no locale
+// is configured.
+func (o Output) defaultUnicode() bool {
+	return true
 }
 
 // EnableVirtualTerminalProcessing enables virtual terminal processing on
@@ -2,6 +2,7 @@ package termenv
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"image/color"
 	"io"
@@ -337,6 +338,10 @@ func TestEnvNoColor(t *testing.T) {
 		{"clicolor=1+clicolor_force=1", []string{"CLICOLOR", "1", "CLICOLOR_FORCE", "1"}, false},
 		{"clicolor=0+clicolor_force=0", []string{"CLICOLOR", "0", "CLICOLOR_FORCE", "0"}, true},
 		{"clicolor=1+clicolor_force=0", []string{"CLICOLOR", "1", "CLICOLOR_FORCE", "0"}, false},
+		{"force_color=0", []string{"FORCE_COLOR", "0"}, true},
+		{"force_color=false+clicolor_force=1", []string{"FORCE_COLOR", "false", "CLICOLOR_FORCE", "1"}, true},
+		{"force_color=1+no_color", []string{"FORCE_COLOR", "1", "NO_COLOR", "Y"}, false},
+		{"force_color=invalid+no_color", []string{"FORCE_COLOR", "yes", "NO_COLOR", "Y"}, true},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -344,6 +349,7 @@ func TestEnvNoColor(t *testing.T) {
 				os.Unsetenv("NO_COLOR")
 				os.Unsetenv("CLICOLOR")
 				os.Unsetenv("CLICOLOR_FORCE")
+				os.Unsetenv("FORCE_COLOR")
 			}()
 			for i := 0; i < len(test.environ); i += 2 {
 				os.Setenv(test.environ[i], test.environ[i+1])
@@ -357,6 +363,34 @@ func TestEnvNoColor(t *testing.T) {
 	}
 }
 
+func TestForceColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		environ  map[string]string
+		tty      bool
+		expected Profile
+	}{
+		{"unset", nil, false, Ascii},
+		{"0", map[string]string{"FORCE_COLOR": "0", "COLORTERM": "truecolor"}, true, Ascii},
+		{"1", map[string]string{"FORCE_COLOR": "1"}, false, ANSI},
+		{"true", map[string]string{"FORCE_COLOR": "true"}, false, ANSI},
+		{"1 limits truecolor", map[string]string{"FORCE_COLOR": "1", "COLORTERM": "truecolor"}, true, ANSI},
+		{"2", map[string]string{"FORCE_COLOR": "2"}, false, ANSI256},
+		{"3", map[string]string{"FORCE_COLOR": "3", "NO_COLOR": "1"}, false, TrueColor},
+		{"unknown level", map[string]string{"FORCE_COLOR": "4"}, false, Ascii},
+		{"clicolor_force", map[string]string{"CLICOLOR_FORCE": "1"}, false, ANSI},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := NewOutput(io.Discard, WithTTY(test.tty), WithEnvironment(mapEnv(test.environ)))
+			if o.Profile != test.expected {
+				t.Errorf("expected %s, got %s", test.expected.Name(), o.Profile.Name())
+			}
+		})
+	}
+}
+
 func TestPseudoTerm(t *testing.T) {
 	buf := &bytes.Buffer{}
 	o := NewOutput(buf)
@@ -415,3 +449,44 @@ func TestWithTTY(t *testing.T) {
 		}
 	}
 }
+
+func TestSafeMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		environ  map[string]string
+		force    bool
+		safe     bool
+		expected Profile
+	}{
+		{"xterm", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, false, false, TrueColor},
+		{"vt100", map[string]string{"TERM": "vt100"}, false, true, Ascii},
+		{"vt220 with forwarded colorterm", map[string]string{"TERM": "vt220", "COLORTERM": "truecolor"}, false, true, ANSI},
+		{"forced", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, true, true, ANSI},
+		{"forced ascii", map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, true, true, Ascii},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := NewOutput(io.Discard, WithTTY(true), WithEnvironment(mapEnv(test.environ)))
+			if test.force {
+				o.ForceSafe()
+			}
+			if o.SafeMode() != test.safe {
+				t.Errorf("expected safe mode %t, got %t", test.safe, o.SafeMode())
+			}
+			if o.Profile != test.expected {
+				t.Errorf("expected %s, got %s", test.expected.Name(), o.Profile.Name())
+			}
+		})
+	}
+}
+
+func TestMakeRawNoTerminal(t *testing.T) {
+	o := NewOutput(&bytes.Buffer{})
+	if _, err := o.MakeRaw(); !errors.Is(err, ErrRawMode) {
+		t.Errorf("expected an error for a non-terminal output")
+	}
+	if _, err := o.State(); err == nil {
+		t.Errorf("expected an error for a non-terminal output")
+	}
+}
@@ -6,6 +6,7 @@ package termenv
 import (
 	"fmt"
 	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +19,9 @@ const (
 	OSCTimeout = 5 * time.Second
 )
 
+// signals that restore the terminal when received in raw mode.
+var interruptSignals = []os.Signal{os.Interrupt, unix.SIGTERM, unix.SIGHUP, unix.SIGQUIT}
+
 // ColorProfile returns the supported color profile:
 // Ascii, ANSI, ANSI256, or TrueColor.
 func (o *Output) ColorProfile() Profile {
@@ -75,50 +79,10 @@ func (o *Output) ColorProfile() Profile {
 	return Ascii
 }
 
-//nolint:mnd
-func (o Output) foregroundColor() Color {
-	s, err := o.termStatusReport(10)
-	if err == nil {
-		c, err := xTermColor(s)
-		if err == nil {
-			return c
-		}
-	}
-
-	colorFGBG := o.environ.Getenv("COLORFGBG")
-	if strings.Contains(colorFGBG, ";") {
-		c := strings.Split(colorFGBG, ";")
-		i, err := strconv.Atoi(c[0])
-		if err == nil {
-			return ANSIColor(i)
-		}
-	}
-
-	// default gray
-	return ANSIColor(7)
-}
-
-//nolint:mnd
-func (o Output) backgroundColor() Color {
-	s, err := o.termStatusReport(11)
-	if err == nil {
-		c, err := xTermColor(s)
-		if err == nil {
-			return c
-		}
-	}
-
-	colorFGBG := o.environ.Getenv("COLORFGBG")
-	if strings.Contains(colorFGBG, ";") {
-		c := strings.Split(colorFGBG, ";")
-		i, err := strconv.Atoi(c[len(c)-1])
-		if err == nil {
-			return ANSIColor(i)
-		}
-	}
-
-	// default black
-	return ANSIColor(0)
+// defaultUnicode reports whether the terminal renders Unicode when no locale
+// is configured. Without a locale the POSIX locale applies, which is ASCII.
+func (o Output) defaultUnicode() bool {
+	return false
 }
 
 func (o *Output) waitForData(timeout time.Duration) error {
@@ -165,9 +129,10 @@ func (o *Output) readNextByte() (byte, error) {
 	return b[0], nil
 }
 
-// readNextResponse reads either an OSC response or a cursor position response:
+// readNextResponse reads either an OSC response or a CSI response, such as
+// the primary device attributes response:
 //   - OSC response: "\x1b]11;rgb:1111/1111/1111\x1b\\"
-//   - cursor position response: "\x1b[42;1R"
+//   - primary device attributes response: "\x1b[?62;22c"
 func (o *Output) readNextResponse() (response string, isOSC bool, err error) {
 	start, err := o.readNextByte()
 	if err != nil {
@@ -184,7 +149,7 @@ func (o *Output) readNextResponse() (response string, isOSC bool, err error) {
 
 	response += string(start)
 
-	// next byte is either '[' (cursor position response) or ']' (OSC response)
+	// next byte is either '[' (CSI response) or ']' (OSC response)
 	tpe, err := o.readNextByte()
 	if err != nil {
 		return "", false, err
@@ -216,14 +181,15 @@ func (o *Output) readNextResponse() (response string, isOSC bool, err error) {
 				return response, true, nil
 			}
 		} else {
-			// cursor position response is terminated by 'R'
-			if b == 'R' {
+			// CSI responses are terminated by a final byte
+			if b >= 0x40 && b <= 0x7e { //nolint:mnd
 				return response, false, nil
 			}
 		}
 
-		// both responses have less than 25 bytes, so if we read more, that's an error
-		if len(response) > 25 { //nolint:mnd
+		// all responses have less than 64 bytes, so if we read more, that's an
+		// error
+		if len(response) > 64 { //nolint:mnd
 			break
 		}
 	}
@@ -231,29 +197,37 @@ func (o *Output) readNextResponse() (response string, isOSC bool, err error) {
 	return "", false, ErrStatusReport
 }
 
-func (o Output) termStatusReport(sequence int) (string, error) {
+// termStatusReports sends all OSC queries in a single write and collects the
+// responses, keyed by their OSC number. Queries the terminal doesn't answer
+// are missing from the result.
+func (o Output) termStatusReports(sequences ...int) (map[int]string, error) {
+	// serial consoles never answer queries
+	if o.SafeMode() {
+		return nil, ErrStatusReport
+	}
+
 	// screen/tmux can't support OSC, because they can be connected to multiple
 	// terminals concurrently.
 	term := o.environ.Getenv("TERM")
 	if strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux") || strings.HasPrefix(term, "dumb") {
-		return "", ErrStatusReport
+		return nil, ErrStatusReport
 	}
 
 	tty := o.TTY()
 	if tty == nil {
-		return "", ErrStatusReport
+		return nil, ErrStatusReport
 	}
 
 	if !o.unsafe {
 		fd := int(tty.Fd()) //nolint:gosec
 		// if in background, we can't control the terminal
 		if !isForeground(fd) {
-			return "", ErrStatusReport
+			return nil, ErrStatusReport
 		}
 
 		t, err := unix.IoctlGetTermios(fd, tcgetattr)
 		if err != nil {
-			return "", fmt.Errorf("%s: %s", ErrStatusReport, err)
+			return nil, fmt.Errorf("%s: %s", ErrStatusReport, err)
 		}
 		defer unix.IoctlSetTermios(fd, tcsetattr, t) //nolint:errcheck
 
@@ -261,34 +235,42 @@ func (o Output) termStatusReport(sequence int) (string, error) {
 		noecho.Lflag = noecho.Lflag &^ unix.ECHO
 		noecho.Lflag = noecho.Lflag &^ unix.ICANON
 		if err := unix.IoctlSetTermios(fd, tcsetattr, &noecho); err != nil {
-			return "", fmt.Errorf("%s: %s", ErrStatusReport, err)
+			return nil, fmt.Errorf("%s: %s", ErrStatusReport, err)
 		}
 	}
 
-	// first, send OSC query, which is ignored by terminal which do not support it
-	fmt.Fprintf(tty, OSC+"%d;?"+ST, sequence) //nolint:errcheck
-
-	// then, query cursor position, should be supported by all terminals
-	fmt.Fprintf(tty, CSI+"6n") //nolint:errcheck
-
-	// read the next response
-	res, isOSC, err := o.readNextResponse()
-	if err != nil {
-		return "", fmt.Errorf("%s: %s", ErrStatusReport, err)
+	// first, send the OSC queries, which are ignored by terminals which do not
+	// support them. Then, query the primary device attributes (DA1), which
+	// all terminals answer, so unanswered queries don't have to time out.
+	// Everything goes out in a single write, so the queries only cost a
+	// single round trip.
+	var b strings.Builder
+	for _, seq := range sequences {
+		fmt.Fprintf(&b, OSC+"%d;?"+ST, seq)
 	}
-
-	// if this is not OSC response, then the terminal does not support it
-	if !isOSC {
-		return "", ErrStatusReport
+	b.WriteString(CSI + "c")
+	if _, err := io.WriteString(tty, b.String()); err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrStatusReport, err)
 	}
 
-	// read the cursor query response next and discard the result
-	_, _, err = o.readNextResponse()
-	if err != nil {
-		return "", err
+	// read the OSC responses, up to the device attributes response
+	res := map[int]string{}
+	for {
+		r, isOSC, err := o.readNextResponse()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", ErrStatusReport, err)
+		}
+		if !isOSC {
+			break
+		}
+
+		if i := strings.IndexByte(r, ';'); i > len(OSC) {
+			if n, err := strconv.Atoi(r[len(OSC):i]); err == nil {
+				res[n] = r
+			}
+		}
 	}
 
-	// fmt.Println("Rcvd", res[1:])
 	return res, nil
 }
 
@@ -11,6 +11,9 @@ import (
 	"golang.org/x/sys/windows"
 )
 
+// signals that restore the console when received in raw mode.
+var interruptSignals = []os.Signal{os.Interrupt}
+
 func (o *Output) ColorProfile() Profile {
 	if !o.isTTY() {
 		return Ascii
@@ -44,14 +47,19 @@ func (o *Output) ColorProfile() Profile {
 	return TrueColor
 }
 
-func (o Output) foregroundColor() Color {
-	// default gray
-	return ANSIColor(7)
+func (o Output) termStatusReports(...int) (map[int]string, error) {
+	return nil, ErrStatusReport
 }
 
-func (o Output) backgroundColor() Color {
-	// default black
-	return ANSIColor(0)
+// defaultUnicode reports whether the console renders Unicode when no locale
+// is configured: Windows Terminal always does, the classic console only with
+// the UTF-8 output code page.
+func (o Output) defaultUnicode() bool {
+	if o.environ.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	cp, err := windows.GetConsoleOutputCP()
+	return err == nil && cp == 65001 //nolint:mnd
 }
 
 // EnableWindowsANSIConsole enables virtual terminal processing on Windows
@@ -0,0 +1,308 @@
+package termenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ThemeVersion is the version of the theme file schema this package writes
+// and reads. Files of older versions get migrated when loaded.
+const ThemeVersion = 1
+
+// ErrInvalidTheme is returned when a theme file can't be loaded, or a Theme
+// doesn't validate.
+var ErrInvalidTheme = errors.New("invalid theme")
+
+// Theme is a named set of colors, semantic styles and symbols. Themes are
+// stored as JSON files:
+//
+//	{
+//	  "version": 1,
+//	  "name": "ocean",
+//	  "colors": {
+//	    "accent": "#5f87ff",
+//	    "danger": "1"
+//	  },
+//	  "styles": {
+//	    "error": {"foreground": "danger", "bold": true},
+//	    "info": {"foreground": "accent"}
+//	  },
+//	  "symbols": {
+//	    "success": "✓"
+//	  }
+//	}
+//
+// Colors are hex colors or ANSI color codes (0-255), as accepted by
+// Output.Color. The colors of styles are either such a value, or the name of
+// one of the theme's colors. Styles are keyed by semantic role, e.g.
+// RoleError, and get applied with ApplyTheme.
+type Theme struct {
+	Version int                   `json:"version"`
+	Name    string                `json:"name"`
+	Colors  map[string]string     `json:"colors,omitempty"`
+	Styles  map[string]ThemeStyle `json:"styles,omitempty"`
+	Symbols map[string]string     `json:"symbols,omitempty"`
+}
+
+// ThemeStyle is the definition of a style in a Theme.
+type ThemeStyle struct {
+	Foreground string `json:"foreground,omitempty"`
+	Background string `json:"background,omitempty"`
+
+	Bold      bool `json:"bold,omitempty"`
+	Faint     bool `json:"faint,omitempty"`
+	Italic    bool `json:"italic,omitempty"`
+	Underline bool `json:"underline,omitempty"`
+	Blink     bool `json:"blink,omitempty"`
+	Reverse   bool `json:"reverse,omitempty"`
+	CrossOut  bool `json:"crossout,omitempty"`
+	Overline  bool `json:"overline,omitempty"`
+}
+
+// themeMigration upgrades the raw data of a theme file by one version.
+type themeMigration func(map[string]interface{}) error
+
+// themeMigrations holds the migration from each schema version to the next
+// one: themeMigrations[v] upgrades version v to v+1.
+var themeMigrations = map[int]themeMigration{}
+
+// ParseTheme parses and validates a theme file. Files written for an older
+// schema version are migrated to ThemeVersion first.
+func ParseTheme(data []byte) (Theme, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Theme{}, fmt.Errorf("%w: %s", ErrInvalidTheme, err)
+	}
+	if err := migrateTheme(raw, themeMigrations, ThemeVersion); err != nil {
+		return Theme{}, err
+	}
+
+	// re-encoding the migrated data is the easiest way to decode it strictly
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return Theme{}, fmt.Errorf("%w: %s", ErrInvalidTheme, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var t Theme
+	if err := dec.Decode(&t); err != nil {
+		return Theme{}, fmt.Errorf("%w: %s", ErrInvalidTheme, err)
+	}
+	return t, t.Validate()
+}
+
+// LoadTheme reads, migrates and validates the theme file at path.
+func LoadTheme(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err //nolint:wrapcheck
+	}
+	t, err := ParseTheme(data)
+	if err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// migrateTheme upgrades raw theme data to version to.
+func migrateTheme(raw map[string]interface{}, migrations map[int]themeMigration, to int) error {
+	f, ok := raw["version"].(float64)
+	if !ok {
+		return fmt.Errorf("%w: missing version, the current version is %d", ErrInvalidTheme, to)
+	}
+	v := int(f)
+	if float64(v) != f || v < 1 {
+		return fmt.Errorf("%w: invalid version %v", ErrInvalidTheme, raw["version"])
+	}
+	if v > to {
+		return fmt.Errorf("%w: version %d is newer than the supported version %d", ErrInvalidTheme, v, to)
+	}
+
+	for ; v < to; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return fmt.Errorf("%w: no migration from version %d", ErrInvalidTheme, v)
+		}
+		if err := m(raw); err != nil {
+			return fmt.Errorf("%w: migrating from version %d: %s", ErrInvalidTheme, v, err)
+		}
+	}
+	raw["version"] = to
+	return nil
+}
+
+// Validate checks the theme for errors, e.g. undefined color names. The
+// returned error lists all problems found.
+func (t Theme) Validate() error {
+	var problems []string
+	if t.Version != ThemeVersion {
+		problems = append(problems, fmt.Sprintf("version: expected %d, got %d", ThemeVersion, t.Version))
+	}
+	if t.Name == "" {
+		problems = append(problems, "name: missing")
+	}
+
+	for _, name := range sortedKeys(t.Colors) {
+		if err := validateColor(t.Colors[name]); err != "" {
+			problems = append(problems, fmt.Sprintf("colors.%s: %s", name, err))
+		}
+	}
+	for _, role := range sortedKeys(t.Styles) {
+		s := t.Styles[role]
+		for _, c := range []struct{ field, value string }{
+			{"foreground", s.Foreground},
+			{"background", s.Background},
+		} {
+			if c.value == "" {
+				continue
+			}
+			if _, ok := t.Colors[c.value]; ok {
+				continue
+			}
+			if err := validateColor(c.value); err != "" {
+				problems = append(problems, fmt.Sprintf("styles.%s.%s: %s, and not a color of the theme", role, c.field, err))
+			}
+		}
+	}
+	for _, name := range sortedKeys(t.Symbols) {
+		if sym := t.Symbols[name]; sym == "" || stripControls(sym) != sym {
+			problems = append(problems, fmt.Sprintf("symbols.%s: must be non-empty and without control characters", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidTheme, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateColor describes why s is not a valid color value, or returns an
+// empty string if it is.
+func validateColor(s string) string {
+	if strings.HasPrefix(s, "#") {
+		if _, err := colorful.Hex(s); err != nil {
+			return fmt.Sprintf("invalid hex color %q", s)
+		}
+		return ""
+	}
+	if n, err := strconv.Atoi(s); err != nil || n < 0 || n > 255 {
+		return fmt.Sprintf("invalid color %q, expected a hex color or 0-255", s)
+	}
+	return ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ThemeColor returns the theme's color name, or the color given by the value
+// name, converted to the profile of o.
+func (o *Output) ThemeColor(t Theme, name string) Color {
+	if v, ok := t.Colors[name]; ok {
+		name = v
+	}
+	return o.Color(name)
+}
+
+// RoleStyle returns the style the theme defines for role, for o. Roles
+// without a style in the theme get the Semantic style of o.
+func (o *Output) RoleStyle(t Theme, role string) Style {
+	ts, ok := t.Styles[role]
+	if !ok {
+		return o.Semantic(role)
+	}
+
+	s := o.String().
+		Foreground(o.ThemeColor(t, ts.Foreground)).
+		Background(o.ThemeColor(t, ts.Background))
+	for _, attr := range []struct {
+		set bool
+		seq string
+	}{
+		{ts.Bold, BoldSeq},
+		{ts.Faint, FaintSeq},
+		{ts.Italic, ItalicSeq},
+		{ts.Underline, UnderlineSeq},
+		{ts.Blink, BlinkSeq},
+		{ts.Reverse, ReverseSeq},
+		{ts.CrossOut, CrossOutSeq},
+		{ts.Overline, OverlineSeq},
+	} {
+		if attr.set {
+			s = s.add(attr.seq)
+		}
+	}
+	return s.WithMeta("role", role)
+}
+
+// ApplyTheme sets the semantic styles of the default output to the styles
+// of the theme.
+func ApplyTheme(t Theme) {
+	output.ApplyTheme(t)
+}
+
+// ApplyTheme sets the semantic styles of o to the styles of the theme.
+// Roles the theme doesn't define keep their styles.
+func (o *Output) ApplyTheme(t Theme) {
+	for role := range t.Styles {
+		o.SetSemantic(role, o.RoleStyle(t, role))
+	}
+}
+
+// themes is the registry of themes, keyed by name.
+var themes = struct {
+	mu     sync.RWMutex
+	themes map[string]Theme
+}{themes: map[string]Theme{}}
+
+// RegisterTheme validates the theme and adds it to the registry, replacing a
+// theme of the same name.
+func RegisterTheme(t Theme) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	themes.mu.Lock()
+	defer themes.mu.Unlock()
+	themes.themes[t.Name] = t
+	return nil
+}
+
+// RegisterThemeFile loads the theme file at path with LoadTheme and adds it
+// to the registry.
+func RegisterThemeFile(path string) (Theme, error) {
+	t, err := LoadTheme(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	return t, RegisterTheme(t)
+}
+
+// LookupTheme returns the registered theme with the given name.
+func LookupTheme(name string) (Theme, bool) {
+	themes.mu.RLock()
+	defer themes.mu.RUnlock()
+	t, ok := themes.themes[name]
+	return t, ok
+}
+
+// ThemeNames returns the names of all registered themes, sorted.
+func ThemeNames() []string {
+	themes.mu.RLock()
+	defer themes.mu.RUnlock()
+	return sortedKeys(themes.themes)
+}
@@ -0,0 +1,152 @@
+package termenv
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTheme = `{
+  "version": 1,
+  "name": "ocean",
+  "colors": {"accent": "#5f87ff", "danger": "1"},
+  "styles": {
+    "error": {"foreground": "danger", "bold": true},
+    "info": {"foreground": "accent", "background": "#000000"}
+  },
+  "symbols": {"success": "✓"}
+}`
+
+func TestParseTheme(t *testing.T) {
+	theme, err := ParseTheme([]byte(testTheme))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := Theme{
+		Version: 1,
+		Name:    "ocean",
+		Colors:  map[string]string{"accent": "#5f87ff", "danger": "1"},
+		Styles: map[string]ThemeStyle{
+			"error": {Foreground: "danger", Bold: true},
+			"info":  {Foreground: "accent", Background: "#000000"},
+		},
+		Symbols: map[string]string{"success": "✓"},
+	}
+	if !reflect.DeepEqual(theme, exp) {
+		t.Errorf("expected %+v, got %+v", exp, theme)
+	}
+}
+
+func TestParseThemeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"syntax", `{`, "unexpected end of JSON input"},
+		{"missing version", `{"name": "a"}`, "missing version"},
+		{"newer version", `{"version": 2, "name": "a"}`, "version 2 is newer than the supported version 1"},
+		{"unknown field", `{"version": 1, "name": "a", "colours": {}}`, `unknown field "colours"`},
+		{"missing name", `{"version": 1}`, "name: missing"},
+		{"bad color", `{"version": 1, "name": "a", "colors": {"x": "#zz"}}`, `colors.x: invalid hex color "#zz"`},
+		{"bad index", `{"version": 1, "name": "a", "colors": {"x": "256"}}`, `colors.x: invalid color "256"`},
+		{
+			"undefined color",
+			`{"version": 1, "name": "a", "styles": {"error": {"foreground": "red"}}}`,
+			`styles.error.foreground: invalid color "red", expected a hex color or 0-255, and not a color of the theme`,
+		},
+		{"bad symbol", `{"version": 1, "name": "a", "symbols": {"x": "\u001b"}}`, "symbols.x: must be non-empty"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseTheme([]byte(test.data))
+			if !errors.Is(err, ErrInvalidTheme) {
+				t.Fatalf("expected ErrInvalidTheme, got %v", err)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error containing %q, got %q", test.err, err)
+			}
+		})
+	}
+}
+
+func TestMigrateTheme(t *testing.T) {
+	migrations := map[int]themeMigration{
+		1: func(raw map[string]interface{}) error {
+			raw["name"] = raw["title"]
+			delete(raw, "title")
+			return nil
+		},
+		2: func(raw map[string]interface{}) error {
+			raw["migrated"] = true
+			return nil
+		},
+	}
+
+	raw := map[string]interface{}{"version": 1.0, "title": "old"}
+	if err := migrateTheme(raw, migrations, 3); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{"version": 3, "name": "old", "migrated": true}
+	if !reflect.DeepEqual(raw, exp) {
+		t.Errorf("expected %v, got %v", exp, raw)
+	}
+
+	raw = map[string]interface{}{"version": 1.0}
+	if err := migrateTheme(raw, migrations, 4); !errors.Is(err, ErrInvalidTheme) {
+		t.Errorf("expected an error for a missing migration, got %v", err)
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	theme, err := ParseTheme([]byte(testTheme))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := NewOutput(&bytes.Buffer{}, WithProfile(TrueColor))
+	o.ApplyTheme(theme)
+	tests := []struct {
+		role     string
+		expected string
+	}{
+		{RoleError, "\x1b[31;1mx\x1b[0m"},
+		{RoleInfo, "\x1b[38;2;95;135;255;48;2;0;0;0mx\x1b[0m"},
+		{RoleHint, "\x1b[2mx\x1b[0m"},
+	}
+	for _, test := range tests {
+		if got := o.Semantic(test.role).Styled("x"); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.role, test.expected, got)
+		}
+	}
+}
+
+func TestThemeRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ocean.json")
+	if err := os.WriteFile(path, []byte(testTheme), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RegisterThemeFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterTheme(Theme{Version: ThemeVersion}); !errors.Is(err, ErrInvalidTheme) {
+		t.Errorf("expected an invalid theme to be rejected, got %v", err)
+	}
+
+	theme, ok := LookupTheme("ocean")
+	if !ok || theme.Colors["accent"] != "#5f87ff" {
+		t.Errorf("expected the registered theme, got %+v", theme)
+	}
+	if names := ThemeNames(); !reflect.DeepEqual(names, []string{"ocean"}) {
+		t.Errorf("expected [ocean], got %v", names)
+	}
+
+	if _, err := LoadTheme(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
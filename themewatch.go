@@ -0,0 +1,182 @@
+package termenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrInvalidWatchInterval gets returned by WatchTheme when the interval set
+// with WithWatchInterval is not positive.
+var ErrInvalidWatchInterval = errors.New("invalid watch interval")
+
+// defaultWatchInterval is how often WatchTheme checks the theme file for
+// changes, unless events are supplied with WithWatchEvents.
+const defaultWatchInterval = time.Second
+
+// WatchThemeOption sets an option on WatchTheme.
+type WatchThemeOption = func(*themeWatcher)
+
+type themeWatcher struct {
+	path     string
+	ctx      context.Context
+	interval time.Duration
+	events   <-chan struct{}
+	onError  func(error)
+}
+
+// WithWatchContext returns a new WatchThemeOption stopping the watch when
+// ctx is done. Themes get applied to the Output returned by OutputFrom(ctx).
+func WithWatchContext(ctx context.Context) WatchThemeOption {
+	return func(w *themeWatcher) {
+		w.ctx = ctx
+	}
+}
+
+// WithWatchInterval returns a new WatchThemeOption replacing the default
+// interval of one second at which the theme file is checked for changes.
+// The interval must be positive.
+func WithWatchInterval(d time.Duration) WatchThemeOption {
+	return func(w *themeWatcher) {
+		w.interval = d
+	}
+}
+
+// WithWatchEvents returns a new WatchThemeOption reloading the theme
+// whenever a value is received from events, instead of polling the file.
+// This hooks up a file system notifier like fsnotify:
+//
+//	fw, _ := fsnotify.NewWatcher()
+//	fw.Add(path)
+//	events := make(chan struct{})
+//	go func() {
+//		for range fw.Events {
+//			events <- struct{}{}
+//		}
+//	}()
+//	themes, err := termenv.WatchTheme(path, termenv.WithWatchEvents(events))
+func WithWatchEvents(events <-chan struct{}) WatchThemeOption {
+	return func(w *themeWatcher) {
+		w.events = events
+	}
+}
+
+// WithWatchErrors returns a new WatchThemeOption calling f with the errors
+// of reloading the theme, e.g. validation errors while the file is being
+// edited. By default, they are ignored.
+func WithWatchErrors(f func(error)) WatchThemeOption {
+	return func(w *themeWatcher) {
+		w.onError = f
+	}
+}
+
+// WatchTheme loads the theme file at path, applies it to the output and
+// keeps watching the file, e.g. for live editing of a theme while developing
+// a TUI. Every time the file changes, the theme is reloaded and validated;
+// valid themes get applied and sent on the returned channel, while invalid
+// ones are reported to the function set with WithWatchErrors. The theme is
+// also re-applied and sent again after the output got re-detected, as its
+// colors may convert differently.
+//
+// The initial theme is the first value sent. Only the latest theme is kept
+// if the receiver falls behind. The channel gets closed when the context set
+// with WithWatchContext is done.
+func WatchTheme(path string, opts ...WatchThemeOption) (<-chan Theme, error) {
+	w := &themeWatcher{
+		path:     path,
+		ctx:      context.Background(),
+		interval: defaultWatchInterval,
+		onError:  func(error) {},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.interval <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWatchInterval, w.interval)
+	}
+
+	t, err := LoadTheme(path)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	o := OutputFrom(w.ctx)
+	o.ApplyTheme(t)
+	ch := make(chan Theme, 1)
+	ch <- t
+
+	go w.run(o, t, stat, ch)
+	return ch, nil
+}
+
+// run watches the theme file until the context is done.
+func (w *themeWatcher) run(o *Output, t Theme, stat os.FileInfo, ch chan Theme) {
+	redetected := make(chan struct{}, 1)
+	unregister := o.OnRedetect(func(Snapshot) {
+		select {
+		case redetected <- struct{}{}:
+		default:
+		}
+	})
+	defer unregister()
+	defer close(ch)
+
+	var tick <-chan time.Time
+	if w.events == nil {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	send := func(t Theme) {
+		o.ApplyTheme(t)
+		// replace a theme the receiver didn't pick up yet
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- t
+	}
+	reload := func() {
+		nt, err := LoadTheme(w.path)
+		if err != nil {
+			w.onError(err)
+			return
+		}
+		t = nt
+		send(t)
+	}
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-redetected:
+			send(t)
+		case _, ok := <-w.events:
+			if !ok {
+				// the notifier is gone; keep serving redetections
+				w.events = nil
+				continue
+			}
+			reload()
+		case <-tick:
+			ns, err := os.Stat(w.path)
+			if err != nil {
+				w.onError(err)
+				continue
+			}
+			if ns.ModTime().Equal(stat.ModTime()) && ns.Size() == stat.Size() {
+				continue
+			}
+			stat = ns
+			reload()
+		}
+	}
+}
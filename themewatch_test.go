@@ -0,0 +1,131 @@
+package termenv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func receiveTheme(t *testing.T, ch <-chan Theme) Theme {
+	t.Helper()
+	select {
+	case theme := <-ch:
+		return theme
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a theme")
+	}
+	return Theme{}
+}
+
+func TestWatchTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(path, []byte(testTheme), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	o := NewOutput(&bytes.Buffer{}, WithProfile(TrueColor))
+	ctx, cancel := context.WithCancel(WithOutput(context.Background(), o))
+	defer cancel()
+
+	errs := make(chan error, 10)
+	ch, err := WatchTheme(path,
+		WithWatchContext(ctx),
+		WithWatchInterval(10*time.Millisecond),
+		WithWatchErrors(func(err error) { errs <- err }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme := receiveTheme(t, ch); theme.Name != "ocean" {
+		t.Errorf("expected the initial theme, got %+v", theme)
+	}
+	if got := o.Semantic(RoleError).Styled("x"); got != "\x1b[31;1mx\x1b[0m" {
+		t.Errorf("expected the theme to be applied, got %q", got)
+	}
+
+	// an invalid theme gets reported, and the last valid one kept
+	if err := os.WriteFile(path, []byte(`{"version": 1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "name: missing") {
+			t.Errorf("unexpected error %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an error")
+	}
+
+	updated := strings.Replace(testTheme, `"danger": "1"`, `"danger": "2"`, 1)
+	if err := os.WriteFile(path, []byte(updated), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if theme := receiveTheme(t, ch); theme.Colors["danger"] != "2" {
+		t.Errorf("expected the updated theme, got %+v", theme)
+	}
+	if got := o.Semantic(RoleError).Styled("x"); got != "\x1b[32;1mx\x1b[0m" {
+		t.Errorf("expected the updated theme to be applied, got %q", got)
+	}
+
+	o.Redetect()
+	if theme := receiveTheme(t, ch); theme.Colors["danger"] != "2" {
+		t.Errorf("expected the theme to be sent after redetecting, got %+v", theme)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestWatchThemeEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(path, []byte(testTheme), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(WithOutput(context.Background(), NewOutput(&bytes.Buffer{})))
+	defer cancel()
+	events := make(chan struct{})
+	ch, err := WatchTheme(path, WithWatchContext(ctx), WithWatchEvents(events))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiveTheme(t, ch)
+
+	updated := strings.Replace(testTheme, `"ocean"`, `"lake"`, 1)
+	if err := os.WriteFile(path, []byte(updated), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	events <- struct{}{}
+	if theme := receiveTheme(t, ch); theme.Name != "lake" {
+		t.Errorf("expected the updated theme, got %+v", theme)
+	}
+}
+
+func TestWatchThemeInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WatchTheme(path); err == nil {
+		t.Error("expected an error for an invalid theme")
+	}
+}
+
+func TestWatchThemeInvalidInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(path, []byte(testTheme), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []time.Duration{0, -time.Second} {
+		if _, err := WatchTheme(path, WithWatchInterval(d)); !errors.Is(err, ErrInvalidWatchInterval) {
+			t.Errorf("%s: expected ErrInvalidWatchInterval, got %v", d, err)
+		}
+	}
+}
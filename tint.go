@@ -0,0 +1,171 @@
+package termenv
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// tintStep is the change in Lab lightness per level for true colors.
+const tintStep = 0.1
+
+// Dim returns a copy of t with its foreground color darkened by the given
+// number of levels. How a level is applied depends on the color's profile:
+// true colors lose lightness, 256-color grays move down the grayscale ramp
+// and cube colors down the cube, and 16-color bright colors become normal
+// ones before the text is rendered faint. Without a foreground color, Dim
+// renders the text faint.
+func (t Style) Dim(levels int) Style {
+	return t.tint(-levels)
+}
+
+// Brighten returns a copy of t with its foreground color brightened by the
+// given number of levels. It is the counterpart of Dim: 16-color normal
+// colors become bright ones before the text is rendered bold. Without a
+// foreground color, Brighten renders the text bold.
+func (t Style) Brighten(levels int) Style {
+	return t.tint(levels)
+}
+
+// tint shifts the foreground of t by levels, darkening it for negative
+// levels.
+func (t Style) tint(levels int) Style {
+	if levels == 0 {
+		return t
+	}
+
+	i := t.foregroundIndex()
+	if i < 0 {
+		return t.addIntensity(levels)
+	}
+
+	params := strings.Split(t.styles[i], ";")
+	switch {
+	case len(params) == 5 && params[1] == "2": //nolint:mnd
+		seq, ok := tintRGB(params[2:], levels)
+		if !ok {
+			return t
+		}
+		return t.replace(i, seq)
+	case len(params) == 3 && params[1] == "5": //nolint:mnd
+		n, err := strconv.Atoi(params[2])
+		if err != nil {
+			return t
+		}
+		if n < 16 { //nolint:mnd
+			return t.tintANSI(i, ANSIColor(n), levels, func(c ANSIColor) string {
+				return ANSI256Color(c).Sequence(false)
+			})
+		}
+		return t.replace(i, tintANSI256(ANSI256Color(n), levels).Sequence(false))
+	case len(params) == 1:
+		n, _ := strconv.Atoi(params[0])
+		c := ANSIColor(n - 30) //nolint:mnd
+		if n >= 90 {           //nolint:mnd
+			c = ANSIColor(n - 90 + 8) //nolint:mnd
+		}
+		return t.tintANSI(i, c, levels, func(c ANSIColor) string {
+			return c.Sequence(false)
+		})
+	}
+	return t
+}
+
+// foregroundIndex returns the index of the style setting the foreground
+// color, or -1 if there is none. Later styles take precedence.
+func (t Style) foregroundIndex() int {
+	for i := len(t.styles) - 1; i >= 0; i-- {
+		seq := t.styles[i]
+		if strings.HasPrefix(seq, Foreground+";") {
+			return i
+		}
+		if len(seq) == 2 && (seq[0] == '3' || seq[0] == '9') && seq[1] >= '0' && seq[1] <= '7' {
+			return i
+		}
+	}
+	return -1
+}
+
+// replace returns a copy of t with the style at index i replaced by seq.
+func (t Style) replace(i int, seq string) Style {
+	styles := make([]string, len(t.styles))
+	copy(styles, t.styles)
+	styles[i] = seq
+	t.styles = styles
+	t.seq = &styleSeq{}
+	return t
+}
+
+// addIntensity renders t bold for positive levels and faint for negative
+// ones.
+func (t Style) addIntensity(levels int) Style {
+	if levels > 0 {
+		return t.Bold()
+	}
+	return t.Faint()
+}
+
+// tintANSI moves the 16-color foreground c at index i between its normal
+// and bright variant, and changes the intensity for the remaining levels.
+func (t Style) tintANSI(i int, c ANSIColor, levels int, seq func(ANSIColor) string) Style {
+	switch {
+	case levels > 0 && c < 8: //nolint:mnd
+		t = t.replace(i, seq(c+8)) //nolint:mnd
+		levels--
+	case levels < 0 && c >= 8: //nolint:mnd
+		t = t.replace(i, seq(c-8)) //nolint:mnd
+		levels++
+	}
+
+	if levels == 0 {
+		return t
+	}
+	return t.addIntensity(levels)
+}
+
+// tintRGB changes the lightness of the true color given by its red, green
+// and blue parameters. It reports false if the parameters are malformed.
+func tintRGB(rgb []string, levels int) (string, bool) {
+	var v [3]float64
+	for i, p := range rgb {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return "", false
+		}
+		v[i] = float64(n) / 255 //nolint:mnd
+	}
+
+	l, a, b := colorful.Color{R: v[0], G: v[1], B: v[2]}.Lab()
+	l += float64(levels) * tintStep
+	if l < 0 {
+		l = 0
+	} else if l > 1 {
+		l = 1
+	}
+	return RGBColor(colorful.Lab(l, a, b).Clamped().Hex()).Sequence(false), true
+}
+
+// tintANSI256 moves c by levels along the grayscale ramp, or along each axis
+// of the color cube.
+func tintANSI256(c ANSI256Color, levels int) ANSI256Color {
+	if c >= 232 { //nolint:mnd
+		return ANSI256Color(clamp(int(c)+levels, 232, 255)) //nolint:mnd
+	}
+
+	n := int(c) - 16                         //nolint:mnd
+	r := clamp(n/36+levels, 0, 5)            //nolint:mnd
+	g := clamp(n/6%6+levels, 0, 5)           //nolint:mnd
+	b := clamp(n%6+levels, 0, 5)             //nolint:mnd
+	return ANSI256Color(16 + r*36 + g*6 + b) //nolint:mnd
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
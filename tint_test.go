@@ -0,0 +1,58 @@
+package termenv
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStyleTint(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{"no color dim", String().Dim(1), "2"},
+		{"no color brighten", String().Brighten(1), "1"},
+		{"ansi brighten", String().Foreground(ANSIColor(1)).Brighten(1), "91"},
+		{"ansi brighten twice", String().Foreground(ANSIColor(1)).Brighten(2), "91;1"},
+		{"ansi dim bright", String().Foreground(ANSIColor(9)).Dim(1), "31"},
+		{"ansi dim", String().Foreground(ANSIColor(1)).Dim(1), "31;2"},
+		{"ansi256 low dim", String().Foreground(ANSI256Color(9)).Dim(1), "38;5;1"},
+		{"gray dim", String().Foreground(ANSI256Color(240)).Dim(2), "38;5;238"},
+		{"gray clamp", String().Foreground(ANSI256Color(254)).Brighten(5), "38;5;255"},
+		{"cube brighten", String().Foreground(ANSI256Color(16 + 36 + 6 + 1)).Brighten(1), "38;5;102"},
+		{"rgb black brighten", String().Foreground(RGBColor("#000000")).Brighten(10), "38;2;255;255;255"},
+		{"rgb white dim", String().Foreground(RGBColor("#ffffff")).Dim(10), "38;2;0;0;0"},
+		{"zero levels", String().Foreground(ANSIColor(1)).Dim(0), "31"},
+		{"background untouched", String().Background(ANSIColor(1)).Dim(1), "41;2"},
+	}
+
+	for _, test := range tests {
+		if got := test.style.sequence(); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestStyleTintLightness(t *testing.T) {
+	base := String().Foreground(RGBColor("#808080"))
+	l := func(s Style) float64 {
+		var r, g, b int
+		if _, err := fmt.Sscanf(s.sequence(), "38;2;%d;%d;%d", &r, &g, &b); err != nil {
+			t.Fatal(err)
+		}
+		return float64(r + g + b)
+	}
+
+	if dim, bright := l(base.Dim(1)), l(base.Brighten(1)); !(dim < l(base) && l(base) < bright) {
+		t.Errorf("expected dim < base < bright, got %v, %v, %v", dim, l(base), bright)
+	}
+}
+
+func TestStyleTintCopyOnWrite(t *testing.T) {
+	base := String().Foreground(ANSIColor(1)).Bold()
+	_ = base.Brighten(1)
+	if got := base.sequence(); got != "31;1" {
+		t.Errorf("expected base to be unchanged, got %q", got)
+	}
+}
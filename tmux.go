@@ -0,0 +1,33 @@
+package termenv
+
+import (
+	"strings"
+)
+
+// InTmux returns whether the output is a tmux pane.
+func InTmux() bool {
+	return output.InTmux()
+}
+
+// InTmux returns whether the output is a tmux pane.
+func (o *Output) InTmux() bool {
+	return o.environ.Getenv("TMUX") != "" || o.environ.Getenv("TERM_PROGRAM") == "tmux"
+}
+
+// TmuxPassthrough wraps seq in a tmux passthrough sequence, so tmux forwards
+// it to the outer terminal instead of interpreting or dropping it. tmux 3.3
+// and later only forward it with the allow-passthrough option enabled.
+func TmuxPassthrough(seq string) string {
+	return DCS + "tmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + ST
+}
+
+// WritePassthrough writes seq to the outer terminal, wrapping it in a tmux
+// passthrough sequence if the output is a tmux pane. This keeps sequences
+// tmux doesn't know, e.g. terminal specific ones, from being dropped or
+// mangled, including when tmux is driven by a control mode client.
+func (o *Output) WritePassthrough(seq string) (int, error) {
+	if o.InTmux() {
+		seq = TmuxPassthrough(seq)
+	}
+	return o.WriteString(seq)
+}
@@ -0,0 +1,47 @@
+package termenv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestInTmux(t *testing.T) {
+	for _, test := range []struct {
+		environ  mapEnv
+		expected bool
+	}{
+		{mapEnv{"TERM": "xterm-256color"}, false},
+		{mapEnv{"TMUX": "/tmp/tmux-1000/default,1234,0"}, true},
+		{mapEnv{"TERM_PROGRAM": "tmux"}, true},
+	} {
+		o := NewOutput(&bytes.Buffer{}, WithEnvironment(test.environ))
+		if got := o.InTmux(); got != test.expected {
+			t.Errorf("%v: expected %t, got %t", test.environ, test.expected, got)
+		}
+	}
+}
+
+func TestTmuxPassthrough(t *testing.T) {
+	exp := "\x1bPtmux;\x1b\x1b]1337;SetMark\x1b\x1b\\\x1b\\"
+	if got := TmuxPassthrough(OSC + ITermSetMarkSeq + ST); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
+
+func TestSetUserVar(t *testing.T) {
+	seq := "\x1b]1337;SetUserVar=job=YnVpbGQ=\x1b\\"
+	for _, test := range []struct {
+		environ  mapEnv
+		expected string
+	}{
+		{mapEnv{"TERM_PROGRAM": "WezTerm"}, seq},
+		{mapEnv{"TMUX": "/tmp/tmux-1000/default,1234,0"}, TmuxPassthrough(seq)},
+	} {
+		var buf bytes.Buffer
+		o := NewOutput(&buf, WithEnvironment(test.environ))
+		o.SetUserVar("job", "build")
+		if got := buf.String(); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
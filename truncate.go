@@ -0,0 +1,221 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// EllipsisPosition selects which part of a string Truncate removes.
+type EllipsisPosition int
+
+const (
+	// EllipsisEnd removes the end of the string.
+	EllipsisEnd EllipsisPosition = iota
+	// EllipsisMiddle removes the middle of the string, keeping its start and
+	// end, e.g. for paths.
+	EllipsisMiddle
+	// EllipsisStart removes the start of the string.
+	EllipsisStart
+)
+
+// TruncateOption sets an option on Truncate.
+type TruncateOption = func(*truncater)
+
+type truncater struct {
+	ellipsis string
+	style    *Style
+	position EllipsisPosition
+	words    bool
+}
+
+// WithEllipsis returns a new TruncateOption replacing the default ellipsis
+// "…" marking removed text.
+func WithEllipsis(s string) TruncateOption {
+	return func(t *truncater) {
+		t.ellipsis = s
+	}
+}
+
+// WithEllipsisStyle returns a new TruncateOption rendering the ellipsis with
+// style s. As the ellipsis is embedded in the truncated string, s should
+// usually use ScopedReset.
+func WithEllipsisStyle(s Style) TruncateOption {
+	return func(t *truncater) {
+		t.style = &s
+	}
+}
+
+// WithEllipsisPosition returns a new TruncateOption selecting which part of
+// the string gets removed.
+func WithEllipsisPosition(p EllipsisPosition) TruncateOption {
+	return func(t *truncater) {
+		t.position = p
+	}
+}
+
+// WithWordBoundary returns a new TruncateOption preferring to remove whole
+// words: the string is cut at spaces, after hyphens or at soft hyphens if
+// possible.
+func WithWordBoundary() TruncateOption {
+	return func(t *truncater) {
+		t.words = true
+	}
+}
+
+// truncToken is a grapheme cluster or an escape sequence.
+type truncToken struct {
+	s   string
+	seq bool
+}
+
+// Truncate shortens s to at most width cells, replacing the removed text
+// with an ellipsis. Escape sequences are kept, so styles stay balanced, and
+// don't count towards the width. s is returned unchanged if it fits.
+func Truncate(s string, width int, opts ...TruncateOption) string {
+	t := &truncater{ellipsis: "…"}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	var (
+		tokens    []truncToken
+		graphemes []string // the visible tokens
+		widths    []int
+		total     int
+	)
+	walkText(s, func(text string) {
+		g := uniseg.NewGraphemes(text)
+		for g.Next() {
+			tokens = append(tokens, truncToken{s: g.Str()})
+			graphemes = append(graphemes, g.Str())
+			widths = append(widths, g.Width())
+			total += g.Width()
+		}
+	}, func(seq string) {
+		tokens = append(tokens, truncToken{s: seq, seq: true})
+	})
+	if total <= width {
+		return s
+	}
+
+	ellipsis := t.ellipsis
+	if uniseg.StringWidth(ellipsis) > width {
+		ellipsis = ""
+	}
+	budget := width - uniseg.StringWidth(ellipsis)
+	if t.style != nil && ellipsis != "" {
+		ellipsis = t.style.Styled(ellipsis)
+	}
+
+	// keep the graphemes [0, head) and [tail, n)
+	n := len(graphemes)
+	head, tail := 0, n
+	switch t.position {
+	case EllipsisEnd:
+		head = t.cutEnd(graphemes, fitForward(widths, 0, budget))
+	case EllipsisStart:
+		tail = t.cutStart(graphemes, fitBackward(widths, n, budget))
+	case EllipsisMiddle:
+		left := (budget + 1) / 2 //nolint:mnd
+		head = fitForward(widths, 0, left)
+		tail = fitBackward(widths, n, budget-sumWidths(widths[:head]))
+		head = t.cutEnd(graphemes, head)
+		tail = t.cutStart(graphemes, tail)
+	}
+
+	var b strings.Builder
+	i := 0 // index of the next grapheme
+	if head == 0 {
+		b.WriteString(ellipsis)
+	}
+	for _, tok := range tokens {
+		if tok.seq {
+			b.WriteString(tok.s)
+			continue
+		}
+		if i < head || i >= tail {
+			b.WriteString(tok.s)
+		}
+		i++
+		if i == head && head > 0 {
+			b.WriteString(ellipsis)
+		}
+	}
+	return b.String()
+}
+
+// fitForward returns the end of the graphemes starting at start that fit
+// into width cells.
+func fitForward(widths []int, start, width int) int {
+	i := start
+	for ; i < len(widths) && widths[i] <= width; i++ {
+		width -= widths[i]
+	}
+	return i
+}
+
+// fitBackward returns the start of the graphemes ending at end that fit
+// into width cells.
+func fitBackward(widths []int, end, width int) int {
+	i := end
+	for ; i > 0 && widths[i-1] <= width; i-- {
+		width -= widths[i-1]
+	}
+	return i
+}
+
+func sumWidths(widths []int) int {
+	var n int
+	for _, w := range widths {
+		n += w
+	}
+	return n
+}
+
+// cutEnd moves the end of the kept graphemes [0, end) back to a word
+// boundary, if enabled, and drops trailing spaces.
+func (t *truncater) cutEnd(g []string, end int) int {
+	if t.words && end < len(g) {
+		for i := end; i > 0; i-- {
+			if isWordBoundary(g, i) {
+				end = i
+				break
+			}
+		}
+	}
+	for end > 0 && (g[end-1] == " " || g[end-1] == string(softHyphen)) {
+		end--
+	}
+	return end
+}
+
+// cutStart moves the start of the kept graphemes [start, n) forward to a
+// word boundary, if enabled, and drops leading spaces.
+func (t *truncater) cutStart(g []string, start int) int {
+	if t.words && start > 0 {
+		for i := start; i < len(g); i++ {
+			if isWordBoundary(g, i) {
+				start = i
+				break
+			}
+		}
+	}
+	for start < len(g) && g[start] == " " {
+		start++
+	}
+	return start
+}
+
+// isWordBoundary returns whether a string may be cut between the graphemes
+// i-1 and i.
+func isWordBoundary(g []string, i int) bool {
+	if i == 0 || i == len(g) || g[i] == " " {
+		return true
+	}
+	switch g[i-1] {
+	case " ", "-", string(softHyphen):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		width    int
+		opts     []TruncateOption
+		expected string
+	}{
+		{"fits", "hello", 5, nil, "hello"},
+		{"end", "hello world", 8, nil, "hello w…"},
+		{"end trailing space", "hello world", 7, nil, "hello…"},
+		{"start", "hello world", 8, []TruncateOption{WithEllipsisPosition(EllipsisStart)}, "…o world"},
+		{"middle", "/usr/local/share/doc", 11, []TruncateOption{WithEllipsisPosition(EllipsisMiddle)}, "/usr/…e/doc"},
+		{"custom ellipsis", "hello world", 8, []TruncateOption{WithEllipsis("...")}, "hello..."},
+		{"ellipsis too wide", "hello", 2, []TruncateOption{WithEllipsis("...")}, "he"},
+		{"words", "the quick brown fox", 14, []TruncateOption{WithWordBoundary()}, "the quick…"},
+		{"words hyphen", "well-known fact", 9, []TruncateOption{WithWordBoundary()}, "well-…"},
+		{"words soft hyphen", "hyphen\u00adation", 10, []TruncateOption{WithWordBoundary()}, "hyphen…"},
+		{"words single word", "incomprehensible", 6, []TruncateOption{WithWordBoundary()}, "incom…"},
+		{"words start", "the quick brown fox", 10, []TruncateOption{WithWordBoundary(), WithEllipsisPosition(EllipsisStart)}, "…brown fox"},
+		{"wide", "日本語の文章", 7, nil, "日本語…"},
+		{"styled", "\x1b[1mhello world\x1b[0m", 6, nil, "\x1b[1mhello…\x1b[0m"},
+		{"styled ellipsis", "hello world", 6, []TruncateOption{WithEllipsisStyle(String().Faint())}, "hello\x1b[2m…\x1b[0m"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Truncate(test.in, test.width, test.opts...)
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+			if w := StringWidth(got); w > test.width {
+				t.Errorf("expected at most %d cells, got %d", test.width, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package termenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UnderlineStyle is the shape of an underline, as set with the sub-parameter
+// of SGR 4, e.g. "4:3" for a curly underline.
+type UnderlineStyle int
+
+// Underline styles.
+const (
+	UnderlineNone UnderlineStyle = iota
+	UnderlineSingle
+	UnderlineDouble
+	UnderlineCurly
+	UnderlineDotted
+	UnderlineDashed
+)
+
+// UnderlineColorSeq is the SGR parameter setting the underline color.
+const UnderlineColorSeq = "58"
+
+// underlineTerms lists the TERM_PROGRAM and TERM values of terminals known
+// to support underline styles and colors.
+var underlineTerms = map[string]bool{
+	"alacritty":     true,
+	"contour":       true,
+	"foot":          true,
+	"foot-extra":    true,
+	"ghostty":       true,
+	"iTerm.app":     true,
+	"WezTerm":       true,
+	"wezterm":       true,
+	"xterm-ghostty": true,
+	"xterm-kitty":   true,
+}
+
+// UnderlineStyle sets the shape of the underline, e.g. UnderlineCurly for
+// spell checking, replacing a previous underline. UnderlineNone removes the
+// underline. Terminals without
+// support for underline styles may misrender them; Output.Adapt replaces
+// them with a plain underline.
+func (t Style) UnderlineStyle(u UnderlineStyle) Style {
+	switch {
+	case u == UnderlineNone:
+		return t.UnsetUnderline()
+	case u == UnderlineSingle:
+		return t.set(UnderlineSeq)
+	case u > UnderlineDashed:
+		return t
+	}
+	return t.set(UnderlineSeq + ":" + strconv.Itoa(int(u)))
+}
+
+// UnderlineColor sets the color of the underline, replacing a previous
+// underline color. It doesn't enable the underline itself. Terminals without support for underline colors may
+// misrender them; Output.Adapt removes them.
+func (t Style) UnderlineColor(c Color) Style {
+	if c == nil {
+		return t
+	}
+	var seq string
+	switch v := resolveColor(t.adapt(c)).(type) {
+	case ANSIColor:
+		seq = fmt.Sprintf("%s;5;%d", UnderlineColorSeq, v)
+	case ANSI256Color:
+		seq = fmt.Sprintf("%s;5;%d", UnderlineColorSeq, v)
+	case RGBColor:
+		r, g, b, err := v.Values()
+		if err != nil {
+			return t
+		}
+		seq = fmt.Sprintf("%s;2;%d;%d;%d", UnderlineColorSeq, r, g, b)
+	case AdaptiveColor:
+		return t.UnderlineColor(v.Resolve(true))
+	default:
+		return t
+	}
+	return t.set(seq)
+}
+
+// set returns a copy of t with seq replacing the sequences of its layer,
+// whether or not t deduplicates its styles.
+func (t Style) set(seq string) Style {
+	dedup := t.dedup
+	t.dedup = true
+	t = t.add(seq)
+	t.dedup = dedup
+	return t
+}
+
+// UnsetUnderlineColor removes the underline color from the Style.
+func (t Style) UnsetUnderlineColor() Style {
+	return t.remove(func(s string) bool {
+		return styleLayer(s) == layerUnderlineColor
+	}, func(*StyleSpec) bool {
+		return false
+	})
+}
+
+// isUnderlineStyleSeq returns whether seq is an underline with a style other
+// than a plain underline, e.g. "4:3".
+func isUnderlineStyleSeq(seq string) bool {
+	return strings.HasPrefix(seq, UnderlineSeq+":") && seq != UnderlineSeq+":1"
+}
+
+// supportsUnderlineStyles returns whether the terminal supports underline
+// styles and colors.
+func (o *Output) supportsUnderlineStyles() bool {
+	term := o.environ.Getenv("TERM")
+	program := o.environ.Getenv("TERM_PROGRAM")
+	if underlineTerms[program] || underlineTerms[term] {
+		return true
+	}
+	// VTE supports both since 0.51.2
+	v, err := strconv.Atoi(o.environ.Getenv("VTE_VERSION"))
+	return err == nil && v >= 5102 //nolint:mnd
+}
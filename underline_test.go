@@ -0,0 +1,78 @@
+package termenv
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestUnderlineStyle(t *testing.T) {
+	s := TrueColor.String("foo")
+	tests := []struct {
+		style    Style
+		expected string
+	}{
+		{s.UnderlineStyle(UnderlineCurly), "4:3"},
+		{s.UnderlineStyle(UnderlineSingle), "4"},
+		{s.UnderlineStyle(UnderlineDouble).UnderlineStyle(UnderlineDashed), "4:5"},
+		{s.UnderlineStyle(UnderlineDotted).Underline(), "4:4;4"},
+		{s.Underline().UnderlineStyle(UnderlineDotted), "4:4"},
+		{s.Bold().UnderlineStyle(UnderlineCurly).UnderlineStyle(UnderlineNone), "1"},
+		{s.UnderlineStyle(UnderlineCurly).UnsetUnderline(), ""},
+		{s.UnderlineStyle(UnderlineStyle(42)), ""},
+		{s.UnderlineColor(RGBColor("#ff8000")), "58;2;255;128;0"},
+		{s.UnderlineColor(ANSIColor(1)).UnderlineColor(ANSI256Color(200)), "58;5;200"},
+		{s.UnderlineColor(ANSIColor(1)).Foreground(ANSIColor(2)).UnsetUnderlineColor(), "32"},
+		{s.UnderlineColor(NoColor{}).UnderlineColor(nil), ""},
+		{s.UnderlineStyle(UnderlineCurly).Inherit(s.Underline().UnderlineColor(ANSIColor(1))), "4:3;58;5;1"},
+	}
+	for i, test := range tests {
+		if got := test.style.Sequence(); got != test.expected {
+			t.Errorf("#%d: expected %q, got %q", i, test.expected, got)
+		}
+	}
+
+	// underline colors don't leak into the other attributes of a StyleSpec
+	spec := NewStyleSpec(s.UnderlineColor(RGBColor("#010203")).UnderlineStyle(UnderlineCurly))
+	if !spec.equal(StyleSpec{Underline: true}) {
+		t.Errorf("expected an underlined StyleSpec, got %+v", spec)
+	}
+}
+
+func TestUnderlineCapabilities(t *testing.T) {
+	s := TrueColor.String("foo").Bold().UnderlineStyle(UnderlineCurly).UnderlineColor(ANSIColor(1))
+
+	tests := []struct {
+		name     string
+		environ  map[string]string
+		warnings []string
+		adapted  string
+	}{
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, nil, "1;4:3;58;5;1"},
+		{"wezterm", map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, nil, "1;4:3;58;5;1"},
+		{"vte", map[string]string{"TERM": "xterm-256color", "VTE_VERSION": "6003"}, nil, "1;4:3;58;5;1"},
+		{"old vte", map[string]string{"TERM": "xterm-256color", "VTE_VERSION": "5000"}, []string{"4:3", "58;5;1"}, "1;4"},
+		{"xterm", map[string]string{"TERM": "xterm-256color"}, []string{"4:3", "58;5;1"}, "1;4"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := NewOutput(io.Discard, WithEnvironment(mapEnv(test.environ)), WithProfile(TrueColor))
+
+			var attrs []string
+			for _, w := range o.Lint(s) {
+				attrs = append(attrs, w.Attribute)
+			}
+			if !reflect.DeepEqual(attrs, test.warnings) {
+				t.Errorf("expected warnings %v, got %v", test.warnings, attrs)
+			}
+			if got := o.Adapt(s).Sequence(); got != test.adapted {
+				t.Errorf("expected %q, got %q", test.adapted, got)
+			}
+		})
+	}
+
+	caps := Capabilities{Profile: TrueColor, Italic: true}
+	if got := Adapt(s.Italic(), caps).Sequence(); got != "3" {
+		t.Errorf("expected unsupported attributes to be dropped, got %q", got)
+	}
+}
@@ -0,0 +1,47 @@
+package termenv
+
+import (
+	"strings"
+)
+
+// SupportsUnicode reports whether the terminal is expected to render Unicode
+// characters, e.g. box drawing characters or symbols. Applications can use
+// it to fall back to an ASCII-only layout.
+func SupportsUnicode() bool {
+	return output.SupportsUnicode()
+}
+
+// SupportsUnicode reports whether the terminal is expected to render Unicode
+// characters. The character encoding of the locale, taken from LC_ALL,
+// LC_CTYPE, or LANG, decides. Without a locale, Windows consoles are checked
+// for the UTF-8 code page. Serial terminals and safe mode never support
+// Unicode.
+func (o *Output) SupportsUnicode() bool {
+	if o.safe || isSerialTerm(o.environ.Getenv("TERM")) {
+		return false
+	}
+
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := o.environ.Getenv(key); v != "" {
+			return isUTF8Locale(v)
+		}
+	}
+
+	return o.defaultUnicode()
+}
+
+// isUTF8Locale returns whether the locale uses the UTF-8 encoding, e.g.
+// "en_US.UTF-8" or "C.utf8".
+func isUTF8Locale(locale string) bool {
+	// strip the modifier, e.g. "@euro"
+	if i := strings.IndexByte(locale, '@'); i >= 0 {
+		locale = locale[:i]
+	}
+	i := strings.IndexByte(locale, '.')
+	if i < 0 {
+		return false
+	}
+
+	charset := strings.ToLower(strings.ReplaceAll(locale[i+1:], "-", ""))
+	return charset == "utf8"
+}
@@ -0,0 +1,33 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestSupportsUnicode(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected bool
+	}{
+		{map[string]string{"LANG": "en_US.UTF-8"}, true},
+		{map[string]string{"LANG": "de_DE.utf8@euro"}, true},
+		{map[string]string{"LANG": "C"}, false},
+		{map[string]string{"LANG": "en_US.ISO-8859-1"}, false},
+		{map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, false},
+		{map[string]string{"LC_CTYPE": "C.UTF-8", "LANG": "C"}, true},
+		{map[string]string{"TERM": "vt100", "LANG": "en_US.UTF-8"}, false},
+	}
+
+	for _, test := range tests {
+		o := NewOutput(nil, WithEnvironment(mapEnv(test.env)))
+		if got := o.SupportsUnicode(); got != test.expected {
+			t.Errorf("%v: expected %t, got %t", test.env, test.expected, got)
+		}
+	}
+
+	o := NewOutput(nil, WithEnvironment(mapEnv{"LANG": "en_US.UTF-8"}))
+	o.ForceSafe()
+	if o.SupportsUnicode() {
+		t.Error("expected no Unicode support in safe mode")
+	}
+}
@@ -0,0 +1,203 @@
+package termenv
+
+// UnsetBold removes bold rendering from the Style.
+func (t Style) UnsetBold() Style {
+	return t.unset(BoldSeq, func(s *StyleSpec) *bool { return &s.Bold })
+}
+
+// UnsetFaint removes faint rendering from the Style.
+func (t Style) UnsetFaint() Style {
+	return t.unset(FaintSeq, func(s *StyleSpec) *bool { return &s.Faint })
+}
+
+// UnsetItalic removes italic rendering from the Style.
+func (t Style) UnsetItalic() Style {
+	return t.unset(ItalicSeq, func(s *StyleSpec) *bool { return &s.Italic })
+}
+
+// UnsetUnderline removes underline rendering, including underline styles,
+// from the Style.
+func (t Style) UnsetUnderline() Style {
+	return t.remove(func(s string) bool {
+		return styleLayer(s) == layerUnderline
+	}, func(s *StyleSpec) bool {
+		set := s.Underline
+		s.Underline = false
+		return set
+	})
+}
+
+// UnsetOverline removes overline rendering from the Style.
+func (t Style) UnsetOverline() Style {
+	return t.unset(OverlineSeq, func(s *StyleSpec) *bool { return &s.Overline })
+}
+
+// UnsetBlink removes blink mode from the Style.
+func (t Style) UnsetBlink() Style {
+	return t.unset(BlinkSeq, func(s *StyleSpec) *bool { return &s.Blink })
+}
+
+// UnsetReverse removes reverse color mode from the Style.
+func (t Style) UnsetReverse() Style {
+	return t.unset(ReverseSeq, func(s *StyleSpec) *bool { return &s.Reverse })
+}
+
+// UnsetCrossOut removes crossed-out rendering from the Style.
+func (t Style) UnsetCrossOut() Style {
+	return t.unset(CrossOutSeq, func(s *StyleSpec) *bool { return &s.CrossOut })
+}
+
+// UnsetForeground removes the foreground color from the Style, so the text
+// gets rendered in the terminal's default color.
+func (t Style) UnsetForeground() Style {
+	return t.unsetColor(layerForeground)
+}
+
+// UnsetBackground removes the background color from the Style.
+func (t Style) UnsetBackground() Style {
+	return t.unsetColor(layerBackground)
+}
+
+// unset removes the attribute seq from t. attr returns the attribute's field
+// of a StyleSpec.
+func (t Style) unset(seq string, attr func(*StyleSpec) *bool) Style {
+	return t.remove(func(s string) bool {
+		return s == seq
+	}, func(s *StyleSpec) bool {
+		set := *attr(s)
+		*attr(s) = false
+		return set
+	})
+}
+
+// unsetColor removes the colors of the given layer from t.
+func (t Style) unsetColor(layer int) Style {
+	return t.remove(func(s string) bool {
+		return styleLayer(s) == layer
+	}, func(s *StyleSpec) bool {
+		c := &s.Foreground
+		if layer == layerBackground {
+			c = &s.Background
+		}
+		set := *c != nil
+		*c = nil
+		return set
+	})
+}
+
+// remove returns a copy of t without the styles matched by drop. If the
+// remaining styles still apply the attribute, e.g. in raw parameters like
+// "1;31", clearSpec removes it from their StyleSpec and the styles get
+// replaced by the ones of the StyleSpec.
+func (t Style) remove(drop func(string) bool, clearSpec func(*StyleSpec) bool) Style {
+	if len(t.styles) == 0 {
+		return t
+	}
+
+	styles := make([]string, 0, len(t.styles))
+	for _, s := range t.styles {
+		if !drop(s) {
+			styles = append(styles, s)
+		}
+	}
+
+	var spec StyleSpec
+	for _, s := range styles {
+		spec.applySGR(s)
+	}
+	if clearSpec(&spec) {
+		styles = spec.Style(t.profile).styles
+	}
+
+	t.styles = styles
+	t.seq = &styleSeq{}
+	return t
+}
+
+// hasLayer returns whether t has a sequence of the given layer.
+func (t Style) hasLayer(layer int) bool {
+	for _, s := range t.styles {
+		if styleLayer(s) == layer {
+			return true
+		}
+	}
+	return false
+}
+
+// has returns whether seq is one of the styles of t.
+func (t Style) has(seq string) bool {
+	for _, s := range t.styles {
+		if s == seq {
+			return true
+		}
+	}
+	return false
+}
+
+// Inherit returns a copy of t with the colors and attributes of parent it
+// doesn't set itself, e.g. to derive the style of a widget's label from the
+// style of the widget:
+//
+//	label := output.String().Bold().Inherit(base)
+//
+// Colors of t take precedence over the ones of parent. The hyperlink and
+// metadata of parent are inherited as well, unless t sets them. The text of
+// t, its profile and its text policies are kept.
+func (t Style) Inherit(parent Style) Style {
+	own := NewStyleSpec(t)
+	for _, seq := range parent.styles {
+		if t.has(seq) {
+			continue
+		}
+		switch styleLayer(seq) {
+		case layerForeground:
+			if own.Foreground == nil {
+				t = t.add(seq)
+			}
+			continue
+		case layerBackground:
+			if own.Background == nil {
+				t = t.add(seq)
+			}
+			continue
+		case layerUnderline:
+			if !own.Underline {
+				t = t.add(seq)
+			}
+			continue
+		case layerUnderlineColor:
+			if !t.hasLayer(layerUnderlineColor) {
+				t = t.add(seq)
+			}
+			continue
+		}
+
+		var spec StyleSpec
+		spec.applySGR(seq)
+		if (spec.Foreground == nil || own.Foreground == nil) && (spec.Background == nil || own.Background == nil) {
+			t = t.add(seq)
+			continue
+		}
+		// raw parameters mixing colors and attributes: only take what t
+		// doesn't set
+		if own.Foreground != nil {
+			spec.Foreground = nil
+		}
+		if own.Background != nil {
+			spec.Background = nil
+		}
+		for _, s := range spec.Style(t.profile).styles {
+			t = t.add(s)
+		}
+	}
+
+	if t.link == "" {
+		t.link = parent.link
+	}
+	for k, v := range parent.meta {
+		if _, ok := t.meta[k]; !ok {
+			t = t.WithMeta(k, v)
+		}
+	}
+	return t
+}
@@ -0,0 +1,71 @@
+package termenv
+
+import "testing"
+
+func TestStyleUnset(t *testing.T) {
+	s := TrueColor.String().
+		Foreground(ANSIColor(1)).
+		Background(RGBColor("#0000ff")).
+		Bold().
+		Italic().
+		Underline()
+
+	tests := []struct {
+		style    Style
+		expected string
+	}{
+		{s.UnsetBold(), "31;48;2;0;0;255;3;4"},
+		{s.UnsetItalic().UnsetUnderline(), "31;48;2;0;0;255;1"},
+		{s.UnsetForeground(), "48;2;0;0;255;1;3;4"},
+		{s.UnsetBackground().UnsetForeground(), "1;3;4"},
+		{s.UnsetBold().Bold(), "31;48;2;0;0;255;3;4;1"},
+		{s.UnsetFaint().UnsetBlink(), s.Sequence()},
+		{TrueColor.String().UnsetBold(), ""},
+		// raw parameters get normalized when they apply the attribute
+		{TrueColor.String().add("1;31;4").UnsetBold(), "31;4"},
+		{TrueColor.String().add("1;31;4").UnsetForeground(), "1;4"},
+		{TrueColor.String().add("1;31").Italic().UnsetReverse(), "1;31;3"},
+	}
+	for i, test := range tests {
+		if got := test.style.Sequence(); got != test.expected {
+			t.Errorf("#%d: expected %q, got %q", i, test.expected, got)
+		}
+	}
+
+	if got := s.Styled("foo"); got != "\x1b[31;48;2;0;0;255;1;3;4mfoo\x1b[0m" {
+		t.Errorf("unset modified the original style: %q", got)
+	}
+}
+
+func TestStyleInherit(t *testing.T) {
+	base := TrueColor.String().
+		Foreground(ANSIColor(2)).
+		Background(ANSIColor(0)).
+		Bold().
+		Hyperlink("https://example.com").
+		WithMeta("role", "base")
+
+	tests := []struct {
+		style    Style
+		expected string
+	}{
+		{TrueColor.String("foo"), "32;40;1"},
+		{TrueColor.String("foo").Italic(), "3;32;40;1"},
+		{TrueColor.String("foo").Foreground(ANSIColor(1)), "31;40;1"},
+		{TrueColor.String("foo").Background(ANSI256Color(100)).Bold(), "48;5;100;1;32"},
+		{TrueColor.String("foo").Foreground(ANSIColor(1)).Inherit(TrueColor.String().add("4;34;43")), "31;43;4;1"},
+	}
+	for i, test := range tests {
+		if got := test.style.Inherit(base).Sequence(); got != test.expected {
+			t.Errorf("#%d: expected %q, got %q", i, test.expected, got)
+		}
+	}
+
+	s := TrueColor.String("foo").WithMeta("role", "label").WithMeta("id", 1).Inherit(base)
+	if v, _ := s.Meta("role"); v != "label" {
+		t.Errorf("expected the style's own metadata to win, got %v", v)
+	}
+	if s.link != "https://example.com" || s.string != "foo" {
+		t.Errorf("expected the parent's link and the own text, got %q and %q", s.link, s.string)
+	}
+}
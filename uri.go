@@ -0,0 +1,69 @@
+package termenv
+
+import (
+	"os"
+	"strings"
+)
+
+const upperhex = "0123456789ABCDEF"
+
+// percentEncode escapes all bytes of s for which keep returns false.
+func percentEncode(s string, keep func(byte) bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if keep(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&15]) //nolint:mnd
+	}
+	return b.String()
+}
+
+// isUnreserved returns whether c is an unreserved URI character.
+func isUnreserved(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// EncodeFileURL returns a file URL for the given host and path, as expected by
+// OSC 7 and OSC 8. Spaces, non-ASCII characters, and everything else that
+// isn't allowed in a URL get percent-encoded as UTF-8. Windows paths like
+// `C:\Users` are converted to "/C:/Users".
+func EncodeFileURL(host, path string) string {
+	if len(path) >= 2 && path[1] == ':' && (len(path) == 2 || path[2] == '\\' || path[2] == '/') {
+		path = "/" + strings.ReplaceAll(path, `\`, "/")
+	}
+
+	host = percentEncode(host, isUnreserved)
+	path = percentEncode(path, func(c byte) bool {
+		return isUnreserved(c) || c == '/' || c == ':'
+	})
+	return "file://" + host + path
+}
+
+// EncodeHyperlinkURL returns link with all bytes that may not appear in an
+// OSC 8 hyperlink percent-encoded. Only printable ASCII characters are
+// allowed; control characters in particular would terminate the sequence
+// early. Existing escapes are left untouched.
+func EncodeHyperlinkURL(link string) string {
+	return percentEncode(link, func(c byte) bool {
+		return c > ' ' && c < 0x7f //nolint:mnd
+	})
+}
+
+// NotifyCWD tells the terminal the current working directory using OSC 7, so
+// it can open new tabs or windows in the same directory.
+func NotifyCWD(path string) {
+	output.NotifyCWD(path)
+}
+
+// NotifyCWD tells the terminal the current working directory using OSC 7, so
+// it can open new tabs or windows in the same directory.
+func (o *Output) NotifyCWD(path string) {
+	host, _ := os.Hostname()
+	_, _ = o.WriteString(OSC + "7;" + EncodeFileURL(host, path) + ST)
+}
@@ -0,0 +1,52 @@
+package termenv
+
+import (
+	"os"
+	"testing"
+)
+
+func TestEncodeFileURL(t *testing.T) {
+	tests := []struct {
+		host     string
+		path     string
+		expected string
+	}{
+		{"host", "/home/user", "file://host/home/user"},
+		{"host", "/tmp/my dir/ä", "file://host/tmp/my%20dir/%C3%A4"},
+		{"müller", "/", "file://m%C3%BCller/"},
+		{"", `C:\Users\me`, "file:///C:/Users/me"},
+		{"host", "/a#b?c%d", "file://host/a%23b%3Fc%25d"},
+	}
+
+	for _, test := range tests {
+		if got := EncodeFileURL(test.host, test.path); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestEncodeHyperlinkURL(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"http://example.com/?q=a%20b", "http://example.com/?q=a%20b"},
+		{"http://example.com/a b", "http://example.com/a%20b"},
+		{"http://example.com/\x1b\\", "http://example.com/%1B\\"},
+		{"http://example.com/ü", "http://example.com/%C3%BC"},
+	}
+
+	for _, test := range tests {
+		if got := EncodeHyperlinkURL(test.in); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestNotifyCWD(t *testing.T) {
+	host, _ := os.Hostname()
+
+	o := tempOutput(t)
+	o.NotifyCWD("/tmp/a b")
+	verify(t, o, "\x1b]7;"+EncodeFileURL(host, "/tmp/a b")+"\x1b\\")
+}
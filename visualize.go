@@ -0,0 +1,88 @@
+package termenv
+
+import (
+	"strings"
+)
+
+// VisualizeMode controls how Visualize renders control characters.
+type VisualizeMode int
+
+const (
+	// VisualizePictures renders C0 control characters as the symbols of
+	// the Unicode Control Pictures block, e.g. ␀, ␉, and ␊.
+	VisualizePictures VisualizeMode = iota
+	// VisualizeCaret renders control characters in caret notation, e.g. ^@,
+	// ^I, and ^J.
+	VisualizeCaret
+)
+
+// VisualizeOption sets an option on Visualize.
+type VisualizeOption = func(*visualizer)
+
+// WithVisualizeMode returns a new VisualizeOption rendering control characters
+// in the given mode.
+func WithVisualizeMode(m VisualizeMode) VisualizeOption {
+	return func(v *visualizer) {
+		v.mode = m
+	}
+}
+
+// WithVisualizeStyle returns a new VisualizeOption rendering the replacement
+// glyphs with the given style, to tell them apart from literal text.
+func WithVisualizeStyle(s Style) VisualizeOption {
+	return func(v *visualizer) {
+		v.style = s
+	}
+}
+
+// visualizer holds the options of a Visualize call.
+type visualizer struct {
+	mode  VisualizeMode
+	style Style
+}
+
+// Visualize returns s with all control characters replaced by visible glyphs,
+// e.g. to display binary files or to debug user input. C1 control characters,
+// which have no control pictures, are rendered as "M-" followed by the caret
+// notation of the matching C0 control character, like cat -v does.
+func Visualize(s string, opts ...VisualizeOption) string {
+	v := visualizer{
+		style: String(),
+	}
+	for _, opt := range opts {
+		opt(&v)
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		g := v.glyph(r)
+		if g == "" {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(v.style.Styled(g))
+	}
+	return b.String()
+}
+
+// glyph returns the replacement for r, or an empty string if r isn't a
+// control character.
+//
+//nolint:mnd
+func (v visualizer) glyph(r rune) string {
+	switch {
+	case r < 0x20:
+		if v.mode == VisualizePictures {
+			return string(0x2400 + r)
+		}
+		return "^" + string(r+0x40)
+	case r == 0x7f:
+		if v.mode == VisualizePictures {
+			return "␡"
+		}
+		return "^?"
+	case r >= 0x80 && r < 0xa0:
+		return "M-^" + string(r-0x80+0x40)
+	}
+	return ""
+}
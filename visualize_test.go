@@ -0,0 +1,27 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestVisualize(t *testing.T) {
+	tests := []struct {
+		in       string
+		opts     []VisualizeOption
+		expected string
+	}{
+		{"a\tb\n", nil, "a␉b␊"},
+		{"\x00\x1b[1m\x7f", nil, "␀␛[1m␡"},
+		{"a\tb\n", []VisualizeOption{WithVisualizeMode(VisualizeCaret)}, "a^Ib^J"},
+		{"\x00\x7f", []VisualizeOption{WithVisualizeMode(VisualizeCaret)}, "^@^?"},
+		{"\u0085", nil, "M-^E"},
+		{"日本", nil, "日本"},
+		{"a\tb", []VisualizeOption{WithVisualizeStyle(String().Faint())}, "a\x1b[2m␉\x1b[0mb"},
+	}
+
+	for _, test := range tests {
+		if got := Visualize(test.in, test.opts...); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package termenv
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// SetUserVarSeq sets a user variable, supported by WezTerm and iTerm2.
+const SetUserVarSeq = "1337;SetUserVar=%s=%s"
+
+// SetUserVar sets the user variable name to value. WezTerm exposes user
+// variables to its Lua configuration, e.g. to adjust the tab title or react
+// to the program running in a pane.
+func SetUserVar(name, value string) {
+	output.SetUserVar(name, value)
+}
+
+// SetUserVar sets the user variable name to value. WezTerm exposes user
+// variables to its Lua configuration, e.g. to adjust the tab title or react
+// to the program running in a pane. Inside tmux the sequence is passed
+// through to the outer terminal.
+func (o *Output) SetUserVar(name, value string) {
+	enc := base64.StdEncoding.EncodeToString([]byte(value))
+	_, _ = o.WritePassthrough(fmt.Sprintf(OSC+SetUserVarSeq+ST, name, enc))
+}
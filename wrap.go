@@ -0,0 +1,239 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// softHyphen marks a position a word may be hyphenated at. It is only
+// rendered, as a hyphen, if a line gets broken there.
+const softHyphen = '\u00ad'
+
+// WrapMode selects where WrapText may break lines.
+type WrapMode int
+
+const (
+	// WrapWords breaks lines at spaces and soft hyphens.
+	WrapWords WrapMode = iota
+	// WrapUnicode breaks lines according to the Unicode line breaking
+	// algorithm (UAX #14), which also finds break opportunities after
+	// hyphens and between ideographs, e.g. in CJK text.
+	WrapUnicode
+)
+
+// WrapOption sets an option on WrapText.
+type WrapOption = func(*wrapper)
+
+type wrapper struct {
+	mode WrapMode
+}
+
+// WithWrapMode returns a new WrapOption selecting where lines may be broken.
+func WithWrapMode(m WrapMode) WrapOption {
+	return func(w *wrapper) {
+		w.mode = m
+	}
+}
+
+// wrapSeq is an escape sequence located at a byte offset of the plain text.
+type wrapSeq struct {
+	pos int
+	seq string
+}
+
+// wrapSegment is a run of plain text ending at a break opportunity.
+type wrapSegment struct {
+	start, end int
+	mustBreak  bool
+}
+
+// WrapText wraps s to lines of at most width cells. Escape sequences are
+// kept and don't count towards the width, and lines are never broken inside
+// the text of an OSC 8 hyperlink. Spaces at a line break get dropped, and a
+// soft hyphen (U+00AD) at a line break is rendered as a hyphen; all other
+// soft hyphens are removed.
+//
+// Words that don't fit a line on their own are not broken, so lines may
+// exceed width.
+func WrapText(s string, width int, opts ...WrapOption) string {
+	w := &wrapper{}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	plain, seqs, links := splitWrapText(s)
+	segments := w.segments(plain)
+	segments = joinLinkSegments(segments, links)
+
+	var b strings.Builder
+	next := 0 // the next sequence to write
+	write := func(start, end int, text bool) {
+		for i, r := range plain[start:end] {
+			for ; next < len(seqs) && seqs[next].pos <= start+i; next++ {
+				b.WriteString(seqs[next].seq)
+			}
+			if text && r != softHyphen {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	var (
+		lineWidth int
+		// the spaces ending the previous segment, only written once the
+		// line continues
+		pendingStart, pendingEnd int
+		hyphenate                bool
+	)
+	for _, seg := range segments {
+		text := plain[seg.start:seg.end]
+		body := strings.TrimRight(strings.TrimRight(text, "\r\n"), " ")
+		bodyEnd := seg.start + len(body)
+		bodyWidth := textWidth(body)
+		pendingWidth := pendingEnd - pendingStart
+
+		if lineWidth > 0 && lineWidth+pendingWidth+bodyWidth > width {
+			write(pendingStart, pendingEnd, false)
+			if hyphenate {
+				b.WriteByte('-')
+			}
+			b.WriteByte('\n')
+			lineWidth = 0
+		} else {
+			write(pendingStart, pendingEnd, true)
+			lineWidth += pendingWidth
+		}
+
+		write(seg.start, bodyEnd, true)
+		lineWidth += bodyWidth
+		hyphenate = strings.HasSuffix(body, string(softHyphen))
+
+		pendingStart, pendingEnd = bodyEnd, seg.end
+		if bodyEnd < seg.end && strings.ContainsAny(plain[bodyEnd:seg.end], "\r\n") {
+			// a mandatory break: spaces before it get dropped
+			for i, r := range plain[bodyEnd:seg.end] {
+				if r != ' ' {
+					write(bodyEnd, bodyEnd+i, false)
+					write(bodyEnd+i, seg.end, true)
+					break
+				}
+			}
+			pendingStart, pendingEnd = seg.end, seg.end
+			lineWidth = 0
+		}
+	}
+	write(pendingStart, pendingEnd, true)
+	for ; next < len(seqs); next++ {
+		b.WriteString(seqs[next].seq)
+	}
+	return b.String()
+}
+
+// textWidth returns the number of cells the plain text s occupies, ignoring
+// soft hyphens.
+func textWidth(s string) int {
+	return uniseg.StringWidth(strings.ReplaceAll(s, string(softHyphen), ""))
+}
+
+// splitWrapText separates s into its plain text and escape sequences, and
+// returns the byte ranges of the plain text covered by OSC 8 hyperlinks.
+func splitWrapText(s string) (string, []wrapSeq, [][2]int) {
+	var (
+		plain strings.Builder
+		seqs  []wrapSeq
+		links [][2]int
+	)
+
+	linkStart := -1
+	walkText(s, func(text string) {
+		plain.WriteString(text)
+	}, func(seq string) {
+		pos := plain.Len()
+		seqs = append(seqs, wrapSeq{pos: pos, seq: seq})
+
+		sq, _, _ := ParseSequence(seq)
+		if sq.Kind != SeqOSC || sq.Params != "8" {
+			return
+		}
+		uri := sq.Data
+		if i := strings.IndexByte(uri, ';'); i >= 0 {
+			uri = uri[i+1:]
+		}
+		switch {
+		case uri != "" && linkStart < 0:
+			linkStart = pos
+		case uri == "" && linkStart >= 0:
+			links = append(links, [2]int{linkStart, pos})
+			linkStart = -1
+		}
+	})
+	return plain.String(), seqs, links
+}
+
+// segments splits s into runs of text ending at break opportunities.
+func (w *wrapper) segments(s string) []wrapSegment {
+	var segments []wrapSegment
+
+	if w.mode == WrapUnicode {
+		state := -1
+		for pos := 0; pos < len(s); {
+			var (
+				seg       string
+				mustBreak bool
+			)
+			seg, _, mustBreak, state = uniseg.FirstLineSegmentInString(s[pos:], state)
+			segments = append(segments, wrapSegment{pos, pos + len(seg), mustBreak})
+			pos += len(seg)
+		}
+		return segments
+	}
+
+	start := 0
+	for i, r := range s {
+		end := i + len(string(r))
+		switch {
+		case r == '\n':
+			segments = append(segments, wrapSegment{start, end, true})
+			start = end
+		case r == softHyphen:
+			segments = append(segments, wrapSegment{start, end, false})
+			start = end
+		case r == ' ' && (end == len(s) || (s[end] != ' ' && s[end] != '\n' && s[end] != '\r')):
+			segments = append(segments, wrapSegment{start, end, false})
+			start = end
+		}
+	}
+	if start < len(s) {
+		segments = append(segments, wrapSegment{start, len(s), true})
+	}
+	return segments
+}
+
+// joinLinkSegments joins segments ending within a hyperlink with the
+// segments following them, so no line break ends up inside the link.
+func joinLinkSegments(segments []wrapSegment, links [][2]int) []wrapSegment {
+	if len(links) == 0 {
+		return segments
+	}
+
+	inLink := func(pos int) bool {
+		for _, l := range links {
+			if pos > l[0] && pos < l[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	joined := segments[:0]
+	for _, seg := range segments {
+		if n := len(joined); n > 0 && !joined[n-1].mustBreak && inLink(joined[n-1].end) {
+			joined[n-1].end = seg.end
+			joined[n-1].mustBreak = seg.mustBreak
+			continue
+		}
+		joined = append(joined, seg)
+	}
+	return joined
+}
@@ -0,0 +1,40 @@
+package termenv
+
+import (
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	link := "\x1b]8;;https://example.com\x1b\\a long link\x1b]8;;\x1b\\"
+	tests := []struct {
+		name     string
+		in       string
+		width    int
+		mode     WrapMode
+		expected string
+	}{
+		{"fits", "hello world", 20, WrapWords, "hello world"},
+		{"words", "the quick brown fox jumps", 10, WrapWords, "the quick\nbrown fox\njumps"},
+		{"multiple spaces", "the  quick", 4, WrapWords, "the\nquick"},
+		{"overflow", "a verylongword b", 5, WrapWords, "a\nverylongword\nb"},
+		{"newlines", "foo  \nbar baz", 7, WrapWords, "foo\nbar baz"},
+		{"soft hyphen", "hyphen\u00adation rocks", 8, WrapWords, "hyphen-\nation\nrocks"},
+		{"soft hyphen unused", "hyphen\u00adation", 20, WrapWords, "hyphenation"},
+		{"styled", "\x1b[1mbold\x1b[0m text", 5, WrapWords, "\x1b[1mbold\x1b[0m\ntext"},
+		{"styled spaces", "\x1b[44mfoo \x1b[0mbar", 4, WrapWords, "\x1b[44mfoo\n\x1b[0mbar"},
+		{"link", "see " + link + " here", 8, WrapWords, "see\n" + link + "\nhere"},
+		{"uax14 hyphen", "well-known fact", 6, WrapUnicode, "well-\nknown\nfact"},
+		{"uax14 cjk", "日本語の文章", 6, WrapUnicode, "日本語\nの文章"},
+		{"words cjk", "日本語の文章", 6, WrapWords, "日本語の文章"},
+		{"uax14 soft hyphen", "hyphen\u00adation", 8, WrapUnicode, "hyphen-\nation"},
+		{"uax14 link", "see " + link, 8, WrapUnicode, "see\n" + link},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := WrapText(test.in, test.width, WithWrapMode(test.mode)); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}